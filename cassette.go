@@ -0,0 +1,307 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"reflect"
+	"sync"
+)
+
+// CassetteMode is whether a Cassette records or replays interactions.
+type CassetteMode int
+
+const (
+	// CassetteRecord sends the requests to the service and records them.
+	CassetteRecord CassetteMode = iota
+	// CassetteReplay answers the requests with the recorded responses.
+	CassetteReplay
+)
+
+// cassetteEndpoint is the endpoint used when replaying if none is given.
+const cassetteEndpoint = "http://cassette"
+
+// Cassette is an http.RoundTripper that records the requests made to a
+// service and their responses to a file, so the same code can later be run
+// against the recording, for example to turn a problem seen with a specific
+// BMC into a regression test:
+//
+//	cassette, err := gofish.NewCassette("testdata/bmc.json", gofish.CassetteRecord)
+//	c, err := cassette.Connect(gofish.ClientConfig{Endpoint: "https://bmc", ...})
+//	... use c ...
+//	err = cassette.Save()
+//
+// Then in the test:
+//
+//	cassette, err := gofish.NewCassette("testdata/bmc.json", gofish.CassetteReplay)
+//	c, err := cassette.Connect(gofish.ClientConfig{})
+//
+// Requests are matched by method, path with query and body. Recorded
+// requests are used in order, so repeated requests, such as polling a task,
+// are answered with successive responses; the last matching response is
+// used once they are exhausted. Credentials are redacted in the recording
+// as in dumps, and are ignored when matching requests.
+type Cassette struct {
+	name     string
+	mode     CassetteMode
+	redactor *redactor
+
+	// Transport is used to send the requests when recording. Defaults to the
+	// transport of the HTTPClient of the ClientConfig given to Connect, or
+	// one with its TLS settings, and to http.DefaultTransport when the
+	// cassette is used without Connect.
+	Transport http.RoundTripper
+
+	lock         sync.Mutex
+	transport    http.RoundTripper
+	interactions []*CassetteInteraction
+	used         []bool
+}
+
+// CassetteInteraction is a recorded request and its response.
+type CassetteInteraction struct {
+	Request  CassetteRequest  `json:"request"`
+	Response CassetteResponse `json:"response"`
+}
+
+// CassetteRequest is a recorded request.
+type CassetteRequest struct {
+	Method string      `json:"method"`
+	URI    string      `json:"uri"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// CassetteResponse is a recorded response.
+type CassetteResponse struct {
+	StatusCode int         `json:"status"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// cassetteFile is the content of a cassette file.
+type cassetteFile struct {
+	Interactions []*CassetteInteraction `json:"interactions"`
+}
+
+// NewCassette creates a Cassette saved to, or replayed from, the file name.
+// In replay mode the file is loaded right away.
+func NewCassette(name string, mode CassetteMode) (*Cassette, error) {
+	c := &Cassette{name: name, mode: mode, redactor: defaultRedactor}
+	if mode != CassetteReplay {
+		return c, nil
+	}
+
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	var file cassetteFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid cassette %s: %w", name, err)
+	}
+	c.interactions = file.Interactions
+	c.used = make([]bool, len(file.Interactions))
+
+	return c, nil
+}
+
+// Interactions returns the recorded or loaded interactions.
+func (c *Cassette) Interactions() []*CassetteInteraction {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return append([]*CassetteInteraction{}, c.interactions...)
+}
+
+// HTTPClient returns an http.Client using the cassette.
+func (c *Cassette) HTTPClient() *http.Client {
+	return &http.Client{Transport: c}
+}
+
+// Connect creates an APIClient using the cassette. When replaying, the
+// endpoint does not need to be set. The DumpRedactHeaders and
+// DumpRedactFields of config are also redacted in the recording.
+func (c *Cassette) Connect(config ClientConfig) (*APIClient, error) { //nolint:gocritic
+	if c.mode == CassetteReplay && config.Endpoint == "" {
+		config.Endpoint = cassetteEndpoint
+	}
+
+	// The requests are recorded as sent with the settings of config, such
+	// as Insecure or RootCAs.
+	var transport http.RoundTripper
+	if config.HTTPClient != nil {
+		transport = config.HTTPClient.Transport
+	} else if c.mode != CassetteReplay {
+		var err error
+		if transport, err = newTransport(&config); err != nil {
+			return nil, err
+		}
+	}
+	config.HTTPClient = c.HTTPClient()
+
+	c.lock.Lock()
+	c.redactor = newRedactor(config.DumpRedactHeaders, config.DumpRedactFields)
+	c.transport = transport
+	c.lock.Unlock()
+
+	return Connect(config)
+}
+
+// Save writes the recorded interactions to the cassette file.
+func (c *Cassette) Save() error {
+	c.lock.Lock()
+	data, err := json.MarshalIndent(cassetteFile{Interactions: c.interactions}, "", "  ")
+	c.lock.Unlock()
+	if err != nil {
+		return err
+	}
+
+	// The recording may contain sensitive information despite the redaction.
+	return os.WriteFile(c.name, append(data, '\n'), 0o600)
+}
+
+// RoundTrip records or replays req.
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	c.lock.Lock()
+	r, transport := c.redactor, c.transport
+	c.lock.Unlock()
+
+	recorded := CassetteRequest{
+		Method: req.Method,
+		URI:    req.URL.RequestURI(),
+		Header: redactHeader(r, req.Header),
+		Body:   string(r.redactBody(body)),
+	}
+
+	if c.mode == CassetteReplay {
+		return c.replay(req, &recorded)
+	}
+	return c.record(req, &recorded, r, transport)
+}
+
+// record sends req with transport, unless the cassette has its own, and
+// records it with its response, redacted with r.
+func (c *Cassette) record(req *http.Request, recorded *CassetteRequest, r *redactor, transport http.RoundTripper) (*http.Response, error) {
+	if c.Transport != nil {
+		transport = c.Transport
+	}
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.interactions = append(c.interactions, &CassetteInteraction{
+		Request: *recorded,
+		Response: CassetteResponse{
+			StatusCode: resp.StatusCode,
+			Header:     redactHeader(r, resp.Header),
+			Body:       string(r.redactBody(body)),
+		},
+	})
+	c.used = append(c.used, true)
+
+	return resp, nil
+}
+
+// replay answers req with the first unused matching interaction, or the last
+// matching one if they have all been used.
+func (c *Cassette) replay(req *http.Request, recorded *CassetteRequest) (*http.Response, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	match := -1
+	for i, interaction := range c.interactions {
+		if !interaction.Request.matches(recorded) {
+			continue
+		}
+		if !c.used[i] {
+			match = i
+			break
+		}
+		match = i
+	}
+	if match < 0 {
+		return nil, fmt.Errorf("cassette %s has no response for %s %s", c.name, req.Method, recorded.URI)
+	}
+	c.used[match] = true
+
+	response := c.interactions[match].Response
+	header := response.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", response.StatusCode, http.StatusText(response.StatusCode)),
+		StatusCode:    response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader([]byte(response.Body))),
+		ContentLength: int64(len(response.Body)),
+		Request:       req,
+	}, nil
+}
+
+// redactHeader returns a copy of header with the values r considers
+// sensitive redacted.
+func redactHeader(r *redactor, header http.Header) http.Header {
+	result := header.Clone()
+	for name, values := range result {
+		if r.redactHeader(name) {
+			for i := range values {
+				values[i] = redacted
+			}
+		}
+	}
+	return result
+}
+
+// matches checks if the recorded request r matches other. JSON bodies are
+// compared regardless of formatting and property order.
+func (r *CassetteRequest) matches(other *CassetteRequest) bool {
+	if r.Method != other.Method || r.URI != other.URI {
+		return false
+	}
+	if r.Body == other.Body {
+		return true
+	}
+
+	var body, otherBody interface{}
+	if json.Unmarshal([]byte(r.Body), &body) != nil || json.Unmarshal([]byte(other.Body), &otherBody) != nil {
+		return false
+	}
+	return reflect.DeepEqual(body, otherBody)
+}
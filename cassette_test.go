@@ -0,0 +1,161 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stmcginnis/gofish/mockserver"
+	"github.com/stmcginnis/gofish/redfish"
+)
+
+// cassetteServer is a service with a single system, which is powered on by
+// the first reset.
+func cassetteServer(t *testing.T) *httptest.Server {
+	powerState := "Off"
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/redfish/v1/":
+			w.Write([]byte(`{"Links": {"Sessions": {"@odata.id": "/redfish/v1/SessionService/Sessions"}}}`)) //nolint
+		case r.URL.Path == "/redfish/v1/SessionService/Sessions" && r.Method == http.MethodPost:
+			w.Header().Set("X-Auth-Token", "real-token")
+			w.Header().Set("Location", "/redfish/v1/SessionService/Sessions/1")
+			w.WriteHeader(http.StatusCreated)
+		case r.URL.Path == "/redfish/v1/Systems/1" && r.Method == http.MethodGet:
+			w.Write([]byte(`{"@odata.id": "/redfish/v1/Systems/1", "Id": "1", "PowerState": "` + powerState + `", ` + //nolint
+				`"Actions": {"#ComputerSystem.Reset": {"target": "/redfish/v1/Systems/1/Actions/ComputerSystem.Reset"}}}`))
+		case r.URL.Path == "/redfish/v1/Systems/1/Actions/ComputerSystem.Reset":
+			powerState = "On"
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+// cassetteScenario reads the system, resets it and reads it again.
+func cassetteScenario(t *testing.T, c *APIClient) (before, after redfish.PowerState) {
+	system, err := redfish.GetComputerSystem(c, "/redfish/v1/Systems/1")
+	if err != nil {
+		t.Fatalf("Error getting system: %s", err)
+	}
+	before = system.PowerState
+
+	if err := system.Reset(redfish.OnResetType); err != nil {
+		t.Fatalf("Error resetting system: %s", err)
+	}
+
+	system, err = redfish.GetComputerSystem(c, "/redfish/v1/Systems/1")
+	if err != nil {
+		t.Fatalf("Error getting system: %s", err)
+	}
+	return before, system.PowerState
+}
+
+// TestCassette tests recording interactions and replaying them.
+func TestCassette(t *testing.T) {
+	ts := cassetteServer(t)
+	name := filepath.Join(t.TempDir(), "bmc.json")
+
+	cassette, err := NewCassette(name, CassetteRecord)
+	if err != nil {
+		t.Fatalf("Error creating cassette: %s", err)
+	}
+	cassette.Transport = ts.Client().Transport
+
+	c, err := cassette.Connect(ClientConfig{Endpoint: ts.URL, Username: "admin", Password: "real-password"})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+	before, after := cassetteScenario(t, c)
+	if before != redfish.OffPowerState || after != redfish.OnPowerState {
+		t.Fatalf("Unexpected power states: %s, %s", before, after)
+	}
+	if err := cassette.Save(); err != nil {
+		t.Fatalf("Error saving cassette: %s", err)
+	}
+
+	data, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatalf("Error reading cassette: %s", err)
+	}
+	if strings.Contains(string(data), "real-password") || strings.Contains(string(data), "real-token") {
+		t.Errorf("Credentials should be redacted:\n%s", data)
+	}
+
+	ts.Close()
+
+	cassette, err = NewCassette(name, CassetteReplay)
+	if err != nil {
+		t.Fatalf("Error loading cassette: %s", err)
+	}
+	c, err = cassette.Connect(ClientConfig{Username: "admin", Password: "other-password"})
+	if err != nil {
+		t.Fatalf("Error connecting to the cassette: %s", err)
+	}
+	before, after = cassetteScenario(t, c)
+	if before != redfish.OffPowerState || after != redfish.OnPowerState {
+		t.Errorf("Expected the recorded power states, got: %s, %s", before, after)
+	}
+
+	// The last response is repeated once the recorded ones are exhausted.
+	system, err := redfish.GetComputerSystem(c, "/redfish/v1/Systems/1")
+	if err != nil || system.PowerState != redfish.OnPowerState {
+		t.Errorf("Expected the last recorded response, got: %v", err)
+	}
+
+	if _, err := c.Get("/redfish/v1/Chassis"); err == nil || !strings.Contains(err.Error(), "has no response for GET /redfish/v1/Chassis") { //nolint:bodyclose
+		t.Errorf("Expected unrecorded requests to fail, got: %v", err)
+	}
+}
+
+// TestCassetteRequestMatches tests matching requests by their JSON bodies.
+func TestCassetteRequestMatches(t *testing.T) {
+	request := CassetteRequest{Method: http.MethodPatch, URI: "/redfish/v1/Systems/1", Body: `{"A": 1, "B": {"C": "d"}}`}
+
+	tests := []struct {
+		other   CassetteRequest
+		matches bool
+	}{
+		{CassetteRequest{Method: http.MethodPatch, URI: "/redfish/v1/Systems/1", Body: `{"B":{"C":"d"},"A":1}`}, true},
+		{CassetteRequest{Method: http.MethodPatch, URI: "/redfish/v1/Systems/1", Body: `{"A": 2, "B": {"C": "d"}}`}, false},
+		{CassetteRequest{Method: http.MethodPost, URI: "/redfish/v1/Systems/1", Body: `{"A": 1, "B": {"C": "d"}}`}, false},
+		{CassetteRequest{Method: http.MethodPatch, URI: "/redfish/v1/Systems/2", Body: `{"A": 1, "B": {"C": "d"}}`}, false},
+		{CassetteRequest{Method: http.MethodPatch, URI: "/redfish/v1/Systems/1", Body: `not json`}, false},
+	}
+	for _, test := range tests {
+		if request.matches(&test.other) != test.matches {
+			t.Errorf("Expected match %t for %#v", test.matches, test.other)
+		}
+	}
+}
+
+// TestCassetteTLS tests recording with the TLS settings of the ClientConfig.
+func TestCassetteTLS(t *testing.T) {
+	server, err := mockserver.New("mockserver/testdata/simple")
+	if err != nil {
+		t.Fatalf("Error loading mockup: %s", err)
+	}
+	ts := httptest.NewTLSServer(server)
+	defer ts.Close()
+
+	cassette, err := NewCassette(filepath.Join(t.TempDir(), "bmc.json"), CassetteRecord)
+	if err != nil {
+		t.Fatalf("Error creating cassette: %s", err)
+	}
+	if _, err := cassette.Connect(ClientConfig{Endpoint: ts.URL, Insecure: true}); err != nil {
+		t.Fatalf("Error connecting to a self-signed service: %s", err)
+	}
+	if len(cassette.Interactions()) == 0 {
+		t.Error("Expected the requests to be recorded")
+	}
+}
@@ -7,7 +7,6 @@ package gofish
 import (
 	"bytes"
 	"context"
-	"crypto/tls"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -18,6 +17,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"sync"
 
 	"strings"
 	"time"
@@ -29,6 +29,14 @@ import (
 const userAgent = "gofish/1.0"
 const applicationJSON = "application/json"
 
+// DefaultCollectionWorkers is the number of collection members fetched at
+// once when ClientConfig.CollectionWorkers is not set.
+const DefaultCollectionWorkers = 4
+
+// DefaultIdleConnTimeout is how long idle connections are kept open when
+// ClientConfig.IdleConnTimeout is not set.
+const DefaultIdleConnTimeout = 90 * time.Second
+
 // APIClient represents a connection to a Redfish/Swordfish enabled service
 // or device.
 type APIClient struct {
@@ -47,8 +55,66 @@ type APIClient struct {
 	// Auth information saved for later to be able to log out
 	auth *redfish.AuthToken
 
+	// authLock guards auth, which may be replaced while re-authenticating.
+	authLock sync.Mutex
+
+	// credentials provides the credentials to create sessions or for basic
+	// authentication. basicCredentials are the ones sent with basic
+	// authentication, guarded by authLock, and kept here rather than on
+	// auth so they are never handed out with the AuthToken.
+	credentials      CredentialProvider
+	basicCredentials *Credentials
+
+	// refreshLock serializes getting new credentials or tokens, so
+	// concurrent requests failing with the same ones only get them once.
+	refreshLock sync.Mutex
+
+	// sessionCache stores the sessions created by the client, if non-nil.
+	// sessionUsername is the user of the current session in the cache.
+	sessionCache    SessionCache
+	sessionUsername string
+
+	// tokenSource provides the bearer tokens, if non-nil. token is the
+	// current one, guarded by authLock.
+	tokenSource TokenSource
+	token       *OAuth2Token
+
+	// reAuth holds the settings used to create a new session when the
+	// service invalidates the current one. It is nil unless re-authentication
+	// was requested in the ClientConfig.
+	reAuth *reAuthConfig
+
+	// keepAlive touches the session while the client is idle, if non-nil.
+	keepAlive *sessionKeepAlive
+
+	// retryPolicy controls how failed requests are retried, if at all.
+	retryPolicy *RetryPolicy
+
+	// collectionWorkers is the number of collection members fetched at once.
+	collectionWorkers int
+
+	// disableKeepAlives closes the connection after every request.
+	disableKeepAlives bool
+
 	// dumpWriter will receive HTTP dumps if non-nil.
 	dumpWriter io.Writer
+
+	// dumpRedactor removes credentials from the dumps. The default headers
+	// and properties are redacted if it is nil.
+	dumpRedactor *redactor
+
+	// harRecorder will record the HTTP traffic if non-nil.
+	harRecorder *HARRecorder
+
+	// messageResolver caches the message registries of the service. It is
+	// created on first use.
+	messageResolver     *redfish.MessageResolver
+	messageResolverLock sync.Mutex
+}
+
+// reAuthConfig holds the settings needed to re-authenticate a session.
+type reAuthConfig struct {
+	handler func(session *Session, err error)
 }
 
 // Session holds the session ID and auth token needed to identify an
@@ -69,25 +135,149 @@ type ClientConfig struct {
 	// Password is the password to use for authentication.
 	Password string
 
+	// CredentialProvider provides the credentials to authenticate with. If
+	// set, it is used instead of Username and Password, and called again
+	// whenever a session is created or, with BasicAuth, when the service
+	// rejects the credentials, so changed passwords are picked up.
+	CredentialProvider CredentialProvider
+
 	// Session is an optional session ID+token obtained from a previous session
 	// If this is set, it is preferred over Username and Password
 	Session *Session
 
+	// SessionCache stores the session created for Username, or the user of
+	// the CredentialProvider, so that later clients resume it rather than
+	// creating a new session. A cached session is checked with a GET of the
+	// session before it is used, and a new session is created if the
+	// service rejects it. Errors reading or writing the cache are ignored,
+	// a new session is then created and not cached. Logout removes the
+	// session from the cache, so clients meant to leave their session for
+	// the next ones should not log out, see DeleteCachedSessions to clean up
+	// sessions left behind.
+	SessionCache SessionCache
+
+	// BearerToken is an optional OAuth 2.0 access token sent in an
+	// Authorization: Bearer header. If this is set, it is preferred over
+	// Username and Password.
+	BearerToken string
+
+	// TokenSource provides the OAuth 2.0 access tokens to authenticate
+	// with, see ClientCredentialsTokenSource. If set, it is used instead of
+	// BearerToken. A new token is requested when the current one expired or
+	// is rejected by the service.
+	TokenSource TokenSource
+
 	// Insecure controls whether to enforce SSL certificate validity.
 	Insecure bool
 
+	// RootCAs are the PEM encoded certificates of the CAs trusted to sign
+	// the service certificate, instead of the system CAs.
+	RootCAs []byte
+
+	// RootCAsFile is the path of a PEM file with the CA certificates, used
+	// if RootCAs is not set.
+	RootCAsFile string
+
+	// ClientCertificate and ClientKey are the PEM encoded certificate and
+	// private key presented to services requiring mutual TLS, such as
+	// services mapping client certificates to accounts.
+	ClientCertificate []byte
+	ClientKey         []byte
+
+	// ClientCertificateFile and ClientKeyFile are the paths of the PEM files
+	// with the client certificate and private key, used if ClientCertificate
+	// and ClientKey are not set.
+	ClientCertificateFile string
+	ClientKeyFile         string
+
+	// PinnedPublicKeys restricts the service certificate to the ones with
+	// any of these public keys, see PublicKeyPin. Pins are checked even if
+	// Insecure is set.
+	PinnedPublicKeys []string
+
+	// MinTLSVersion is the minimum TLS version accepted, such as
+	// tls.VersionTLS12. Defaults to the minimum of the crypto/tls package.
+	MinTLSVersion uint16
+
+	// ServerName is the name checked against the service certificate, if it
+	// differs from the host of the Endpoint. For example when connecting to
+	// a BMC by IP address.
+	ServerName string
+
 	// Controls TLS handshake timeout
 	TLSHandshakeTimeout int
 
-	// HTTPClient is the optional client to connect with.
+	// DisableKeepAlives closes the connection after every request instead
+	// of reusing it for the following ones. Only set this for services that
+	// do not handle persistent connections correctly, as every request then
+	// needs a new TCP connection and TLS handshake.
+	DisableKeepAlives bool
+
+	// MaxIdleConnsPerHost is the number of idle connections kept open to
+	// the service for the following requests. Defaults to the number of
+	// CollectionWorkers.
+	MaxIdleConnsPerHost int
+
+	// IdleConnTimeout is how long idle connections are kept open. Defaults
+	// to DefaultIdleConnTimeout.
+	IdleConnTimeout time.Duration
+
+	// HTTPClient is the optional client to connect with. The TLS and
+	// connection pool settings are ignored if it is set.
 	HTTPClient *http.Client
 
 	// DumpWriter is an optional io.Writer to receive dumps of HTTP
-	// requests and responses.
+	// requests and responses. The values of the Authorization, X-Auth-Token
+	// and cookie headers, as well as password, token and SNMP community
+	// properties, are redacted. Errors writing to DumpWriter are ignored so
+	// they do not fail the requests.
 	DumpWriter io.Writer
 
+	// DumpRedactHeaders lists additional headers to redact in dumps.
+	DumpRedactHeaders []string
+
+	// DumpRedactFields lists additional JSON properties to redact in dumps,
+	// for example "Oem" properties holding credentials.
+	DumpRedactFields []string
+
+	// HARRecorder is an optional recorder of the HTTP traffic in the HTTP
+	// Archive format. The same values as in dumps are redacted.
+	HARRecorder *HARRecorder
+
 	// BasicAuth tells the APIClient if basic auth should be used (true) or token based auth must be used (false)
 	BasicAuth bool
+
+	// ReAuthenticate tells the APIClient to create a new session and replay
+	// the request once when a request using session authentication is
+	// rejected as unauthorized, for example after the BMC was rebooted or
+	// the session timed out. It only applies when Username and Password, or
+	// a CredentialProvider, are used to create a session.
+	ReAuthenticate bool
+
+	// ReAuthHandler is an optional function called after every
+	// re-authentication attempt with the new session, or the error if a new
+	// session could not be created.
+	ReAuthHandler func(session *Session, err error)
+
+	// SessionKeepAlive starts a background task reading the session when
+	// the client was idle for SessionKeepAliveInterval, so the service does
+	// not close the session due to inactivity. The task is stopped by
+	// Logout.
+	SessionKeepAlive bool
+
+	// SessionKeepAliveInterval is how long the client may be idle before
+	// the session is read. Defaults to half the SessionTimeout of the
+	// SessionService, or of DefaultSessionTimeout if it is not available.
+	SessionKeepAliveInterval time.Duration
+
+	// RetryPolicy is an optional policy to retry requests that failed with a
+	// transient error. Requests are not retried if this is nil.
+	RetryPolicy *RetryPolicy
+
+	// CollectionWorkers is the number of collection members fetched at once
+	// when listing a collection. Defaults to DefaultCollectionWorkers; set it
+	// to 1 to fetch members one at a time.
+	CollectionWorkers int
 }
 
 // setupClientWithConfig setups the client using the client config
@@ -97,27 +287,20 @@ func setupClientWithConfig(ctx context.Context, config *ClientConfig) (c *APICli
 	}
 
 	client := &APIClient{
-		endpoint:   config.Endpoint,
-		dumpWriter: config.DumpWriter,
-		ctx:        ctx,
-	}
-
-	if config.TLSHandshakeTimeout == 0 {
-		config.TLSHandshakeTimeout = 10
+		endpoint:          config.Endpoint,
+		dumpWriter:        config.DumpWriter,
+		dumpRedactor:      newRedactor(config.DumpRedactHeaders, config.DumpRedactFields),
+		harRecorder:       config.HARRecorder,
+		retryPolicy:       config.RetryPolicy,
+		collectionWorkers: config.CollectionWorkers,
+		disableKeepAlives: config.DisableKeepAlives,
+		ctx:               ctx,
 	}
 
 	if config.HTTPClient == nil {
-		defaultTransport := http.DefaultTransport.(*http.Transport)
-		transport := &http.Transport{
-			Proxy:                 defaultTransport.Proxy,
-			DialContext:           defaultTransport.DialContext,
-			MaxIdleConns:          defaultTransport.MaxIdleConns,
-			IdleConnTimeout:       defaultTransport.IdleConnTimeout,
-			ExpectContinueTimeout: defaultTransport.ExpectContinueTimeout,
-			TLSHandshakeTimeout:   time.Duration(config.TLSHandshakeTimeout) * time.Second,
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: config.Insecure,
-			},
+		transport, err := newTransport(config)
+		if err != nil {
+			return nil, err
 		}
 		client.HTTPClient = &http.Client{Transport: transport}
 	} else {
@@ -133,6 +316,44 @@ func setupClientWithConfig(ctx context.Context, config *ClientConfig) (c *APICli
 	return client, nil
 }
 
+// newTransport creates the transport to connect to the service with, using
+// the connection and TLS settings of config.
+func newTransport(config *ClientConfig) (*http.Transport, error) {
+	tlsConfig, err := newTLSConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsHandshakeTimeout := config.TLSHandshakeTimeout
+	if tlsHandshakeTimeout == 0 {
+		tlsHandshakeTimeout = 10
+	}
+	maxIdleConnsPerHost := config.MaxIdleConnsPerHost
+	if maxIdleConnsPerHost == 0 {
+		maxIdleConnsPerHost = config.CollectionWorkers
+	}
+	if maxIdleConnsPerHost <= 0 {
+		maxIdleConnsPerHost = DefaultCollectionWorkers
+	}
+	idleConnTimeout := config.IdleConnTimeout
+	if idleConnTimeout == 0 {
+		idleConnTimeout = DefaultIdleConnTimeout
+	}
+
+	defaultTransport := http.DefaultTransport.(*http.Transport)
+	return &http.Transport{
+		Proxy:                 defaultTransport.Proxy,
+		DialContext:           defaultTransport.DialContext,
+		MaxIdleConns:          defaultTransport.MaxIdleConns,
+		MaxIdleConnsPerHost:   maxIdleConnsPerHost,
+		IdleConnTimeout:       idleConnTimeout,
+		DisableKeepAlives:     config.DisableKeepAlives,
+		ExpectContinueTimeout: defaultTransport.ExpectContinueTimeout,
+		TLSHandshakeTimeout:   time.Duration(tlsHandshakeTimeout) * time.Second,
+		TLSClientConfig:       tlsConfig,
+	}, nil
+}
+
 // setupClientWithEndpoint setups the client using only the endpoint
 func setupClientWithEndpoint(ctx context.Context, endpoint string) (c *APIClient, err error) {
 	if !strings.HasPrefix(endpoint, "http") {
@@ -161,28 +382,90 @@ func (c *APIClient) setupClientAuth(config *ClientConfig) error {
 			Session: config.Session.ID,
 			Token:   config.Session.Token,
 		}
-	} else if config.Username != "" {
-		var auth *redfish.AuthToken
-		if config.BasicAuth {
-			auth = &redfish.AuthToken{
-				Username:  config.Username,
-				Password:  config.Password,
-				BasicAuth: true,
-			}
-		} else {
-			var err error
-			auth, err = c.Service.CreateSession(config.Username, config.Password)
-			if err != nil {
-				return err
-			}
+		return nil
+	}
+
+	if config.TokenSource != nil || config.BearerToken != "" {
+		c.tokenSource = config.TokenSource
+		if c.tokenSource == nil {
+			c.tokenSource = staticTokenSource(config.BearerToken)
 		}
+		return c.refreshToken(c.ctx, nil)
+	}
 
-		c.auth = auth
+	c.credentials = config.CredentialProvider
+	if c.credentials == nil && config.Username != "" {
+		c.credentials = staticCredentials{Username: config.Username, Password: config.Password}
+	}
+	if c.credentials == nil {
+		return nil
+	}
+
+	if config.BasicAuth {
+		return c.refreshCredentials(c.ctx, nil)
+	}
+
+	c.sessionCache = config.SessionCache
+	auth, err := c.resumeOrCreateSession(c.ctx)
+	if err != nil {
+		return err
+	}
+	c.auth = auth
+
+	if config.ReAuthenticate {
+		c.reAuth = &reAuthConfig{
+			handler: config.ReAuthHandler,
+		}
 	}
 
 	return nil
 }
 
+// resumeOrCreateSession resumes the cached session of the client's user, if
+// there is a valid one, or else creates a new session.
+func (c *APIClient) resumeOrCreateSession(ctx context.Context) (*redfish.AuthToken, error) {
+	if c.sessionCache != nil {
+		credentials, err := c.credentials.Credentials(ctx, c.endpoint)
+		if err != nil {
+			return nil, err
+		}
+		auth, err := c.resumeSession(ctx, credentials.Username)
+		if err != nil || auth != nil {
+			c.sessionUsername = credentials.Username
+			return auth, err
+		}
+	}
+
+	return c.createSession(ctx, c.Service)
+}
+
+// createSession creates a session on service with the credentials of the
+// client, and stores it in the session cache if there is one.
+func (c *APIClient) createSession(ctx context.Context, service *Service) (*redfish.AuthToken, error) {
+	credentials, err := c.credentials.Credentials(ctx, c.endpoint)
+	if err != nil {
+		return nil, err
+	}
+	auth, err := service.CreateSession(credentials.Username, credentials.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.sessionCache != nil {
+		// The session is usable even if it could not be cached, later
+		// clients then create their own.
+		c.sessionUsername = credentials.Username
+		_ = c.sessionCache.Store(&CachedSession{
+			Endpoint: c.endpoint,
+			Username: credentials.Username,
+			Session:  Session{ID: auth.Session, Token: auth.Token},
+			LastUsed: time.Now(),
+		})
+	}
+
+	return auth, nil
+}
+
 // Connect creates a new client connection to a Redfish service.
 func Connect(config ClientConfig) (c *APIClient, err error) { //nolint:gocritic
 	return ConnectContext(context.Background(), config)
@@ -201,6 +484,10 @@ func ConnectContext(ctx context.Context, config ClientConfig) (c *APIClient, err
 		return c, err
 	}
 
+	if config.SessionKeepAlive && client.auth != nil && client.auth.Session != "" {
+		client.startSessionKeepAlive(config.SessionKeepAliveInterval)
+	}
+
 	return client, err
 }
 
@@ -224,106 +511,317 @@ func (c *APIClient) GetService() *Service {
 	return c.Service
 }
 
+// MessageResolver returns the MessageResolver used to format the messages of
+// errors returned by the service, for example:
+//
+//	infos, err := c.MessageResolver().Resolve(err, "en")
+//
+// The message registries are cached by the client, so they are only
+// retrieved the first time a message in a given language is resolved.
+func (c *APIClient) MessageResolver() *redfish.MessageResolver {
+	c.messageResolverLock.Lock()
+	defer c.messageResolverLock.Unlock()
+
+	if c.messageResolver == nil {
+		var link string
+		if c.Service != nil {
+			link = c.Service.registries
+		}
+		c.messageResolver = redfish.NewMessageResolver(c, link)
+	}
+	return c.messageResolver
+}
+
+// CollectionWorkers returns the number of collection members the APIClient
+// fetches at once.
+func (c *APIClient) CollectionWorkers() int {
+	if c.collectionWorkers <= 0 {
+		return DefaultCollectionWorkers
+	}
+	return c.collectionWorkers
+}
+
+// CollectionExpandQuery returns the $expand query parameter value used to
+// retrieve collection members along with the collection, or an empty string
+// if the service does not support expanding collections.
+func (c *APIClient) CollectionExpandQuery() string {
+	if c.Service == nil {
+		return ""
+	}
+
+	// Prefer expanding only the members, not the Links section.
+	expand := c.Service.ProtocolFeaturesSupported.ExpandQuery
+	var query string
+	switch {
+	case expand.NoLinks:
+		query = "."
+	case expand.ExpandAll:
+		query = "*"
+	default:
+		return ""
+	}
+	if expand.Levels {
+		query += "($levels=1)"
+	}
+	return query
+}
+
+// QueryFeatures returns the query parameters supported by the service.
+func (c *APIClient) QueryFeatures() common.QueryFeatures {
+	if c.Service == nil {
+		return common.QueryFeatures{}
+	}
+
+	features := c.Service.ProtocolFeaturesSupported
+	return common.QueryFeatures{
+		Select:  features.SelectQuery,
+		Filter:  features.FilterQuery,
+		TopSkip: features.TopSkipQuery,
+		Only:    features.OnlyMemberQuery,
+		Excerpt: features.ExcerptQuery,
+	}
+}
+
 // CloneWithSession will create a new Client with a session instead of basic auth.
 func (c *APIClient) CloneWithSession() (*APIClient, error) {
 	if c.auth.Session != "" {
 		return nil, fmt.Errorf("client already has a session")
 	}
+	if c.auth.BearerAuth {
+		return nil, fmt.Errorf("client uses bearer token authentication")
+	}
+
+	if c.credentials == nil {
+		return nil, fmt.Errorf("client has no credentials")
+	}
 
-	newClient := *c
-	newClient.HTTPClient = c.HTTPClient
-	service, err := ServiceRoot(&newClient)
+	// The new client gets the credentials from the provider itself rather
+	// than copying the ones of this client.
+	newClient := &APIClient{
+		ctx:               c.ctx,
+		endpoint:          c.endpoint,
+		HTTPClient:        c.HTTPClient,
+		credentials:       c.credentials,
+		retryPolicy:       c.retryPolicy,
+		collectionWorkers: c.collectionWorkers,
+		disableKeepAlives: c.disableKeepAlives,
+		dumpWriter:        c.dumpWriter,
+		dumpRedactor:      c.dumpRedactor,
+		harRecorder:       c.harRecorder,
+	}
+	if err := newClient.refreshCredentials(newClient.ctx, nil); err != nil {
+		return nil, err
+	}
+	service, err := ServiceRoot(newClient)
 	if err != nil {
 		return nil, err
 	}
 	newClient.Service = service
 
-	auth, err := newClient.Service.CreateSession(
-		newClient.auth.Username,
-		newClient.auth.Password)
+	auth, err := newClient.createSession(newClient.ctx, newClient.Service)
 	if err != nil {
 		return nil, err
 	}
 	newClient.auth = auth
+	newClient.basicCredentials = nil
 
-	return &newClient, err
+	return newClient, err
 }
 
 // GetSession retrieves the session data from an initialized APIClient. An error
 // is returned if the client is not authenticated.
 func (c *APIClient) GetSession() (*Session, error) {
-	if c.auth == nil || c.auth.Session == "" {
+	auth := c.currentAuth()
+	if auth == nil || auth.Session == "" {
 		return nil, fmt.Errorf("client not authenticated")
 	}
 	return &Session{
-		ID:    c.auth.Session,
-		Token: c.auth.Token,
+		ID:    auth.Session,
+		Token: auth.Token,
 	}, nil
 }
 
+// currentAuth returns the auth information currently used by the client.
+func (c *APIClient) currentAuth() *redfish.AuthToken {
+	c.authLock.Lock()
+	defer c.authLock.Unlock()
+	return c.auth
+}
+
+// reAuthenticate creates a new session to replace the failed one. If another
+// request already replaced the failed session, the new session is reused.
+func (c *APIClient) reAuthenticate(ctx context.Context, failed *redfish.AuthToken) error {
+	c.authLock.Lock()
+	defer c.authLock.Unlock()
+
+	if c.auth != failed {
+		return nil
+	}
+
+	// The session is created through a client without any credentials so the
+	// login request itself is never re-authenticated.
+	sessionClient := &APIClient{
+		ctx:               ctx,
+		endpoint:          c.endpoint,
+		HTTPClient:        c.HTTPClient,
+		disableKeepAlives: c.disableKeepAlives,
+		dumpWriter:        c.dumpWriter,
+		dumpRedactor:      c.dumpRedactor,
+		harRecorder:       c.harRecorder,
+	}
+	service := *c.Service
+	service.SetClient(sessionClient)
+
+	auth, err := c.createSession(ctx, &service)
+	if err != nil {
+		if c.reAuth.handler != nil {
+			c.reAuth.handler(nil, err)
+		}
+		return err
+	}
+
+	c.auth = auth
+	if c.reAuth.handler != nil {
+		c.reAuth.handler(&Session{ID: auth.Session, Token: auth.Token}, nil)
+	}
+
+	return nil
+}
+
+// shouldReAuthenticate checks if a failed request should be retried with a
+// new session.
+func (c *APIClient) shouldReAuthenticate(auth *redfish.AuthToken, err error) bool {
+	if c.reAuth == nil || c.Service == nil || auth == nil || auth.Session == "" {
+		return false
+	}
+
+	e, ok := err.(*common.Error)
+	return ok && e.HTTPReturnedStatusCode == http.StatusUnauthorized
+}
+
 // Get performs a GET request against the Redfish service.
 func (c *APIClient) Get(url string) (*http.Response, error) {
-	return c.GetWithHeaders(url, nil)
+	return c.GetWithHeadersContext(c.ctx, url, nil)
+}
+
+// GetWithContext is the same as Get, but uses ctx for the request.
+func (c *APIClient) GetWithContext(ctx context.Context, url string) (*http.Response, error) {
+	return c.GetWithHeadersContext(ctx, url, nil)
 }
 
 // GetWithHeaders performs a GET request against the Redfish service but allowing custom headers
 func (c *APIClient) GetWithHeaders(url string, customHeaders map[string]string) (*http.Response, error) {
+	return c.GetWithHeadersContext(c.ctx, url, customHeaders)
+}
+
+// GetWithHeadersContext is the same as GetWithHeaders, but uses ctx for the request.
+func (c *APIClient) GetWithHeadersContext(ctx context.Context, url string, customHeaders map[string]string) (*http.Response, error) {
 	relativePath := url
 	if relativePath == "" {
 		relativePath = common.DefaultServiceRoot
 	}
 
-	return c.runRequestWithHeaders(http.MethodGet, relativePath, nil, customHeaders)
+	return c.runRequestWithHeaders(ctx, http.MethodGet, relativePath, nil, customHeaders)
 }
 
 // Post performs a Post request against the Redfish service.
 func (c *APIClient) Post(url string, payload interface{}) (*http.Response, error) {
-	return c.PostWithHeaders(url, payload, nil)
+	return c.PostWithHeadersContext(c.ctx, url, payload, nil)
+}
+
+// PostWithContext is the same as Post, but uses ctx for the request.
+func (c *APIClient) PostWithContext(ctx context.Context, url string, payload interface{}) (*http.Response, error) {
+	return c.PostWithHeadersContext(ctx, url, payload, nil)
 }
 
 // PostWithHeaders performs a Post request against the Redfish service but allowing custom headers
 func (c *APIClient) PostWithHeaders(url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
-	return c.runRequestWithHeaders(http.MethodPost, url, payload, customHeaders)
+	return c.PostWithHeadersContext(c.ctx, url, payload, customHeaders)
+}
+
+// PostWithHeadersContext is the same as PostWithHeaders, but uses ctx for the request.
+func (c *APIClient) PostWithHeadersContext(ctx context.Context, url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
+	return c.runRequestWithHeaders(ctx, http.MethodPost, url, payload, customHeaders)
 }
 
 // PostMultipart performs a Post request against the Redfish service with multipart payload.
 func (c *APIClient) PostMultipart(url string, payload map[string]io.Reader) (*http.Response, error) {
-	return c.PostMultipartWithHeaders(url, payload, nil)
+	return c.PostMultipartWithHeadersContext(c.ctx, url, payload, nil)
+}
+
+// PostMultipartWithContext is the same as PostMultipart, but uses ctx for the request.
+func (c *APIClient) PostMultipartWithContext(ctx context.Context, url string, payload map[string]io.Reader) (*http.Response, error) {
+	return c.PostMultipartWithHeadersContext(ctx, url, payload, nil)
 }
 
 // PostMultipartWithHeadersperforms a Post request against the Redfish service with multipart payload but allowing custom headers
 func (c *APIClient) PostMultipartWithHeaders(url string, payload map[string]io.Reader, customHeaders map[string]string) (*http.Response, error) {
-	return c.runRequestWithMultipartPayloadWithHeaders(http.MethodPost, url, payload, customHeaders)
+	return c.PostMultipartWithHeadersContext(c.ctx, url, payload, customHeaders)
+}
+
+// PostMultipartWithHeadersContext is the same as PostMultipartWithHeaders, but uses ctx for the request.
+func (c *APIClient) PostMultipartWithHeadersContext(ctx context.Context, url string, payload map[string]io.Reader, customHeaders map[string]string) (*http.Response, error) {
+	return c.runRequestWithMultipartPayloadWithHeaders(ctx, http.MethodPost, url, payload, customHeaders)
 }
 
 // Put performs a Put request against the Redfish service.
 func (c *APIClient) Put(url string, payload interface{}) (*http.Response, error) {
-	return c.PutWithHeaders(url, payload, nil)
+	return c.PutWithHeadersContext(c.ctx, url, payload, nil)
+}
+
+// PutWithContext is the same as Put, but uses ctx for the request.
+func (c *APIClient) PutWithContext(ctx context.Context, url string, payload interface{}) (*http.Response, error) {
+	return c.PutWithHeadersContext(ctx, url, payload, nil)
 }
 
 // PutWithHeaders performs a Put request against the Redfish service but allowing custom headers
 func (c *APIClient) PutWithHeaders(url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
-	return c.runRequestWithHeaders(http.MethodPut, url, payload, customHeaders)
+	return c.PutWithHeadersContext(c.ctx, url, payload, customHeaders)
+}
+
+// PutWithHeadersContext is the same as PutWithHeaders, but uses ctx for the request.
+func (c *APIClient) PutWithHeadersContext(ctx context.Context, url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
+	return c.runRequestWithHeaders(ctx, http.MethodPut, url, payload, customHeaders)
 }
 
 // Patch performs a Patch request against the Redfish service.
 func (c *APIClient) Patch(url string, payload interface{}) (*http.Response, error) {
-	return c.PatchWithHeaders(url, payload, nil)
+	return c.PatchWithHeadersContext(c.ctx, url, payload, nil)
+}
+
+// PatchWithContext is the same as Patch, but uses ctx for the request.
+func (c *APIClient) PatchWithContext(ctx context.Context, url string, payload interface{}) (*http.Response, error) {
+	return c.PatchWithHeadersContext(ctx, url, payload, nil)
 }
 
 // PatchWithHeaders performs a Patch request against the Redfish service but allowing custom headers
 func (c *APIClient) PatchWithHeaders(url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
-	return c.runRequestWithHeaders(http.MethodPatch, url, payload, customHeaders)
+	return c.PatchWithHeadersContext(c.ctx, url, payload, customHeaders)
+}
+
+// PatchWithHeadersContext is the same as PatchWithHeaders, but uses ctx for the request.
+func (c *APIClient) PatchWithHeadersContext(ctx context.Context, url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
+	return c.runRequestWithHeaders(ctx, http.MethodPatch, url, payload, customHeaders)
 }
 
 // Delete performs a Delete request against the Redfish service
 func (c *APIClient) Delete(url string) (*http.Response, error) {
-	return c.DeleteWithHeaders(url, nil)
+	return c.DeleteWithHeadersContext(c.ctx, url, nil)
+}
+
+// DeleteWithContext is the same as Delete, but uses ctx for the request.
+func (c *APIClient) DeleteWithContext(ctx context.Context, url string) (*http.Response, error) {
+	return c.DeleteWithHeadersContext(ctx, url, nil)
 }
 
 // DeleteWithHeaders performs a Delete request against the Redfish service but allowing custom headers
 func (c *APIClient) DeleteWithHeaders(url string, customHeaders map[string]string) (*http.Response, error) {
-	resp, err := c.runRequestWithHeaders(http.MethodDelete, url, nil, customHeaders)
+	return c.DeleteWithHeadersContext(c.ctx, url, customHeaders)
+}
+
+// DeleteWithHeadersContext is the same as DeleteWithHeaders, but uses ctx for the request.
+func (c *APIClient) DeleteWithHeadersContext(ctx context.Context, url string, customHeaders map[string]string) (*http.Response, error) {
+	resp, err := c.runRequestWithHeaders(ctx, http.MethodDelete, url, nil, customHeaders)
 	if err != nil {
 		return nil, err
 	}
@@ -334,7 +832,7 @@ func (c *APIClient) DeleteWithHeaders(url string, customHeaders map[string]strin
 }
 
 // runRequestWithHeaders performs JSON REST calls but allowing custom headers
-func (c *APIClient) runRequestWithHeaders(method, url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
+func (c *APIClient) runRequestWithHeaders(ctx context.Context, method, url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
 	if url == "" {
 		return nil, fmt.Errorf("unable to execute request, no target provided")
 	}
@@ -348,11 +846,11 @@ func (c *APIClient) runRequestWithHeaders(method, url string, payload interface{
 		payloadBuffer = bytes.NewReader(body)
 	}
 
-	return c.runRawRequestWithHeaders(method, url, payloadBuffer, applicationJSON, customHeaders)
+	return c.runRawRequestWithHeaders(ctx, method, url, payloadBuffer, applicationJSON, customHeaders)
 }
 
 // runRequestWithMultipartPayloadWithHeaders performs REST calls with a multipart payload but allowing custom headers
-func (c *APIClient) runRequestWithMultipartPayloadWithHeaders(method, url string, payload map[string]io.Reader, customHeaders map[string]string) (*http.Response, error) {
+func (c *APIClient) runRequestWithMultipartPayloadWithHeaders(ctx context.Context, method, url string, payload map[string]io.Reader, customHeaders map[string]string) (*http.Response, error) {
 	if url == "" {
 		return nil, fmt.Errorf("unable to execute request, no target provided")
 	}
@@ -379,27 +877,153 @@ func (c *APIClient) runRequestWithMultipartPayloadWithHeaders(method, url string
 	}
 	payloadWriter.Close()
 
-	return c.runRawRequestWithHeaders(method, url, bytes.NewReader(payloadBuffer.Bytes()), payloadWriter.FormDataContentType(), customHeaders)
+	return c.runRawRequestWithHeaders(ctx, method, url, bytes.NewReader(payloadBuffer.Bytes()), payloadWriter.FormDataContentType(), customHeaders)
 }
 
 // runRawRequest actually performs the REST calls
 func (c *APIClient) runRawRequest(method, url string, payloadBuffer io.ReadSeeker, contentType string) (*http.Response, error) {
-	return c.runRawRequestWithHeaders(method, url, payloadBuffer, contentType, nil)
+	return c.runRawRequestWithHeaders(c.ctx, method, url, payloadBuffer, contentType, nil)
 }
 
 // RunRawRequestWithHeaders actually performs the REST calls but allowing custom headers
 func (c *APIClient) RunRawRequestWithHeaders(method, url string, payloadBuffer io.ReadSeeker, contentType string, customHeaders map[string]string) (*http.Response, error) {
-	return c.runRawRequestWithHeaders(method, url, payloadBuffer, contentType, customHeaders)
+	return c.runRawRequestWithHeaders(c.ctx, method, url, payloadBuffer, contentType, customHeaders)
+}
+
+// RunRawRequestWithHeadersContext is the same as RunRawRequestWithHeaders, but uses ctx for the request.
+func (c *APIClient) RunRawRequestWithHeadersContext(ctx context.Context, method, url string, payloadBuffer io.ReadSeeker, contentType string, customHeaders map[string]string) (*http.Response, error) {
+	return c.runRawRequestWithHeaders(ctx, method, url, payloadBuffer, contentType, customHeaders)
 }
 
 // runRawRequestWithHeaders actually performs the REST calls but allowing custom headers
-func (c *APIClient) runRawRequestWithHeaders(method, url string, payloadBuffer io.ReadSeeker, contentType string, customHeaders map[string]string) (*http.Response, error) {
+func (c *APIClient) runRawRequestWithHeaders(ctx context.Context, method, url string, payloadBuffer io.ReadSeeker, contentType string, customHeaders map[string]string) (*http.Response, error) {
 	if url == "" {
 		return nil, common.ConstructError(0, []byte("unable to execute request, no target provided"))
 	}
 
+	ctx, cancel := c.requestContext(ctx)
+	resp, err := c.sendRequest(ctx, method, url, payloadBuffer, contentType, customHeaders)
+	if cancel != nil {
+		if err != nil {
+			cancel()
+		} else {
+			resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+		}
+	}
+
+	return resp, err
+}
+
+// requestContext returns the context to use for a single request, which is
+// done as soon as either ctx or the client's context is done. The returned
+// cancel function is nil if no new context had to be created.
+func (c *APIClient) requestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	switch {
+	case ctx == nil || ctx == c.ctx || ctx.Done() == nil:
+		return c.ctx, nil
+	case c.ctx == nil || c.ctx.Done() == nil:
+		return ctx, nil
+	}
+
+	merged, cancel := context.WithCancel(ctx)
+	go func() {
+		select {
+		case <-c.ctx.Done():
+			cancel()
+		case <-merged.Done():
+		}
+	}()
+
+	return merged, cancel
+}
+
+// cancelOnClose releases the context of a request once its response body has
+// been closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close closes the body and releases the request context.
+func (b *cancelOnClose) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
+
+// sendRequest sends the request, replaying it after re-authenticating or
+// retrying it according to the retry policy if needed.
+func (c *APIClient) sendRequest(ctx context.Context, method, url string, payloadBuffer io.ReadSeeker, contentType string, customHeaders map[string]string) (*http.Response, error) {
+	reAuthenticated := false
+	for attempt := 1; ; attempt++ {
+		auth, err := c.requestAuth(ctx)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.doRequest(ctx, method, url, payloadBuffer, contentType, customHeaders, auth)
+		if err == nil {
+			return resp, nil
+		}
+
+		switch {
+		case !reAuthenticated && c.shouldRefreshToken(auth, err):
+			// The token was revoked or expired early, get a new one and
+			// replay the request. This does not count as a retry.
+			reAuthenticated = true
+			attempt--
+			if err := c.refreshToken(ctx, auth); err != nil {
+				return nil, err
+			}
+		case !reAuthenticated && c.shouldRefreshCredentials(auth, err):
+			// The password may have changed, get the credentials again and
+			// replay the request. This does not count as a retry.
+			reAuthenticated = true
+			attempt--
+			if err := c.refreshCredentials(ctx, auth); err != nil {
+				return nil, err
+			}
+		case !reAuthenticated && c.shouldReAuthenticate(auth, err):
+			// The session is no longer valid, log in again and replay the
+			// request. This does not count as a retry.
+			reAuthenticated = true
+			attempt--
+			if err := c.reAuthenticate(ctx, auth); err != nil {
+				return nil, err
+			}
+		case c.retryPolicy.retryable(method, attempt, err):
+			if err := wait(ctx, c.retryPolicy.backoff(attempt, resp)); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, err
+		}
+
+		if payloadBuffer != nil {
+			if _, err := payloadBuffer.Seek(0, io.SeekStart); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// wait blocks for the given delay, or until ctx is done.
+func wait(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// doRequest sends a single request using the provided auth information. If the
+// service returns an unsuccessful status code, the response is returned with
+// its body already consumed and closed, along with the resulting error.
+func (c *APIClient) doRequest(ctx context.Context, method, url string, payloadBuffer io.ReadSeeker, contentType string, customHeaders map[string]string, auth *redfish.AuthToken) (*http.Response, error) {
 	endpoint := fmt.Sprintf("%s%s", c.endpoint, url)
-	req, err := http.NewRequestWithContext(c.ctx, method, endpoint, payloadBuffer)
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, payloadBuffer)
 	if err != nil {
 		return nil, err
 	}
@@ -434,15 +1058,19 @@ func (c *APIClient) runRawRequestWithHeaders(method, url string, payloadBuffer i
 	}
 
 	// Add auth info if authenticated
-	if c.auth != nil {
-		if c.auth.Token != "" {
-			req.Header.Set("X-Auth-Token", c.auth.Token)
-		} else if c.auth.BasicAuth && c.auth.Username != "" && c.auth.Password != "" {
-			encodedAuth := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%v:%v", c.auth.Username, c.auth.Password)))
-			req.Header.Set("Authorization", fmt.Sprintf("Basic %v", encodedAuth))
+	if auth != nil {
+		if auth.BearerAuth {
+			req.Header.Set("Authorization", "Bearer "+auth.Token)
+		} else if auth.Token != "" {
+			req.Header.Set("X-Auth-Token", auth.Token)
+		} else if auth.BasicAuth {
+			if credentials := c.currentCredentials(); credentials != nil && credentials.Username != "" && credentials.Password != "" {
+				encodedAuth := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%v:%v", credentials.Username, credentials.Password)))
+				req.Header.Set("Authorization", fmt.Sprintf("Basic %v", encodedAuth))
+			}
 		}
 	}
-	req.Close = true
+	req.Close = c.disableKeepAlives
 
 	// Dump request if needed.
 	if c.dumpWriter != nil {
@@ -451,9 +1079,24 @@ func (c *APIClient) runRawRequestWithHeaders(method, url string, payloadBuffer i
 		}
 	}
 
+	if c.keepAlive != nil {
+		c.keepAlive.touch()
+	}
+
+	start := time.Now()
 	resp, err := c.HTTPClient.Do(req)
+	if c.harRecorder != nil {
+		// A response cut off while it is recorded fails as if it was read.
+		if readErr := c.harRecorder.record(req, start, resp, err, c.redactor()); err == nil && readErr != nil {
+			resp, err = nil, readErr
+		}
+	}
 	if err != nil {
-		return nil, err
+		// Cancellation is reported as is rather than as a network failure.
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		return nil, &common.TransportError{Err: err}
 	}
 
 	// Dump response if needed.
@@ -466,11 +1109,11 @@ func (c *APIClient) runRawRequestWithHeaders(method, url string, payloadBuffer i
 
 	if resp.StatusCode != 200 && resp.StatusCode != 201 && resp.StatusCode != 202 && resp.StatusCode != 204 {
 		payload, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
 		if err != nil {
-			return nil, common.ConstructError(0, []byte(err.Error()))
+			return resp, &common.TransportError{Err: err}
 		}
-		defer resp.Body.Close()
-		return nil, common.ConstructError(resp.StatusCode, payload)
+		return resp, common.ConstructError(resp.StatusCode, payload)
 	}
 
 	return resp, err
@@ -483,36 +1126,47 @@ func (c *APIClient) dumpRequest(req *http.Request) error {
 		return common.ConstructError(0, []byte(err.Error()))
 	}
 
-	d = append(d, '\n')
-	_, err = c.dumpWriter.Write(d)
-	if err != nil {
-		panic(err)
-	}
-
+	c.writeDump(d)
 	return nil
 }
 
-// dumpRequest writes incoming responses to dumpWriter
+// dumpResponse writes incoming responses to dumpWriter
 func (c *APIClient) dumpResponse(resp *http.Response) error {
 	d, err := httputil.DumpResponse(resp, true)
 	if err != nil {
 		return common.ConstructError(0, []byte(err.Error()))
 	}
 
-	d = append(d, '\n')
-	_, err = c.dumpWriter.Write(d)
-	if err != nil {
-		panic(err)
-	}
-
+	c.writeDump(d)
 	return nil
 }
 
+// writeDump redacts d and writes it to dumpWriter. Write errors are ignored,
+// as failing to log a request is no reason to fail the request itself.
+func (c *APIClient) writeDump(d []byte) {
+	d = append(c.redactor().redact(d), '\n')
+	_, _ = c.dumpWriter.Write(d)
+}
+
+// redactor returns the redactor for dumps and recordings.
+func (c *APIClient) redactor() *redactor {
+	if c.dumpRedactor == nil {
+		return defaultRedactor
+	}
+	return c.dumpRedactor
+}
+
 // Logout will delete any active session. Useful to defer logout when creating
 // a new connection.
 func (c *APIClient) Logout() {
-	if c.Service != nil && c.auth != nil {
-		_ = c.Service.DeleteSession(c.auth.Session)
+	c.stopSessionKeepAlive()
+
+	auth := c.currentAuth()
+	if c.Service != nil && auth != nil {
+		_ = c.Service.DeleteSession(auth.Session)
+	}
+	if c.sessionCache != nil {
+		_ = c.sessionCache.Delete(c.endpoint, c.sessionUsername)
 	}
 }
 
@@ -520,3 +1174,9 @@ func (c *APIClient) Logout() {
 func (c *APIClient) SetDumpWriter(writer io.Writer) {
 	c.dumpWriter = writer
 }
+
+// SetHARRecorder sets the recorder of the HTTP traffic dynamically. Recording
+// stops if recorder is nil.
+func (c *APIClient) SetHARRecorder(recorder *HARRecorder) {
+	c.harRecorder = recorder
+}
@@ -8,12 +8,19 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/mockserver"
+	"github.com/stmcginnis/gofish/redfish"
 )
 
 const (
@@ -200,3 +207,357 @@ func TestClientRunRawRequestNoURL(t *testing.T) {
 		t.Errorf("Unexpected error response: %s", err.Error())
 	}
 }
+
+// reAuthServer returns a service that hands out a new token for every session
+// created and only accepts the most recent one.
+func reAuthServer(t *testing.T) (*httptest.Server, *int) {
+	sessions := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/redfish/v1/":
+			w.Write([]byte(`{"Links": {"Sessions": {"@odata.id": "/redfish/v1/SessionService/Sessions"}}}`)) //nolint
+		case r.Method == http.MethodPost && r.URL.Path == "/redfish/v1/SessionService/Sessions":
+			sessions++
+			w.Header().Set("X-Auth-Token", fmt.Sprintf("token-%d", sessions))
+			w.Header().Set("Location", fmt.Sprintf("/redfish/v1/SessionService/Sessions/%d", sessions))
+			w.WriteHeader(http.StatusCreated)
+		case r.Header.Get("X-Auth-Token") != fmt.Sprintf("token-%d", sessions):
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			body, _ := io.ReadAll(r.Body)
+			w.Write(body) //nolint
+		}
+	}))
+	t.Cleanup(ts.Close)
+	return ts, &sessions
+}
+
+// TestReAuthenticate tests that an invalidated session is replaced and the
+// request replayed.
+func TestReAuthenticate(t *testing.T) {
+	ts, sessions := reAuthServer(t)
+
+	var handled []*Session
+	c, err := Connect(ClientConfig{
+		Endpoint:       ts.URL,
+		HTTPClient:     ts.Client(),
+		Username:       "admin",
+		Password:       "password",
+		ReAuthenticate: true,
+		ReAuthHandler: func(session *Session, err error) {
+			if err != nil {
+				t.Errorf("Unexpected re-authentication error: %s", err)
+			}
+			handled = append(handled, session)
+		},
+	})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	// Simulate the service dropping the session
+	*sessions++
+
+	resp, err := c.Post("/redfish/v1/Systems/1", map[string]string{"AssetTag": "replayed"})
+	if err != nil {
+		t.Fatalf("Request should have been replayed: %s", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != `{"AssetTag":"replayed"}` {
+		t.Errorf("Payload was not resent: %s", body)
+	}
+
+	if len(handled) != 1 || handled[0].Token != "token-3" {
+		t.Errorf("Expected one re-authentication event, got: %#v", handled)
+	}
+
+	session, _ := c.GetSession()
+	if session.ID != "/redfish/v1/SessionService/Sessions/3" {
+		t.Errorf("Client should use the new session, got: %s", session.ID)
+	}
+}
+
+// TestReAuthenticateDisabled tests that a 401 is returned if re-authentication
+// was not requested.
+func TestReAuthenticateDisabled(t *testing.T) {
+	ts, sessions := reAuthServer(t)
+
+	c, err := Connect(ClientConfig{
+		Endpoint:   ts.URL,
+		HTTPClient: ts.Client(),
+		Username:   "admin",
+		Password:   "password",
+	})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	*sessions++
+
+	_, err = c.Get("/redfish/v1/Systems/1") //nolint:bodyclose
+	errStruct, ok := err.(*common.Error)
+	if !ok || errStruct.HTTPReturnedStatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected unauthorized error, got: %v", err)
+	}
+}
+
+// TestRequestContextCancel tests that a per-call context only affects that call.
+func TestRequestContextCancel(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer ts.Close()
+
+	c := &APIClient{
+		ctx:        context.Background(),
+		endpoint:   ts.URL,
+		HTTPClient: ts.Client(),
+		auth:       &redfish.AuthToken{},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := c.GetWithContext(ctx, "/redfish/v1/") //nolint:bodyclose
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got: %v", err)
+	}
+
+	resp, err := c.Get("/redfish/v1/")
+	if err != nil {
+		t.Fatalf("Client should still be usable: %s", err)
+	}
+	resp.Body.Close()
+}
+
+// TestTransportError tests that network failures are reported as transport
+// errors and error responses are not.
+func TestTransportError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+
+	c := &APIClient{
+		ctx:        context.Background(),
+		endpoint:   ts.URL,
+		HTTPClient: ts.Client(),
+		auth:       &redfish.AuthToken{},
+	}
+
+	_, err := c.Get("/redfish/v1/Systems/missing") //nolint:bodyclose
+	if !common.IsNotFound(err) || common.IsTransportError(err) {
+		t.Errorf("Expected a not found error response, got: %v", err)
+	}
+
+	ts.Close()
+
+	_, err = c.Get("/redfish/v1/") //nolint:bodyclose
+	if !common.IsTransportError(err) {
+		t.Errorf("Expected a transport error, got: %#v", err)
+	}
+}
+
+// expandServer returns a service with a Chassis collection of two members.
+// Expanded requests fail with rejectStatus unless it is zero. Every request
+// path and query is recorded.
+func expandServer(t *testing.T, rejectStatus int) (*httptest.Server, *[]string) {
+	var requests []string
+	var lock sync.Mutex
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		requests = append(requests, r.URL.RequestURI())
+		lock.Unlock()
+		member := `{"@odata.id": "/redfish/v1/Chassis/%[1]d", "Id": "%[1]d", "AssetTag": "tag-%[1]d"}`
+		switch {
+		case r.URL.Path == "/redfish/v1/":
+			w.Write([]byte(`{
+				"Chassis": {"@odata.id": "/redfish/v1/Chassis"},
+				"ProtocolFeaturesSupported": {"ExpandQuery": {"ExpandAll": true, "Levels": true, "NoLinks": true}}
+			}`)) //nolint
+		case r.URL.Path == "/redfish/v1/Chassis" && r.URL.Query().Get("$expand") != "":
+			if rejectStatus != 0 {
+				w.WriteHeader(rejectStatus)
+				return
+			}
+			w.Write([]byte(`{"Members@odata.count": 2, "Members": [` + //nolint
+				fmt.Sprintf(member, 1) + "," + fmt.Sprintf(member, 2) + `]}`))
+		case r.URL.Path == "/redfish/v1/Chassis":
+			w.Write([]byte(`{"Members@odata.count": 2, "Members": [
+				{"@odata.id": "/redfish/v1/Chassis/1"}, {"@odata.id": "/redfish/v1/Chassis/2"}]}`)) //nolint
+		case r.URL.Path == "/redfish/v1/Chassis/1":
+			w.Write([]byte(fmt.Sprintf(member, 1))) //nolint
+		case r.URL.Path == "/redfish/v1/Chassis/2":
+			w.Write([]byte(fmt.Sprintf(member, 2))) //nolint
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(ts.Close)
+	return ts, &requests
+}
+
+// TestCollectionExpand tests that collection members are retrieved in a
+// single request when the service supports $expand.
+func TestCollectionExpand(t *testing.T) {
+	ts, requests := expandServer(t, 0)
+
+	c, err := Connect(ClientConfig{Endpoint: ts.URL, HTTPClient: ts.Client()})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	chassis, err := c.Service.Chassis()
+	if err != nil {
+		t.Fatalf("Error listing chassis: %s", err)
+	}
+
+	if len(chassis) != 2 || chassis[0].AssetTag != "tag-1" || chassis[1].AssetTag != "tag-2" {
+		t.Errorf("Unexpected chassis: %v", chassis)
+	}
+
+	expected := []string{"/redfish/v1/", "/redfish/v1/Chassis?$expand=.($levels=1)"}
+	if fmt.Sprint(*requests) != fmt.Sprint(expected) {
+		t.Errorf("Expected requests %v, got %v", expected, *requests)
+	}
+
+	// Expanded members should still be usable for further requests.
+	chassis[0].AssetTag = "updated"
+	if err := chassis[0].Update(); err != nil {
+		t.Errorf("Error updating expanded chassis: %s", err)
+	}
+	last := (*requests)[len(*requests)-1]
+	if last != "/redfish/v1/Chassis/1" {
+		t.Errorf("Expected update to be sent to the chassis, got %s", last)
+	}
+}
+
+// TestCollectionExpandFallback tests that members are retrieved one at a time
+// if the service rejects the expanded request.
+func TestCollectionExpandFallback(t *testing.T) {
+	for _, status := range []int{http.StatusBadRequest, http.StatusNotImplemented} {
+		ts, _ := expandServer(t, status)
+
+		c, err := Connect(ClientConfig{Endpoint: ts.URL, HTTPClient: ts.Client()})
+		if err != nil {
+			t.Fatalf("Error connecting: %s", err)
+		}
+
+		chassis, err := c.Service.Chassis()
+		if err != nil {
+			t.Fatalf("Error listing chassis: %s", err)
+		}
+
+		if len(chassis) != 2 || chassis[0].AssetTag != "tag-1" || chassis[1].AssetTag != "tag-2" {
+			t.Errorf("Unexpected chassis: %v", chassis)
+		}
+	}
+
+	// Other failures are returned rather than hidden by a second request.
+	ts, requests := expandServer(t, http.StatusInternalServerError)
+	c, err := Connect(ClientConfig{Endpoint: ts.URL, HTTPClient: ts.Client()})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+	if _, err := c.Service.Chassis(); err == nil {
+		t.Error("Expected the service error")
+	}
+	if len(*requests) != 2 {
+		t.Errorf("Expected no fallback request, got %v", *requests)
+	}
+}
+
+// TestCollectionExpandQuery tests that only the $expand values the service
+// supports are used.
+func TestCollectionExpandQuery(t *testing.T) {
+	tests := []struct {
+		expand   Expand
+		expected string
+	}{
+		{Expand{}, ""},
+		{Expand{Levels: true}, ""},
+		{Expand{Links: true}, ""},
+		{Expand{ExpandAll: true}, "*"},
+		{Expand{ExpandAll: true, Levels: true}, "*($levels=1)"},
+		{Expand{NoLinks: true}, "."},
+		{Expand{ExpandAll: true, NoLinks: true, Levels: true}, ".($levels=1)"},
+	}
+	for _, test := range tests {
+		c := &APIClient{Service: &Service{}}
+		c.Service.ProtocolFeaturesSupported.ExpandQuery = test.expand
+		if query := c.CollectionExpandQuery(); query != test.expected {
+			t.Errorf("Expected %q for %+v, got %q", test.expected, test.expand, query)
+		}
+	}
+}
+
+// mockTLSServer starts a TLS mock server counting the connections opened.
+func mockTLSServer(tb testing.TB) (*httptest.Server, *int32) {
+	server, err := mockserver.New("mockserver/testdata/simple")
+	if err != nil {
+		tb.Fatalf("Error loading mockup: %s", err)
+	}
+
+	var connections int32
+	ts := httptest.NewUnstartedServer(server)
+	ts.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&connections, 1)
+		}
+	}
+	ts.StartTLS()
+	tb.Cleanup(ts.Close)
+	return ts, &connections
+}
+
+// TestKeepAlive tests reusing connections unless keep-alive is disabled.
+func TestKeepAlive(t *testing.T) {
+	tests := []struct {
+		disableKeepAlives bool
+		connections       int32
+	}{
+		{false, 1},
+		// The service root, the systems collection and its member for
+		// every iteration.
+		{true, 1 + 2*10},
+	}
+	for _, test := range tests {
+		ts, connections := mockTLSServer(t)
+		c, err := Connect(ClientConfig{Endpoint: ts.URL, Insecure: true, DisableKeepAlives: test.disableKeepAlives})
+		if err != nil {
+			t.Fatalf("Error connecting: %s", err)
+		}
+
+		for i := 0; i < 10; i++ {
+			if _, err := c.Service.Systems(); err != nil {
+				t.Fatalf("Error listing systems: %s", err)
+			}
+		}
+		if got := atomic.LoadInt32(connections); got != test.connections {
+			t.Errorf("Expected %d connections with DisableKeepAlives %t, got %d", test.connections, test.disableKeepAlives, got)
+		}
+	}
+}
+
+// BenchmarkKeepAlive compares listing systems over TLS with and without
+// reusing connections.
+func BenchmarkKeepAlive(b *testing.B) {
+	for _, disableKeepAlives := range []bool{false, true} {
+		b.Run(fmt.Sprintf("DisableKeepAlives=%t", disableKeepAlives), func(b *testing.B) {
+			ts, _ := mockTLSServer(b)
+			c, err := Connect(ClientConfig{Endpoint: ts.URL, Insecure: true, DisableKeepAlives: disableKeepAlives})
+			if err != nil {
+				b.Fatalf("Error connecting: %s", err)
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := c.Service.Systems(); err != nil {
+					b.Fatalf("Error listing systems: %s", err)
+				}
+			}
+		})
+	}
+}
@@ -5,14 +5,26 @@
 package common
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
 )
 
 // Collection represents a collection of entity references.
 type Collection struct {
 	Name      string `json:"Name"`
 	ItemLinks []string
+	// NextLink is the URI of the next page of members if the service
+	// returned the collection in pages.
+	NextLink string `json:"Members@odata.nextLink"`
+	// ExpandedItems holds the JSON of each member, in the same order as
+	// ItemLinks, when the collection was retrieved using $expand. Entries for
+	// members the service did not expand are nil.
+	ExpandedItems []json.RawMessage
 }
 
 // UnmarshalJSON unmarshals a collection from the raw JSON.
@@ -29,36 +41,418 @@ func (c *Collection) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
+	var members struct {
+		Members []json.RawMessage
+		Links   struct {
+			Members []json.RawMessage
+		}
+	}
+
+	err = json.Unmarshal(b, &members)
+	if err != nil {
+		return err
+	}
+
 	*c = Collection(t.temp)
 
 	// Redfish objects store collection items under Links
 	c.ItemLinks = t.Links.ToStrings()
+	rawMembers := members.Links.Members
 
 	// Swordfish has them at the root
 	if len(c.ItemLinks) == 0 &&
 		(t.Count > 0 || t.ODataCount > 0) {
 		c.ItemLinks = t.Members.ToStrings()
+		rawMembers = members.Members
 	}
 
+	c.ExpandedItems = expandedItems(rawMembers)
+
 	return nil
 }
 
+// expandedItems returns the members that contain more than a link to the
+// member, or nil if none of them do.
+func expandedItems(members []json.RawMessage) []json.RawMessage {
+	var result []json.RawMessage
+	for i, member := range members {
+		var properties map[string]json.RawMessage
+		if err := json.Unmarshal(member, &properties); err != nil || len(properties) <= 1 {
+			continue
+		}
+
+		if result == nil {
+			result = make([]json.RawMessage, len(members))
+		}
+		result[i] = member
+	}
+
+	return result
+}
+
 // GetCollection retrieves a collection from the service.
 func GetCollection(c Client, uri string) (*Collection, error) {
-	resp, err := c.Get(uri)
+	return GetCollectionContext(context.Background(), c, uri)
+}
+
+// GetCollectionContext is the same as GetCollection, but uses ctx for the
+// request(s).
+func GetCollectionContext(ctx context.Context, c Client, uri string) (*Collection, error) {
+	return getCollection(ctx, c, uri, false, nil)
+}
+
+// GetCollectionWithOptions is the same as GetCollectionContext, but adds the
+// collection query options opts, if not nil, to the request(s).
+func GetCollectionWithOptions(ctx context.Context, c Client, uri string, opts *QueryOptions) (*Collection, error) {
+	return getCollection(ctx, c, uri, false, opts)
+}
+
+// MaxCollectionPages is the maximum number of pages followed when retrieving
+// a collection, to protect against services that never stop returning a next
+// link.
+const MaxCollectionPages = 10000
+
+// CollectionIterator retrieves a collection one page at a time, following
+// the Members@odata.nextLink of each page. It only holds the current page.
+//
+//	pages := common.NewCollectionIterator(ctx, c, uri)
+//	for pages.Next() {
+//		var entries []*redfish.LogEntry
+//		err := pages.Objects(&entries)
+//		...
+//	}
+//	if err := pages.Err(); err != nil {
+//		...
+//	}
+type CollectionIterator struct {
+	ctx    context.Context
+	c      Client
+	uri    string
+	expand bool
+	opts   *QueryOptions
+
+	page  *Collection
+	seen  map[string]bool
+	done  bool
+	err   error
+	pages int
+}
+
+// NewCollectionIterator returns an iterator over the pages of the collection
+// at uri. If the service supports $expand, the members are retrieved along
+// with each page.
+func NewCollectionIterator(ctx context.Context, c Client, uri string) *CollectionIterator {
+	return NewCollectionIteratorWithOptions(ctx, c, uri, nil)
+}
+
+// NewCollectionIteratorWithOptions is the same as NewCollectionIterator, but
+// adds the query options opts, if not nil, to the requests.
+func NewCollectionIteratorWithOptions(ctx context.Context, c Client, uri string, opts *QueryOptions) *CollectionIterator {
+	return &CollectionIterator{
+		ctx:    ctx,
+		c:      c,
+		uri:    uri,
+		expand: true,
+		opts:   opts,
+		seen:   make(map[string]bool),
+	}
+}
+
+// Next retrieves the next page of the collection. It returns false when there
+// are no more pages or a page could not be retrieved.
+func (it *CollectionIterator) Next() bool {
+	if it.done {
+		return false
+	}
+
+	var page *Collection
+	var err error
+	switch {
+	case it.pages == 0:
+		page, err = getFirstCollectionPage(it.ctx, it.c, it.uri, it.expand, it.opts)
+	case it.page.NextLink == "":
+		it.done = true
+		return false
+	case it.pages >= MaxCollectionPages:
+		err = fmt.Errorf("collection %s has more than %d pages", it.uri, MaxCollectionPages)
+	case it.seen[it.page.NextLink]:
+		err = fmt.Errorf("collection %s links to page %s more than once", it.uri, it.page.NextLink)
+	default:
+		it.seen[it.page.NextLink] = true
+		page = &Collection{}
+		err = getObject(it.ctx, it.c, it.page.NextLink, page)
+	}
+
 	if err != nil {
+		it.err = err
+		it.done = true
+		return false
+	}
+
+	it.page = page
+	it.pages++
+	return true
+}
+
+// Links returns the member links of the current page.
+func (it *CollectionIterator) Links() []string {
+	if it.page == nil {
+		return nil
+	}
+	return it.page.ItemLinks
+}
+
+// Objects retrieves the members of the current page and appends them to
+// result. See GetObjects for the requirements on result.
+func (it *CollectionIterator) Objects(result interface{}) error {
+	if it.page == nil {
+		return nil
+	}
+	return getObjects(it.ctx, it.c, it.page.ItemLinks, it.page.ExpandedItems, it.opts, result)
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *CollectionIterator) Err() error {
+	return it.err
+}
+
+// getCollection retrieves all pages of the collection at uri, including the
+// members if expand is set and the client supports it.
+func getCollection(ctx context.Context, c Client, uri string, expand bool, opts *QueryOptions) (*Collection, error) {
+	pages := NewCollectionIteratorWithOptions(ctx, c, uri, opts)
+	pages.expand = expand
+
+	var result *Collection
+	for pages.Next() {
+		if result == nil {
+			result = pages.page
+			continue
+		}
+
+		page := pages.page
+		if result.ExpandedItems != nil || page.ExpandedItems != nil {
+			result.ExpandedItems = append(
+				padItems(result.ExpandedItems, len(result.ItemLinks)),
+				padItems(page.ExpandedItems, len(page.ItemLinks))...)
+		}
+		result.ItemLinks = append(result.ItemLinks, page.ItemLinks...)
+	}
+
+	if err := pages.Err(); err != nil {
+		return nil, err
+	}
+	result.NextLink = ""
+	return result, nil
+}
+
+// padItems extends items with nil entries up to length n.
+func padItems(items []json.RawMessage, n int) []json.RawMessage {
+	if len(items) >= n {
+		return items
+	}
+	return append(items, make([]json.RawMessage, n-len(items))...)
+}
+
+// getCollectionPage retrieves the first page of the collection at uri,
+// adding the $expand query parameter if expand is set and the collection
+// query options opts.
+func getCollectionPage(ctx context.Context, c Client, uri, expand string, opts *QueryOptions) (*Collection, error) {
+	var params []string
+	if expand != "" {
+		params = append(params, "$expand="+expand)
+	}
+	if opts != nil {
+		if err := opts.check(c); err != nil {
+			return nil, err
+		}
+		params = append(params, opts.collectionParams(expand != "")...)
+	}
+
+	var data json.RawMessage
+	if err := getObject(ctx, c, addQuery(uri, params...), &data); err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+
+	if opts != nil && opts.Only {
+		if member := onlyMember(data); member != nil {
+			return member, nil
+		}
+	}
 
 	var result Collection
-	err = json.NewDecoder(resp.Body).Decode(&result)
-	if err != nil {
+	if err := json.Unmarshal(data, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
 
+// onlyMember returns a collection holding the resource in data if it is the
+// single member of a collection returned in place of the collection because
+// of the only query parameter, or nil if data is a collection.
+func onlyMember(data []byte) *Collection {
+	var t struct {
+		ODataID string `json:"@odata.id"`
+		Members json.RawMessage
+		Links   struct {
+			Members json.RawMessage
+		}
+	}
+	if err := json.Unmarshal(data, &t); err != nil || t.ODataID == "" ||
+		t.Members != nil || t.Links.Members != nil {
+		return nil
+	}
+
+	return &Collection{
+		ItemLinks:     []string{t.ODataID},
+		ExpandedItems: []json.RawMessage{data},
+	}
+}
+
+// collectionWorkers is implemented by clients that allow the members of a
+// collection to be fetched concurrently.
+type collectionWorkers interface {
+	CollectionWorkers() int
+}
+
+// collectionExpander is implemented by clients that can retrieve the members
+// of a collection along with the collection itself. CollectionExpandQuery
+// returns the value to use for the $expand query parameter, or an empty
+// string if the service does not support it.
+type collectionExpander interface {
+	CollectionExpandQuery() string
+}
+
+// getFirstCollectionPage retrieves the first page of the collection at uri.
+// If expand is set and the client and service support $expand, the members
+// are included, falling back to retrieving only the member links if the
+// expanded request is rejected.
+func getFirstCollectionPage(ctx context.Context, c Client, uri string, expand bool, opts *QueryOptions) (*Collection, error) {
+	expander, ok := c.(collectionExpander)
+	if !expand || !ok || expander.CollectionExpandQuery() == "" {
+		return getCollectionPage(ctx, c, uri, "", opts)
+	}
+
+	// Services advertising $expand may still reject it for some
+	// collections, other errors are returned as is.
+	result, err := getCollectionPage(ctx, c, uri, expander.CollectionExpandQuery(), opts)
+	var redfishErr *Error
+	if errors.As(err, &redfishErr) && (redfishErr.HTTPReturnedStatusCode == http.StatusBadRequest ||
+		redfishErr.HTTPReturnedStatusCode == http.StatusNotImplemented) {
+		return getCollectionPage(ctx, c, uri, "", opts)
+	}
+	return result, err
+}
+
+// GetCollectionObjects retrieves the collection at uri and all of its
+// members. If the service supports $expand, the members are retrieved in
+// the same request as the collection. See GetObjects for the requirements on
+// result.
+func GetCollectionObjects(ctx context.Context, c Client, uri string, result interface{}) error {
+	return GetCollectionObjectsWithOptions(ctx, c, uri, nil, result)
+}
+
+// GetCollectionObjectsWithOptions is the same as GetCollectionObjects, but
+// adds the query options opts, if not nil, to the requests for the
+// collection and its members.
+func GetCollectionObjectsWithOptions(ctx context.Context, c Client, uri string, opts *QueryOptions, result interface{}) error {
+	collection, err := getCollection(ctx, c, uri, true, opts)
+	if err != nil {
+		return err
+	}
+
+	return getObjects(ctx, c, collection.ItemLinks, collection.ExpandedItems, opts, result)
+}
+
+// GetObjects retrieves the object at each of links and appends them to
+// result, which must be a pointer to a slice of object pointers such as
+// *[]*redfish.Chassis. If the client supports it, several objects are
+// fetched at once. The objects are appended in the same order as links.
+// Any links that could not be retrieved are reported in a *CollectionError.
+func GetObjects(ctx context.Context, c Client, links []string, result interface{}) error {
+	return getObjects(ctx, c, links, nil, nil, result)
+}
+
+// getObjects is the same as GetObjects, but decodes any non-nil entries of
+// expanded instead of retrieving the corresponding link, and adds opts to
+// the requests.
+func getObjects(ctx context.Context, c Client, links []string, expanded []json.RawMessage, opts *QueryOptions, result interface{}) error {
+	slice := reflect.ValueOf(result)
+	if slice.Kind() != reflect.Ptr || slice.Elem().Kind() != reflect.Slice ||
+		slice.Elem().Type().Elem().Kind() != reflect.Ptr {
+		return fmt.Errorf("result must be a pointer to a slice of pointers, got %T", result)
+	}
+	slice = slice.Elem()
+	objectType := slice.Type().Elem().Elem()
+
+	workers := 1
+	if cw, ok := c.(collectionWorkers); ok {
+		workers = cw.CollectionWorkers()
+	}
+
+	var lock sync.Mutex
+	objects := make([]reflect.Value, len(links))
+	collectionError := NewCollectionError()
+	forEachLink(links, workers, func(i int, link string) {
+		object := reflect.New(objectType)
+		var err error
+		if i < len(expanded) && expanded[i] != nil {
+			err = decodeObject(c, expanded[i], object.Interface())
+		} else {
+			err = GetObjectWithOptions(ctx, c, link, opts, object.Interface())
+		}
+		if err != nil {
+			lock.Lock()
+			collectionError.Failures[link] = err
+			lock.Unlock()
+			return
+		}
+		objects[i] = object
+	})
+
+	for _, object := range objects {
+		if object.IsValid() {
+			slice.Set(reflect.Append(slice, object))
+		}
+	}
+
+	if collectionError.Empty() {
+		return nil
+	}
+
+	return collectionError
+}
+
+// forEachLink calls fn for each of links, using up to workers goroutines.
+func forEachLink(links []string, workers int, fn func(i int, link string)) {
+	if workers > len(links) {
+		workers = len(links)
+	}
+	if workers <= 1 {
+		for i, link := range links {
+			fn(i, link)
+		}
+		return
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i, links[i])
+			}
+		}()
+	}
+
+	for i := range links {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
+
 // CollectionError is used for collecting errors when working with collections
 type CollectionError struct {
 	Failures map[string]error
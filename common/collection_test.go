@@ -5,10 +5,17 @@
 package common
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 var collectionBody = strings.NewReader(
@@ -55,3 +62,257 @@ func TestCollection(t *testing.T) {
 		}
 	}
 }
+
+// concurrentTestClient serves GET requests for "/items/N" concurrently,
+// failing for any link ending in "fail".
+type concurrentTestClient struct {
+	TestClient
+	workers  int
+	inFlight int32
+	maxSeen  int32
+}
+
+func (c *concurrentTestClient) CollectionWorkers() int {
+	return c.workers
+}
+
+func (c *concurrentTestClient) GetWithContext(ctx context.Context, url string) (*http.Response, error) {
+	current := atomic.AddInt32(&c.inFlight, 1)
+	defer atomic.AddInt32(&c.inFlight, -1)
+	for {
+		seen := atomic.LoadInt32(&c.maxSeen)
+		if current <= seen || atomic.CompareAndSwapInt32(&c.maxSeen, seen, current) {
+			break
+		}
+	}
+
+	// Finish out of order so ordering of the results is exercised.
+	time.Sleep(time.Duration(len(url)%3) * time.Millisecond)
+
+	if strings.HasSuffix(url, "fail") {
+		return nil, errors.New("not found")
+	}
+
+	body := fmt.Sprintf(`{"@odata.id": %q, "Id": %q}`, url, url)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(body)),
+		Header:     http.Header{"Etag": []string{`W/"` + url + `"`}},
+	}, nil
+}
+
+// TestGetObjects tests fetching objects concurrently.
+func TestGetObjects(t *testing.T) {
+	var links []string
+	for i := 0; i < 20; i++ {
+		links = append(links, fmt.Sprintf("/items/%d", i))
+	}
+	links = append(links, "/items/fail")
+
+	c := &concurrentTestClient{workers: 4}
+	var result []*Entity
+	err := GetObjects(context.Background(), c, links, &result)
+
+	var collectionError *CollectionError
+	if !errors.As(err, &collectionError) {
+		t.Fatalf("Expected a CollectionError, got: %v", err)
+	}
+	if len(collectionError.Failures) != 1 || collectionError.Failures["/items/fail"] == nil {
+		t.Errorf("Unexpected failures: %v", collectionError.Failures)
+	}
+
+	if len(result) != 20 {
+		t.Fatalf("Expected 20 objects, got %d", len(result))
+	}
+	for i, entity := range result {
+		if entity.ODataID != links[i] {
+			t.Errorf("Expected object %d to be %s, got %s", i, links[i], entity.ODataID)
+		}
+		if entity.Client != c {
+			t.Errorf("Object %d was not set to use the client", i)
+		}
+		if entity.ETag() != `W/"`+links[i]+`"` {
+			t.Errorf("Object %d has unexpected etag: %s", i, entity.ETag())
+		}
+	}
+
+	if c.maxSeen < 2 || c.maxSeen > 4 {
+		t.Errorf("Expected between 2 and 4 concurrent requests, saw %d", c.maxSeen)
+	}
+}
+
+// TestGetObjectsSequential tests that clients without worker settings fetch
+// one object at a time.
+func TestGetObjectsSequential(t *testing.T) {
+	testClient := &TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				&http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(`{"Id": "1"}`))},
+				&http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(`{"Id": "2"}`))},
+			},
+		},
+	}
+
+	var result []*Entity
+	err := GetObjects(context.Background(), testClient, []string{"/items/1", "/items/2"}, &result)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(result) != 2 || result[0].ID != "1" || result[1].ID != "2" {
+		t.Errorf("Unexpected result: %v", result)
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 2 || calls[0].URL != "/items/1" || calls[1].URL != "/items/2" {
+		t.Errorf("Unexpected calls: %v", calls)
+	}
+}
+
+// TestGetObjectsInvalidResult tests that the result must be a slice pointer.
+func TestGetObjectsInvalidResult(t *testing.T) {
+	var result []Entity
+	err := GetObjects(context.Background(), &TestClient{}, []string{"/items/1"}, result)
+	if err == nil {
+		t.Error("Expected an error for a non-pointer result")
+	}
+
+	err = GetObjects(context.Background(), &TestClient{}, []string{"/items/1"}, &result)
+	if err == nil {
+		t.Error("Expected an error for a slice of non-pointers")
+	}
+}
+
+// TestCollectionExpanded tests the parsing of expanded collection members.
+func TestCollectionExpanded(t *testing.T) {
+	var result Collection
+	err := json.Unmarshal([]byte(`{
+		"Name": "Expanded Collection",
+		"Members@odata.count": 2,
+		"Members": [
+			{"@odata.id": "/redfish/v1/Systems/System-1", "Id": "System-1"},
+			{"@odata.id": "/redfish/v1/Systems/System-2"}
+		]
+	}`), &result)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	if len(result.ItemLinks) != 2 || len(result.ExpandedItems) != 2 {
+		t.Fatalf("Expected 2 links and expanded items, got %d and %d",
+			len(result.ItemLinks), len(result.ExpandedItems))
+	}
+
+	if result.ExpandedItems[0] == nil || result.ExpandedItems[1] != nil {
+		t.Errorf("Only the first member should be expanded: %v", result.ExpandedItems)
+	}
+
+	testClient := &TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				&http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(`{"Id": "System-2"}`))},
+			},
+		},
+	}
+
+	var entities []*Entity
+	err = getObjects(context.Background(), testClient, result.ItemLinks, result.ExpandedItems, nil, &entities)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(entities) != 2 || entities[0].ID != "System-1" || entities[1].ID != "System-2" {
+		t.Errorf("Unexpected result: %v", entities)
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 1 || calls[0].URL != "/redfish/v1/Systems/System-2" {
+		t.Errorf("Only the unexpanded member should be retrieved: %v", calls)
+	}
+}
+
+// pagedTestClient serves a collection split into pages that link to each
+// other, with the last page linking to next.
+type pagedTestClient struct {
+	TestClient
+	pages int
+	next  string
+}
+
+func (c *pagedTestClient) GetWithContext(ctx context.Context, url string) (*http.Response, error) {
+	page := 0
+	fmt.Sscanf(url, "/items?page=%d", &page) //nolint:errcheck
+
+	next := fmt.Sprintf("/items?page=%d", page+1)
+	if page+1 == c.pages {
+		next = c.next
+	}
+
+	body := fmt.Sprintf(`{"Members@odata.count": %d, "Members": [{"@odata.id": "/items/%d"}], "Members@odata.nextLink": %q}`,
+		c.pages, page, next)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(body)),
+	}, nil
+}
+
+// TestGetCollectionPages tests that all pages of a collection are retrieved.
+func TestGetCollectionPages(t *testing.T) {
+	c := &pagedTestClient{pages: 3}
+	result, err := GetCollection(c, "/items")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{"/items/0", "/items/1", "/items/2"}
+	if fmt.Sprint(result.ItemLinks) != fmt.Sprint(expected) {
+		t.Errorf("Expected links %v, got %v", expected, result.ItemLinks)
+	}
+
+	if result.NextLink != "" {
+		t.Errorf("NextLink should be cleared, got %s", result.NextLink)
+	}
+}
+
+// TestGetCollectionPageLoop tests that a page linking back to an earlier page
+// is reported.
+func TestGetCollectionPageLoop(t *testing.T) {
+	c := &pagedTestClient{pages: 3, next: "/items?page=1"}
+	_, err := GetCollection(c, "/items")
+	if err == nil || !strings.Contains(err.Error(), "more than once") {
+		t.Errorf("Expected a page loop error, got: %v", err)
+	}
+}
+
+// TestCollectionIterator tests walking a collection one page at a time.
+func TestCollectionIterator(t *testing.T) {
+	c := &pagedTestClient{pages: 3}
+	pages := NewCollectionIterator(context.Background(), c, "/items")
+
+	var links []string
+	for pages.Next() {
+		links = append(links, pages.Links()...)
+	}
+
+	if err := pages.Err(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(links) != 3 {
+		t.Errorf("Expected 3 links, got %v", links)
+	}
+
+	if pages.Next() {
+		t.Error("Next should keep returning false once the iteration is done")
+	}
+}
+
+// TestGetCollectionPageLimit tests that a collection that never stops
+// returning next links is cut off.
+func TestGetCollectionPageLimit(t *testing.T) {
+	c := &pagedTestClient{pages: MaxCollectionPages * 2}
+	_, err := GetCollection(c, "/items")
+	if err == nil || !strings.Contains(err.Error(), "more than") {
+		t.Errorf("Expected a page limit error, got: %v", err)
+	}
+}
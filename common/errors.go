@@ -0,0 +1,139 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors to check a *Error returned by the service against with
+// errors.Is, for example:
+//
+//	if errors.Is(err, common.ErrNotFound) {
+//		...
+//	}
+var (
+	// ErrNotFound is matched by a 404 Not Found response.
+	ErrNotFound = errors.New("resource not found")
+	// ErrUnauthorized is matched by a 401 Unauthorized response.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrForbidden is matched by a 403 Forbidden response.
+	ErrForbidden = errors.New("forbidden")
+	// ErrMethodNotAllowed is matched by a 405 Method Not Allowed response.
+	ErrMethodNotAllowed = errors.New("method not allowed")
+	// ErrConflict is matched by a 409 Conflict response.
+	ErrConflict = errors.New("conflict")
+	// ErrPreconditionFailed is matched by a 412 Precondition Failed response,
+	// returned when a change is sent with an out of date entity tag.
+	ErrPreconditionFailed = errors.New("precondition failed")
+	// ErrServiceUnavailable is matched by a 503 Service Unavailable response.
+	ErrServiceUnavailable = errors.New("service unavailable")
+	// ErrActionNotSupported is matched by an error with the Base registry
+	// ActionNotSupported message.
+	ErrActionNotSupported = errors.New("action not supported")
+)
+
+// Is reports whether the error matches one of the sentinel errors.
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.HTTPReturnedStatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.HTTPReturnedStatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.HTTPReturnedStatusCode == http.StatusForbidden
+	case ErrMethodNotAllowed:
+		return e.HTTPReturnedStatusCode == http.StatusMethodNotAllowed
+	case ErrConflict:
+		return e.HTTPReturnedStatusCode == http.StatusConflict
+	case ErrPreconditionFailed:
+		return e.HTTPReturnedStatusCode == http.StatusPreconditionFailed
+	case ErrServiceUnavailable:
+		return e.HTTPReturnedStatusCode == http.StatusServiceUnavailable
+	case ErrActionNotSupported:
+		return e.HasMessage("ActionNotSupported")
+	}
+	return false
+}
+
+// HasMessage checks if the error code or any of the extended information
+// messages is the registry message named key, such as "ActionNotSupported",
+// regardless of the registry version.
+func (e *Error) HasMessage(key string) bool {
+	if strings.HasSuffix(e.Code, "."+key) {
+		return true
+	}
+	for _, info := range e.ExtendedInfos {
+		if strings.HasSuffix(info.MessageID, "."+key) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsNotFound checks if err is a 404 Not Found response.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsUnauthorized checks if err is a 401 Unauthorized response.
+func IsUnauthorized(err error) bool {
+	return errors.Is(err, ErrUnauthorized)
+}
+
+// IsForbidden checks if err is a 403 Forbidden response.
+func IsForbidden(err error) bool {
+	return errors.Is(err, ErrForbidden)
+}
+
+// IsMethodNotAllowed checks if err is a 405 Method Not Allowed response.
+func IsMethodNotAllowed(err error) bool {
+	return errors.Is(err, ErrMethodNotAllowed)
+}
+
+// IsConflict checks if err is a 409 Conflict response.
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
+
+// IsPreconditionFailed checks if err is a 412 Precondition Failed response.
+func IsPreconditionFailed(err error) bool {
+	return errors.Is(err, ErrPreconditionFailed)
+}
+
+// IsServiceUnavailable checks if err is a 503 Service Unavailable response.
+func IsServiceUnavailable(err error) bool {
+	return errors.Is(err, ErrServiceUnavailable)
+}
+
+// IsActionNotSupported checks if err reports the requested action is not
+// supported.
+func IsActionNotSupported(err error) bool {
+	return errors.Is(err, ErrActionNotSupported)
+}
+
+// TransportError is returned when a request could not be sent or its
+// response could not be read, as opposed to the service returning an error
+// response.
+type TransportError struct {
+	Err error
+}
+
+func (e *TransportError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying network error.
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+// IsTransportError checks if err is a *TransportError.
+func IsTransportError(err error) bool {
+	var transportError *TransportError
+	return errors.As(err, &transportError)
+}
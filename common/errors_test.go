@@ -0,0 +1,112 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+var relatedPropertiesBody = `{
+	"error": {
+		"code": "Base.1.8.GeneralError",
+		"message": "A general error has occurred.",
+		"@Message.ExtendedInfo": [
+			{
+				"MessageId": "Base.1.8.PropertyValueNotInList",
+				"Message": "The value Red for the property IndicatorLED is not in the list of acceptable values.",
+				"MessageArgs": ["Red", "IndicatorLED"],
+				"RelatedProperties": ["#/IndicatorLED"],
+				"Severity": "Warning",
+				"Resolution": "Choose a value from the enumeration list and resubmit the request."
+			}
+		]
+	}
+}`
+
+// TestErrorIs tests matching errors against the sentinel errors.
+func TestErrorIs(t *testing.T) {
+	tests := []struct {
+		status    int
+		sentinel  error
+		predicate func(error) bool
+	}{
+		{http.StatusNotFound, ErrNotFound, IsNotFound},
+		{http.StatusUnauthorized, ErrUnauthorized, IsUnauthorized},
+		{http.StatusForbidden, ErrForbidden, IsForbidden},
+		{http.StatusMethodNotAllowed, ErrMethodNotAllowed, IsMethodNotAllowed},
+		{http.StatusConflict, ErrConflict, IsConflict},
+		{http.StatusPreconditionFailed, ErrPreconditionFailed, IsPreconditionFailed},
+		{http.StatusServiceUnavailable, ErrServiceUnavailable, IsServiceUnavailable},
+	}
+
+	for _, test := range tests {
+		err := fmt.Errorf("wrapped: %w", ConstructError(test.status, []byte("{}")))
+		if !errors.Is(err, test.sentinel) || !test.predicate(err) {
+			t.Errorf("Expected %d to match %v", test.status, test.sentinel)
+		}
+		if errors.Is(err, ErrActionNotSupported) {
+			t.Errorf("Did not expect %d to match %v", test.status, ErrActionNotSupported)
+		}
+	}
+
+	if IsNotFound(ConstructError(http.StatusBadRequest, []byte("{}"))) {
+		t.Error("Did not expect 400 to match ErrNotFound")
+	}
+	if IsNotFound(nil) {
+		t.Error("Did not expect nil to match ErrNotFound")
+	}
+
+	err := &PreconditionFailedError{Err: ConstructError(http.StatusPreconditionFailed, []byte("{}")).(*Error)}
+	if !IsPreconditionFailed(err) {
+		t.Errorf("Expected %v to match ErrPreconditionFailed", err)
+	}
+}
+
+// TestErrorActionNotSupported tests matching the ActionNotSupported message.
+func TestErrorActionNotSupported(t *testing.T) {
+	body := `{"error": {"code": "Base.1.8.GeneralError", "@Message.ExtendedInfo": [{"MessageId": "Base.1.8.ActionNotSupported"}]}}`
+	if err := ConstructError(http.StatusBadRequest, []byte(body)); !IsActionNotSupported(err) {
+		t.Errorf("Expected %v to match ErrActionNotSupported", err)
+	}
+
+	body = `{"error": {"code": "Base.1.0.ActionNotSupported"}}`
+	if err := ConstructError(http.StatusBadRequest, []byte(body)); !IsActionNotSupported(err) {
+		t.Errorf("Expected %v to match ErrActionNotSupported", err)
+	}
+}
+
+// TestErrorRelatedProperties tests parsing the related properties of an
+// extended info message.
+func TestErrorRelatedProperties(t *testing.T) {
+	err := ConstructError(http.StatusBadRequest, []byte(relatedPropertiesBody)).(*Error)
+
+	if len(err.ExtendedInfos) != 1 {
+		t.Fatalf("Expected one extended info message, got: %#v", err.ExtendedInfos)
+	}
+	related := err.ExtendedInfos[0].RelatedProperties
+	if len(related) != 1 || related[0] != "#/IndicatorLED" {
+		t.Errorf("Unexpected related properties: %v", related)
+	}
+}
+
+// TestTransportError tests that a transport error can be told apart from an
+// error response and still exposes the underlying error.
+func TestTransportError(t *testing.T) {
+	cause := errors.New("connection refused")
+	err := fmt.Errorf("wrapped: %w", &TransportError{Err: cause})
+
+	if !IsTransportError(err) {
+		t.Errorf("Expected a transport error: %v", err)
+	}
+	if !errors.Is(err, cause) {
+		t.Errorf("Expected the underlying error to be available: %v", err)
+	}
+	if IsTransportError(ConstructError(http.StatusNotFound, []byte("{}"))) {
+		t.Error("Did not expect an error response to be a transport error")
+	}
+}
@@ -5,7 +5,7 @@
 package common
 
 import (
-	"encoding/json"
+	"context"
 )
 
 // Message is This type shall define a Message as described in the
@@ -37,48 +37,32 @@ type Message struct {
 
 // GetMessage will get a Message instance from the service.
 func GetMessage(c Client, uri string) (*Message, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetMessageContext(context.Background(), c, uri)
+}
 
+// GetMessageContext is the same as GetMessage, but uses ctx for the request.
+func GetMessageContext(ctx context.Context, c Client, uri string) (*Message, error) {
 	var message Message
-	err = json.NewDecoder(resp.Body).Decode(&message)
-	if err != nil {
+	if err := GetObject(ctx, c, uri, &message); err != nil {
 		return nil, err
 	}
-
-	message.SetClient(c)
 	return &message, nil
 }
 
 // ListReferencedMessages gets the collection of Message from
 // a provided reference.
 func ListReferencedMessages(c Client, link string) ([]*Message, error) {
+	return ListReferencedMessagesContext(context.Background(), c, link)
+}
+
+// ListReferencedMessagesContext is the same as ListReferencedMessages, but
+// uses ctx for the requests.
+func ListReferencedMessagesContext(ctx context.Context, c Client, link string) ([]*Message, error) {
 	var result []*Message
 	if link == "" {
 		return result, nil
 	}
 
-	links, err := GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := NewCollectionError()
-	for _, messageLink := range links.ItemLinks {
-		message, err := GetMessage(c, messageLink)
-		if err != nil {
-			collectionError.Failures[messageLink] = err
-		} else {
-			result = append(result, message)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	err := GetCollectionObjects(ctx, c, link, &result)
+	return result, err
 }
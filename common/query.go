@@ -0,0 +1,198 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Filter is an expression for the $filter query parameter. Use the Eq, Ne,
+// Gt, Lt, And and Or functions to build one.
+type Filter string
+
+// filterValue formats value as a $filter literal. Strings, including enum
+// types such as PowerState, are quoted.
+func filterValue(value interface{}) string {
+	v := reflect.ValueOf(value)
+	switch v.Kind() { //nolint:exhaustive
+	case reflect.String:
+		return "'" + strings.ReplaceAll(v.String(), "'", "''") + "'"
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+func comparison(property, operator string, value interface{}) Filter {
+	return Filter(property + " " + operator + " " + filterValue(value))
+}
+
+// Eq matches resources where property is equal to value.
+func Eq(property string, value interface{}) Filter {
+	return comparison(property, "eq", value)
+}
+
+// Ne matches resources where property is not equal to value.
+func Ne(property string, value interface{}) Filter {
+	return comparison(property, "ne", value)
+}
+
+// Gt matches resources where property is greater than value.
+func Gt(property string, value interface{}) Filter {
+	return comparison(property, "gt", value)
+}
+
+// Lt matches resources where property is less than value.
+func Lt(property string, value interface{}) Filter {
+	return comparison(property, "lt", value)
+}
+
+func combine(operator string, filters []Filter) Filter {
+	var parts []string
+	for _, filter := range filters {
+		if filter != "" {
+			parts = append(parts, string(filter))
+		}
+	}
+
+	if len(parts) <= 1 {
+		return Filter(strings.Join(parts, ""))
+	}
+	return Filter("(" + strings.Join(parts, " "+operator+" ") + ")")
+}
+
+// And matches resources that match all of filters.
+func And(filters ...Filter) Filter {
+	return combine("and", filters)
+}
+
+// Or matches resources that match any of filters.
+func Or(filters ...Filter) Filter {
+	return combine("or", filters)
+}
+
+// QueryFeatures describes the query parameters supported by a service.
+type QueryFeatures struct {
+	Select  bool
+	Filter  bool
+	TopSkip bool
+	Only    bool
+	Excerpt bool
+}
+
+// queryFeaturesClient is implemented by clients that know which query
+// parameters the service supports.
+type queryFeaturesClient interface {
+	QueryFeatures() QueryFeatures
+}
+
+// QueryOptions holds the query parameters used to limit what the service
+// returns. Select and Excerpt are added to requests for individual
+// resources, including collection members. Filter, Top, Skip and Only are
+// added to requests for collections, as is Select when the collection
+// members are expanded.
+type QueryOptions struct {
+	// Select lists the properties to return, for example "PowerState" or
+	// "Status/Health".
+	Select []string
+	// Filter limits the members of a collection to those that match.
+	Filter Filter
+	// Top limits the number of collection members returned, if greater
+	// than zero.
+	Top int
+	// Skip is the number of collection members to skip.
+	Skip int
+	// Only returns the member itself when a collection contains exactly one
+	// member. The member is then returned as the only member of the
+	// collection.
+	Only bool
+	// Excerpt returns only the excerpt properties of a resource.
+	Excerpt bool
+}
+
+// check returns an error if the options use a query parameter the service
+// does not support.
+func (q *QueryOptions) check(c Client) error {
+	fc, ok := c.(queryFeaturesClient)
+	if !ok {
+		return nil
+	}
+	features := fc.QueryFeatures()
+
+	unsupported := func(param string) error {
+		return fmt.Errorf("the service does not support the %s query parameter", param)
+	}
+	switch {
+	case len(q.Select) > 0 && !features.Select:
+		return unsupported("$select")
+	case q.Filter != "" && !features.Filter:
+		return unsupported("$filter")
+	case q.Top > 0 && !features.TopSkip:
+		return unsupported("$top")
+	case q.Skip > 0 && !features.TopSkip:
+		return unsupported("$skip")
+	case q.Only && !features.Only:
+		return unsupported("only")
+	case q.Excerpt && !features.Excerpt:
+		return unsupported("excerpt")
+	}
+	return nil
+}
+
+// resourceParams returns the query parameters for an individual resource.
+func (q *QueryOptions) resourceParams() []string {
+	var params []string
+	if len(q.Select) > 0 {
+		params = append(params, "$select="+queryEscape(strings.Join(q.Select, ",")))
+	}
+	if q.Excerpt {
+		params = append(params, "excerpt")
+	}
+	return params
+}
+
+// collectionParams returns the query parameters for a collection. The
+// $select parameter is only included if the members are expanded.
+func (q *QueryOptions) collectionParams(expanded bool) []string {
+	var params []string
+	if q.Filter != "" {
+		params = append(params, "$filter="+queryEscape(string(q.Filter)))
+	}
+	if q.Top > 0 {
+		params = append(params, "$top="+strconv.Itoa(q.Top))
+	}
+	if q.Skip > 0 {
+		params = append(params, "$skip="+strconv.Itoa(q.Skip))
+	}
+	if q.Only {
+		params = append(params, "only")
+	}
+	if expanded && len(q.Select) > 0 {
+		params = append(params, "$select="+queryEscape(strings.Join(q.Select, ",")))
+	}
+	return params
+}
+
+// queryEscape escapes s for use as a query parameter value.
+func queryEscape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+// addQuery appends params to the query string of uri.
+func addQuery(uri string, params ...string) string {
+	if len(params) == 0 {
+		return uri
+	}
+
+	separator := "?"
+	if strings.Contains(uri, "?") {
+		separator = "&"
+	}
+	return uri + separator + strings.Join(params, "&")
+}
@@ -0,0 +1,143 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type testPowerState string
+
+// TestFilter tests building $filter expressions.
+func TestFilter(t *testing.T) {
+	tests := []struct {
+		filter   Filter
+		expected string
+	}{
+		{Eq("PowerState", testPowerState("On")), "PowerState eq 'On'"},
+		{Ne("Name", "O'Brien"), "Name ne 'O''Brien'"},
+		{Gt("MemorySummary/TotalSystemMemoryGiB", 64), "MemorySummary/TotalSystemMemoryGiB gt 64"},
+		{Lt("ReadingCelsius", 40.5), "ReadingCelsius lt 40.5"},
+		{Eq("Enabled", true), "Enabled eq true"},
+		{And(Eq("A", 1), Or(Eq("B", 2), Eq("C", 3))), "(A eq 1 and (B eq 2 or C eq 3))"},
+		{And(Eq("A", 1)), "A eq 1"},
+		{Or(), ""},
+	}
+
+	for _, test := range tests {
+		if string(test.filter) != test.expected {
+			t.Errorf("Expected filter %q, got %q", test.expected, test.filter)
+		}
+	}
+}
+
+type queryTestClient struct {
+	TestClient
+	features QueryFeatures
+}
+
+func (c *queryTestClient) QueryFeatures() QueryFeatures {
+	return c.features
+}
+
+// TestGetObjectQueryOptions tests that query options are added to requests.
+func TestGetObjectQueryOptions(t *testing.T) {
+	c := &queryTestClient{features: QueryFeatures{Select: true, Filter: true, TopSkip: true}}
+	c.CustomReturnForActions = map[string][]interface{}{
+		http.MethodGet: {
+			&http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(`{"Id": "1"}`))},
+			&http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(
+				`{"Members@odata.count": 1, "Members": [{"@odata.id": "/redfish/v1/Systems/1"}]}`))},
+		},
+	}
+
+	opts := &QueryOptions{
+		Select: []string{"PowerState", "Status"},
+		Filter: Eq("PowerState", "On"),
+		Top:    10,
+	}
+
+	var entity Entity
+	if err := GetObjectWithOptions(context.Background(), c, "/redfish/v1/Systems/1", opts, &entity); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := GetCollectionWithOptions(context.Background(), c, "/redfish/v1/Systems", opts); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	calls := c.CapturedCalls()
+	expected := []string{
+		"/redfish/v1/Systems/1?$select=PowerState%2CStatus",
+		"/redfish/v1/Systems?$filter=PowerState%20eq%20%27On%27&$top=10",
+	}
+	for i, url := range expected {
+		if calls[i].URL != url {
+			t.Errorf("Expected request to %s, got %s", url, calls[i].URL)
+		}
+	}
+}
+
+// TestGetObjectUnsupportedQuery tests that options the service does not
+// support are rejected without making a request.
+func TestGetObjectUnsupportedQuery(t *testing.T) {
+	c := &queryTestClient{features: QueryFeatures{Select: true}}
+
+	var entity Entity
+	err := GetObjectWithOptions(context.Background(), c, "/redfish/v1/Systems/1", &QueryOptions{Excerpt: true}, &entity)
+	if err == nil || err.Error() != "the service does not support the excerpt query parameter" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	if len(c.CapturedCalls()) != 0 {
+		t.Errorf("No request should be made: %v", c.CapturedCalls())
+	}
+}
+
+// TestGetCollectionOnly tests that only is sent for collections, and that a
+// single member returned in place of the collection is decoded as its member.
+func TestGetCollectionOnly(t *testing.T) {
+	c := &queryTestClient{features: QueryFeatures{Only: true}}
+	c.CustomReturnForActions = map[string][]interface{}{
+		http.MethodGet: {
+			&http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(
+				`{"@odata.id": "/redfish/v1/Systems/1", "Id": "1"}`))},
+			&http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(
+				`{"@odata.id": "/redfish/v1/Systems", "Members@odata.count": 0, "Members": []}`))},
+			&http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(`{"Id": "1"}`))},
+		},
+	}
+	opts := &QueryOptions{Only: true}
+
+	var result []*Entity
+	if err := GetCollectionObjectsWithOptions(context.Background(), c, "/redfish/v1/Systems", opts, &result); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(result) != 1 || result[0].ODataID != "/redfish/v1/Systems/1" || result[0].ID != "1" {
+		t.Errorf("Expected the single member, got: %v", result)
+	}
+
+	collection, err := GetCollectionWithOptions(context.Background(), c, "/redfish/v1/Systems", opts)
+	if err != nil || len(collection.ItemLinks) != 0 {
+		t.Errorf("Expected an empty collection, got: %v %v", collection, err)
+	}
+
+	var entity Entity
+	if err := GetObjectWithOptions(context.Background(), c, "/redfish/v1/Systems/1", opts, &entity); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	calls := c.CapturedCalls()
+	expected := []string{"/redfish/v1/Systems?only", "/redfish/v1/Systems?only", "/redfish/v1/Systems/1"}
+	for i, url := range expected {
+		if calls[i].URL != url {
+			t.Errorf("Expected request to %s, got %s", url, calls[i].URL)
+		}
+	}
+}
@@ -5,10 +5,13 @@
 package common
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"regexp"
 	"strings"
 )
 
@@ -22,6 +25,17 @@ type TestAPICall struct {
 	Payload string
 	// CustomHeaders is the Map that holds customer HTTP headers
 	CustomHeaders map[string]string
+	// routed is set if the call was answered by one of the Routes
+	routed bool
+}
+
+// TestRouteHandler builds the response to a call answered by a route.
+type TestRouteHandler func(call TestAPICall) (*http.Response, error)
+
+// TestingT is the part of testing.T used by the TestClient assertions.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
 }
 
 // TestClient is a mock client to use for unit testing some of the
@@ -37,6 +51,20 @@ type TestClient struct {
 	// For each key it is possible to define a list of
 	// returns (in the order they should be returned).
 	CustomReturnForActions map[string][]interface{}
+	// Routes can be used to define the return for calls by their
+	// method and URL instead of their order. Keys are a method and
+	// a URL pattern, such as "GET /redfish/v1/Systems/*", or just a
+	// pattern to match any method. In patterns, * matches a single
+	// path segment or part of one, and ** matches anything. When
+	// several routes match, the one with the fewest wildcards, then
+	// the longest one, is used.
+	// Values can be a string, returned as the body of a 200 response,
+	// an *http.Response, whose body is returned for every match, or a
+	// TestRouteHandler. Calls that do not match any route are
+	// answered from CustomReturnForActions.
+	Routes map[string]interface{}
+	// routeBodies holds the bodies of the *http.Response routes
+	routeBodies map[*http.Response][]byte
 }
 
 // CapturedCalls gets all calls that were made through this instance
@@ -45,11 +73,12 @@ func (c *TestClient) CapturedCalls() []TestAPICall {
 }
 
 // actionCount returns how many actions
-// of a specific type were already recorded.
+// of a specific type were already recorded,
+// not counting the ones answered by a route.
 func (c *TestClient) actionCount(action string) int {
 	var actionCount int
 	for _, call := range c.calls {
-		if call.Action == action {
+		if call.Action == action && !call.routed {
 			actionCount++
 		}
 	}
@@ -105,10 +134,12 @@ func (c *TestClient) getPayloadToBeRecorded(payload interface{}) string {
 func (c *TestClient) Reset() {
 	c.calls = []TestAPICall{}
 	c.CustomReturnForActions = map[string][]interface{}{}
+	c.Routes = map[string]interface{}{}
+	c.routeBodies = nil
 }
 
 // recordCall is a helper to record any API calls made through this client
-func (c *TestClient) recordCall(action, url string, payload interface{}, customHeaders map[string]string) {
+func (c *TestClient) recordCall(action, url string, payload interface{}, customHeaders map[string]string) *TestAPICall {
 	call := TestAPICall{
 		Action:        action,
 		URL:           url,
@@ -117,17 +148,158 @@ func (c *TestClient) recordCall(action, url string, payload interface{}, customH
 	}
 
 	c.calls = append(c.calls, call)
+	return &c.calls[len(c.calls)-1]
+}
+
+// testRoute is a parsed entry of Routes.
+type testRoute struct {
+	method    string
+	pattern   *regexp.Regexp
+	wildcards int
+	length    int
+	value     interface{}
+}
+
+// parseTestRoute parses a Routes key, "METHOD pattern" or "pattern".
+func parseTestRoute(key string, value interface{}) *testRoute {
+	route := &testRoute{value: value}
+	pattern := key
+	if i := strings.IndexByte(key, ' '); i >= 0 {
+		route.method, pattern = key[:i], strings.TrimSpace(key[i+1:])
+	}
+
+	route.wildcards = strings.Count(pattern, "*")
+	route.length = len(pattern)
+
+	expr := regexp.QuoteMeta(pattern)
+	expr = strings.ReplaceAll(expr, `\*\*`, `.*`)
+	expr = strings.ReplaceAll(expr, `\*`, `[^/]*`)
+	route.pattern = regexp.MustCompile("^" + expr + "$")
+
+	return route
+}
+
+// matches checks if the route matches a call.
+func (r *testRoute) matches(action, url string) bool {
+	return (r.method == "" || r.method == action) && r.pattern.MatchString(url)
+}
+
+// getRoute gets the most specific route matching the call, or nil.
+func (c *TestClient) getRoute(action, url string) *testRoute {
+	var best *testRoute
+	for key, value := range c.Routes {
+		route := parseTestRoute(key, value)
+		if !route.matches(action, url) {
+			continue
+		}
+		if best == nil || route.wildcards < best.wildcards ||
+			(route.wildcards == best.wildcards && route.length > best.length) ||
+			(route.wildcards == best.wildcards && route.length == best.length && route.method != "" && best.method == "") {
+			best = route
+		}
+	}
+	return best
+}
+
+// routeResponse builds the response of route for call.
+func (c *TestClient) routeResponse(route *testRoute, call *TestAPICall) (*http.Response, error) {
+	switch value := route.value.(type) {
+	case string:
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(value))}, nil
+	case *http.Response:
+		// Buffer the body so it can be returned for every match.
+		body, ok := c.routeBodies[value]
+		if !ok && value.Body != nil {
+			var err error
+			if body, err = io.ReadAll(value.Body); err != nil {
+				return nil, err
+			}
+			value.Body.Close()
+			if c.routeBodies == nil {
+				c.routeBodies = make(map[*http.Response][]byte)
+			}
+			c.routeBodies[value] = body
+		}
+		resp := *value
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		return &resp, nil
+	case TestRouteHandler:
+		return value(*call)
+	case func(call TestAPICall) (*http.Response, error):
+		return value(*call)
+	}
+	return nil, fmt.Errorf("unsupported route value %T", route.value)
+}
+
+// CallsTo returns the recorded calls matching method, which may be empty to
+// match any method, and the URL pattern, which uses the same wildcards as
+// Routes. If payloadContains is given, only calls whose recorded payload
+// contains all of the strings are returned.
+func (c *TestClient) CallsTo(method, pattern string, payloadContains ...string) []TestAPICall {
+	route := parseTestRoute(pattern, nil)
+	route.method = method
+
+	var result []TestAPICall
+	for _, call := range c.calls {
+		if !route.matches(call.Action, call.URL) {
+			continue
+		}
+		matches := true
+		for _, s := range payloadContains {
+			if !strings.Contains(call.Payload, s) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			result = append(result, call)
+		}
+	}
+	return result
+}
+
+// AssertCalls reports an error through t unless exactly times calls were
+// made matching method, pattern and payloadContains, as with CallsTo. For
+// example, to check a single PATCH changed the AssetTag:
+//
+//	testClient.AssertCalls(t, http.MethodPatch, "/redfish/v1/Systems/*", 1, "AssetTag:new")
+func (c *TestClient) AssertCalls(t TestingT, method, pattern string, times int, payloadContains ...string) bool {
+	t.Helper()
+
+	calls := c.CallsTo(method, pattern, payloadContains...)
+	if len(calls) != times {
+		t.Errorf("Expected %d %s calls to %s with payload containing %q, got %d of %d calls: %v",
+			times, method, pattern, payloadContains, len(calls), len(c.calls), c.calls)
+		return false
+	}
+	return true
 }
 
-func (c *TestClient) performAction(action, url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
-	c.recordCall(action, url, payload, customHeaders)
-	customReturnForAction := c.getCustomReturnForAction(action)
-	if customReturnForAction == nil {
-		body := io.NopCloser(strings.NewReader(""))
-		return &http.Response{Body: body}, nil
+func (c *TestClient) performAction(ctx context.Context, action, url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
+	// Fail like a real request would if the call was already cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	call := c.recordCall(action, url, payload, customHeaders)
+
+	var resp *http.Response
+	if route := c.getRoute(action, url); route != nil {
+		call.routed = true
+		var err error
+		resp, err = c.routeResponse(route, call)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		customReturnForAction := c.getCustomReturnForAction(action)
+		if customReturnForAction == nil {
+			body := io.NopCloser(strings.NewReader(""))
+			return &http.Response{Body: body}, nil
+		}
+		resp = customReturnForAction.(*http.Response)
 	}
 
-	resp := customReturnForAction.(*http.Response)
 	if resp.StatusCode != 200 && resp.StatusCode != 201 && resp.StatusCode != 202 && resp.StatusCode != 204 {
 		payload, err := io.ReadAll(resp.Body)
 		if err != nil {
@@ -141,60 +313,120 @@ func (c *TestClient) performAction(action, url string, payload interface{}, cust
 
 // Get performs a GET request against the Redfish service.
 func (c *TestClient) Get(url string) (*http.Response, error) {
-	return c.performAction(http.MethodGet, url, nil, nil)
+	return c.performAction(context.Background(), http.MethodGet, url, nil, nil)
 }
 
 // GetWithHeaders performs a GET request against the Redfish service.
 func (c *TestClient) GetWithHeaders(url string, customHeaders map[string]string) (*http.Response, error) {
-	return c.performAction(http.MethodGet, url, nil, customHeaders)
+	return c.performAction(context.Background(), http.MethodGet, url, nil, customHeaders)
 }
 
 // Post performs a Post request against the Redfish service.
 func (c *TestClient) Post(url string, payload interface{}) (*http.Response, error) {
-	return c.performAction(http.MethodPost, url, payload, nil)
+	return c.performAction(context.Background(), http.MethodPost, url, payload, nil)
 }
 
 // PostWithHeaders performs a Post request against the Redfish service.
 func (c *TestClient) PostWithHeaders(url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
-	return c.performAction(http.MethodPost, url, payload, customHeaders)
+	return c.performAction(context.Background(), http.MethodPost, url, payload, customHeaders)
 }
 
 // PostMultipart performs a Post request against the Redfish service.
 func (c *TestClient) PostMultipart(url string, payload map[string]io.Reader) (*http.Response, error) {
-	return c.performAction(http.MethodPost, url, payload, nil)
+	return c.performAction(context.Background(), http.MethodPost, url, payload, nil)
 }
 
 // PostMultipartWithHeaders performs a Post request against the Redfish service.
 func (c *TestClient) PostMultipartWithHeaders(url string, payload map[string]io.Reader, customHeaders map[string]string) (*http.Response, error) {
-	return c.performAction(http.MethodPost, url, payload, customHeaders)
+	return c.performAction(context.Background(), http.MethodPost, url, payload, customHeaders)
 }
 
 // Put performs a Put request against the Redfish service.
 func (c *TestClient) Put(url string, payload interface{}) (*http.Response, error) {
-	return c.performAction(http.MethodPut, url, payload, nil)
+	return c.performAction(context.Background(), http.MethodPut, url, payload, nil)
 }
 
 // PutWithHeaders performs a Put request against the Redfish service.
 func (c *TestClient) PutWithHeaders(url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
-	return c.performAction(http.MethodPut, url, payload, customHeaders)
+	return c.performAction(context.Background(), http.MethodPut, url, payload, customHeaders)
 }
 
 // Patch performs a Patch request against the Redfish service.
 func (c *TestClient) Patch(url string, payload interface{}) (*http.Response, error) {
-	return c.performAction(http.MethodPatch, url, payload, nil)
+	return c.performAction(context.Background(), http.MethodPatch, url, payload, nil)
 }
 
 // PatchWithHeaders performs a Patch request against the Redfish service.
 func (c *TestClient) PatchWithHeaders(url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
-	return c.performAction(http.MethodPatch, url, payload, customHeaders)
+	return c.performAction(context.Background(), http.MethodPatch, url, payload, customHeaders)
 }
 
 // Delete performs a Delete request against the Redfish service.
 func (c *TestClient) Delete(url string) (*http.Response, error) {
-	return c.performAction(http.MethodDelete, url, nil, nil)
+	return c.performAction(context.Background(), http.MethodDelete, url, nil, nil)
 }
 
 // DeleteWithHeaders performs a Delete request against the Redfish service.
 func (c *TestClient) DeleteWithHeaders(url string, customHeaders map[string]string) (*http.Response, error) {
-	return c.performAction(http.MethodDelete, url, nil, customHeaders)
+	return c.performAction(context.Background(), http.MethodDelete, url, nil, customHeaders)
+}
+
+// GetWithContext performs a GET request against the Redfish service.
+func (c *TestClient) GetWithContext(ctx context.Context, url string) (*http.Response, error) {
+	return c.performAction(ctx, http.MethodGet, url, nil, nil)
+}
+
+// GetWithHeadersContext performs a GET request against the Redfish service.
+func (c *TestClient) GetWithHeadersContext(ctx context.Context, url string, customHeaders map[string]string) (*http.Response, error) {
+	return c.performAction(ctx, http.MethodGet, url, nil, customHeaders)
+}
+
+// PostWithContext performs a Post request against the Redfish service.
+func (c *TestClient) PostWithContext(ctx context.Context, url string, payload interface{}) (*http.Response, error) {
+	return c.performAction(ctx, http.MethodPost, url, payload, nil)
+}
+
+// PostWithHeadersContext performs a Post request against the Redfish service.
+func (c *TestClient) PostWithHeadersContext(ctx context.Context, url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
+	return c.performAction(ctx, http.MethodPost, url, payload, customHeaders)
+}
+
+// PostMultipartWithContext performs a Post request against the Redfish service.
+func (c *TestClient) PostMultipartWithContext(ctx context.Context, url string, payload map[string]io.Reader) (*http.Response, error) {
+	return c.performAction(ctx, http.MethodPost, url, payload, nil)
+}
+
+// PostMultipartWithHeadersContext performs a Post request against the Redfish service.
+func (c *TestClient) PostMultipartWithHeadersContext(ctx context.Context, url string, payload map[string]io.Reader, customHeaders map[string]string) (*http.Response, error) {
+	return c.performAction(ctx, http.MethodPost, url, payload, customHeaders)
+}
+
+// PutWithContext performs a Put request against the Redfish service.
+func (c *TestClient) PutWithContext(ctx context.Context, url string, payload interface{}) (*http.Response, error) {
+	return c.performAction(ctx, http.MethodPut, url, payload, nil)
+}
+
+// PutWithHeadersContext performs a Put request against the Redfish service.
+func (c *TestClient) PutWithHeadersContext(ctx context.Context, url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
+	return c.performAction(ctx, http.MethodPut, url, payload, customHeaders)
+}
+
+// PatchWithContext performs a Patch request against the Redfish service.
+func (c *TestClient) PatchWithContext(ctx context.Context, url string, payload interface{}) (*http.Response, error) {
+	return c.performAction(ctx, http.MethodPatch, url, payload, nil)
+}
+
+// PatchWithHeadersContext performs a Patch request against the Redfish service.
+func (c *TestClient) PatchWithHeadersContext(ctx context.Context, url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
+	return c.performAction(ctx, http.MethodPatch, url, payload, customHeaders)
+}
+
+// DeleteWithContext performs a Delete request against the Redfish service.
+func (c *TestClient) DeleteWithContext(ctx context.Context, url string) (*http.Response, error) {
+	return c.performAction(ctx, http.MethodDelete, url, nil, nil)
+}
+
+// DeleteWithHeadersContext performs a Delete request against the Redfish service.
+func (c *TestClient) DeleteWithHeadersContext(ctx context.Context, url string, customHeaders map[string]string) (*http.Response, error) {
+	return c.performAction(ctx, http.MethodDelete, url, nil, customHeaders)
 }
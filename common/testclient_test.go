@@ -0,0 +1,94 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// TestTestClientRoutes tests answering calls by their method and URL.
+func TestTestClientRoutes(t *testing.T) {
+	testClient := &TestClient{
+		Routes: map[string]interface{}{
+			"GET /redfish/v1/Systems/*":     `{"Id": "any"}`,
+			"GET /redfish/v1/Systems/1":     `{"Id": "1"}`,
+			"/redfish/v1/Systems/*/Bios":    `{"Id": "BIOS"}`,
+			"GET /redfish/v1/Managers/**":   &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"Id": "manager"}`))},
+			"DELETE /redfish/v1/Sessions/*": &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(`{}`))},
+			"PATCH /redfish/v1/Systems/*": TestRouteHandler(func(call TestAPICall) (*http.Response, error) {
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(call.Payload))}, nil
+			}),
+		},
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"Id": "custom"}`))}},
+		},
+	}
+
+	tests := []struct {
+		method, url, expected string
+	}{
+		{http.MethodGet, "/redfish/v1/Systems/1", `{"Id": "1"}`},
+		{http.MethodGet, "/redfish/v1/Systems/2", `{"Id": "any"}`},
+		{http.MethodGet, "/redfish/v1/Systems/2/Bios", `{"Id": "BIOS"}`},
+		{http.MethodGet, "/redfish/v1/Managers/1/EthernetInterfaces", `{"Id": "manager"}`},
+		{http.MethodGet, "/redfish/v1/Managers/1", `{"Id": "manager"}`},
+		{http.MethodPatch, "/redfish/v1/Systems/1", `map[AssetTag:new]`},
+		// Unrouted calls are answered in order from CustomReturnForActions.
+		{http.MethodGet, "/redfish/v1/Chassis", `{"Id": "custom"}`},
+	}
+	for _, test := range tests {
+		var resp *http.Response
+		var err error
+		if test.method == http.MethodPatch {
+			resp, err = testClient.Patch(test.url, map[string]string{"AssetTag": "new"})
+		} else {
+			resp, err = testClient.Get(test.url)
+		}
+		if err != nil {
+			t.Fatalf("%s %s: unexpected error: %v", test.method, test.url, err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if string(body) != test.expected {
+			t.Errorf("%s %s: expected %s, got %s", test.method, test.url, test.expected, body)
+		}
+	}
+
+	if _, err := testClient.Delete("/redfish/v1/Sessions/1"); !IsNotFound(err) {
+		t.Errorf("Expected the route error status, got: %v", err)
+	}
+}
+
+type recordingT struct {
+	errors []string
+}
+
+func (t *recordingT) Helper() {}
+
+func (t *recordingT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+// TestTestClientAssertCalls tests the call assertions.
+func TestTestClientAssertCalls(t *testing.T) {
+	testClient := &TestClient{}
+	_, _ = testClient.Get("/redfish/v1/Systems/1")
+	_, _ = testClient.Patch("/redfish/v1/Systems/1", map[string]string{"AssetTag": "new"})
+	_, _ = testClient.Patch("/redfish/v1/Systems/2", map[string]string{"AssetTag": "other"})
+
+	testClient.AssertCalls(t, http.MethodPatch, "/redfish/v1/Systems/*", 1, "AssetTag:new")
+	testClient.AssertCalls(t, http.MethodPatch, "/redfish/v1/Systems/*", 2)
+	testClient.AssertCalls(t, "", "/redfish/v1/**", 3)
+	testClient.AssertCalls(t, http.MethodDelete, "/redfish/v1/Systems/*", 0)
+
+	failing := &recordingT{}
+	if testClient.AssertCalls(failing, http.MethodPatch, "/redfish/v1/Systems/1", 2) || len(failing.errors) != 1 {
+		t.Errorf("Expected the assertion to fail: %v", failing.errors)
+	}
+}
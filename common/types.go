@@ -5,11 +5,13 @@
 package common
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"reflect"
+	"strings"
 )
 
 // DefaultServiceRoot is the default path to the Redfish service endpoint.
@@ -29,6 +31,20 @@ type Client interface {
 	PutWithHeaders(url string, payload interface{}, customHeaders map[string]string) (*http.Response, error)
 	Delete(url string) (*http.Response, error)
 	DeleteWithHeaders(url string, customHeaders map[string]string) (*http.Response, error)
+
+	// The context variants use the provided ctx for a single request.
+	GetWithContext(ctx context.Context, url string) (*http.Response, error)
+	GetWithHeadersContext(ctx context.Context, url string, customHeaders map[string]string) (*http.Response, error)
+	PostWithContext(ctx context.Context, url string, payload interface{}) (*http.Response, error)
+	PostWithHeadersContext(ctx context.Context, url string, payload interface{}, customHeaders map[string]string) (*http.Response, error)
+	PostMultipartWithContext(ctx context.Context, url string, payload map[string]io.Reader) (*http.Response, error)
+	PostMultipartWithHeadersContext(ctx context.Context, url string, payload map[string]io.Reader, customHeaders map[string]string) (*http.Response, error)
+	PatchWithContext(ctx context.Context, url string, payload interface{}) (*http.Response, error)
+	PatchWithHeadersContext(ctx context.Context, url string, payload interface{}, customHeaders map[string]string) (*http.Response, error)
+	PutWithContext(ctx context.Context, url string, payload interface{}) (*http.Response, error)
+	PutWithHeadersContext(ctx context.Context, url string, payload interface{}, customHeaders map[string]string) (*http.Response, error)
+	DeleteWithContext(ctx context.Context, url string) (*http.Response, error)
+	DeleteWithHeadersContext(ctx context.Context, url string, customHeaders map[string]string) (*http.Response, error)
 }
 
 // Entity provides the common basis for all Redfish and Swordfish objects.
@@ -41,6 +57,9 @@ type Entity struct {
 	Name string `json:"Name"`
 	// Client is the REST client interface to the system.
 	Client Client
+	// etag contains the Etag header or @odata.etag returned when the entity
+	// was fetched. It is sent as If-Match with any changes to the entity.
+	etag string
 }
 
 // SetClient sets the API client connection to use for accessing this
@@ -49,67 +68,277 @@ func (e *Entity) SetClient(c Client) {
 	e.Client = c
 }
 
-// Update commits changes to an entity.
+// ETag gets the entity tag returned by the service when this entity was
+// fetched, if there was one.
+func (e *Entity) ETag() string {
+	return e.etag
+}
+
+// setETag records the entity tag for this entity.
+func (e *Entity) setETag(etag string) {
+	e.etag = etag
+}
+
+// ifMatchHeaders returns headers with If-Match set to the entity tag, if
+// there is one.
+func (e *Entity) ifMatchHeaders() map[string]string {
+	headers := make(map[string]string)
+	if e.etag != "" {
+		headers["If-Match"] = e.etag
+	}
+	return headers
+}
+
+// checkResponse closes the response to a request sent with If-Match and
+// converts a 412 status into a *PreconditionFailedError. The body is read
+// to the end first so the connection can be reused.
+func (e *Entity) checkResponse(resp *http.Response, err error) error {
+	if err != nil {
+		if se, ok := err.(*Error); ok && se.HTTPReturnedStatusCode == http.StatusPreconditionFailed {
+			return &PreconditionFailedError{ETag: e.etag, Err: se}
+		}
+		return err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.Body.Close()
+}
+
+// post sends payload to uri, usually one of the entity's action targets. The
+// entity tag is sent as If-Match, if there is one.
+func (e *Entity) post(ctx context.Context, uri string, payload interface{}) error {
+	return e.checkResponse(e.Client.PostWithHeadersContext(ctx, uri, payload, e.ifMatchHeaders()))
+}
+
+// patch sends payload to the entity as a PATCH request. The entity tag is
+// sent as If-Match, if there is one.
+func (e *Entity) patch(ctx context.Context, payload interface{}) error {
+	resp, err := e.Client.PatchWithHeadersContext(ctx, e.ODataID, payload, e.ifMatchHeaders())
+	if err := e.checkResponse(resp, err); err != nil {
+		return err
+	}
+
+	// The entity tag changes with the entity. Use the new one if the service
+	// returned it, rather than sending a stale one with the next change.
+	if etag := resp.Header.Get("Etag"); etag != "" {
+		e.etag = etag
+	}
+	return nil
+}
+
+// PostAction sends payload to target, one of the actions of entity. The
+// entity tag is sent as If-Match, if there is one, and a 412 response is
+// returned as a *PreconditionFailedError.
+//
+// PostAction and PatchEntity are exported because the resources of the
+// redfish and swordfish packages send their actions and changes through
+// them. They are functions rather than Entity methods so they are not
+// promoted to the API of every resource embedding Entity.
+func PostAction(ctx context.Context, entity *Entity, target string, payload interface{}) error {
+	return entity.post(ctx, target, payload)
+}
+
+// PatchEntity sends payload to entity as a PATCH request, for changes that
+// are not made through Update. The entity tag is sent as If-Match, if there
+// is one, and a 412 response is returned as a *PreconditionFailedError.
+func PatchEntity(ctx context.Context, entity *Entity, payload interface{}) error {
+	return entity.patch(ctx, payload)
+}
+
+// Update commits changes to an entity. allowedUpdates lists the properties
+// that can be changed, with nested ones named by their dotted path, for
+// example "Boot.BootSourceOverrideTarget". Nothing is sent if any other
+// property changed, and the error names its dotted path.
 func (e *Entity) Update(originalEntity, currentEntity reflect.Value, allowedUpdates []string) error {
+	return e.UpdateContext(context.Background(), originalEntity, currentEntity, allowedUpdates)
+}
+
+// UpdateContext is the same as Update, but uses ctx for the request.
+func (e *Entity) UpdateContext(ctx context.Context, originalEntity, currentEntity reflect.Value, allowedUpdates []string) error {
 	payload := make(map[string]interface{})
+	err := diffStruct(originalEntity, currentEntity, "", allowedUpdates, payload)
+	if err != nil {
+		return err
+	}
+
+	// If there are any allowed updates, try to send updates to the system and
+	// return the result.
+	if len(payload) > 0 {
+		if err := e.patch(ctx, payload); err != nil {
+			return err
+		}
+	}
 
-	for i := 0; i < originalEntity.NumField(); i++ {
-		if !originalEntity.Field(i).CanInterface() {
+	return nil
+}
+
+var (
+	entityType    = reflect.TypeOf(Entity{})
+	marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// diffStruct adds the fields of current that differ from original to
+// payload, keyed by their JSON names. Nested structs are compared field by
+// field so only the changed properties are included. Each change is checked
+// against allowedUpdates using its dotted path below path, for example
+// "Boot.BootSourceOverrideTarget".
+func diffStruct(original, current reflect.Value, path string, allowedUpdates []string, payload map[string]interface{}) error {
+	for i := 0; i < original.NumField(); i++ {
+		if !original.Field(i).CanInterface() {
 			// Private field or something that we can't access
 			continue
 		}
-		fieldType := originalEntity.Type().Field(i).Type.Kind()
-		if fieldType == reflect.Struct || fieldType == reflect.Ptr || fieldType == reflect.Slice {
-			// TODO: Handle more complicated data types
+
+		field := original.Type().Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			// The fields of embedded structs are part of this object, except
+			// for the Entity ones which cannot be updated.
+			if field.Type == entityType {
+				continue
+			}
+			if err := diffStruct(original.Field(i), current.Field(i), path, allowedUpdates, payload); err != nil {
+				return err
+			}
 			continue
 		}
-		fieldName := originalEntity.Type().Field(i).Name
-		jsonName := originalEntity.Type().Field(i).Tag.Get("json")
-		if jsonName != "" {
-			fieldName = jsonName
-		}
-		originalValue := originalEntity.Field(i).Interface()
-		currentValue := currentEntity.Field(i).Interface()
-		if originalValue == nil && currentValue == nil {
+
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
 			continue
-		} else if originalValue == nil {
-			payload[fieldName] = currentValue
-		} else if reflect.TypeOf(originalValue).Kind() != reflect.Map {
-			if originalValue != currentValue {
-				payload[fieldName] = currentValue
-			}
-		} else if !reflect.DeepEqual(originalValue, currentValue) {
-			payload[fieldName] = currentValue
 		}
-	}
+		if name == "" {
+			name = field.Name
+		}
 
-	// See if we are attempting to update anything that is not allowed
-	for field := range payload {
-		found := false
-		for _, name := range allowedUpdates {
-			if name == field {
-				found = true
-				break
-			}
+		fieldPath := name
+		if path != "" {
+			fieldPath = path + "." + name
+		}
+
+		value, changed, err := diffValue(original.Field(i), current.Field(i), fieldPath, allowedUpdates)
+		if err != nil {
+			return err
+		}
+		if changed {
+			payload[name] = value
 		}
+	}
+
+	return nil
+}
+
+// diffValue compares a single field, returning the value to send if it
+// changed.
+func diffValue(original, current reflect.Value, path string, allowedUpdates []string) (interface{}, bool, error) {
+	if original.Kind() == reflect.Ptr && !original.IsNil() && !current.IsNil() {
+		return diffValue(original.Elem(), current.Elem(), path, allowedUpdates)
+	}
+
+	if original.Kind() == reflect.Struct &&
+		!original.Type().Implements(marshalerType) &&
+		!reflect.PtrTo(original.Type()).Implements(marshalerType) {
+		nested := make(map[string]interface{})
+		err := diffStruct(original, current, path, allowedUpdates, nested)
+		return nested, len(nested) > 0, err
+	}
+
+	// Anything else, including slices and maps, is sent in full if changed.
+	if reflect.DeepEqual(original.Interface(), current.Interface()) {
+		return nil, false, nil
+	}
+
+	// Read only changes fail the whole update rather than being left out of
+	// the payload, so callers know they were not made.
+	if !updateAllowed(path, allowedUpdates) {
+		return nil, false, fmt.Errorf("%s field is read only", path)
+	}
 
-		if !found {
-			return fmt.Errorf("%s field is read only", field)
+	return current.Interface(), true, nil
+}
+
+// updateAllowed checks if path, or one of the structs containing it, is in
+// allowedUpdates.
+func updateAllowed(path string, allowedUpdates []string) bool {
+	for _, name := range allowedUpdates {
+		if name == path || strings.HasPrefix(path, name+".") {
+			return true
 		}
 	}
+	return false
+}
 
-	// If there are any allowed updates, try to send updates to the system and
-	// return the result.
-	if len(payload) > 0 {
-		_, err := e.Client.Patch(e.ODataID, payload) //nolint:bodyclose
-		if err != nil {
+// GetObject retrieves the resource at uri and decodes it into obj. If obj is
+// an entity, it is set to use c for any further requests and records the
+// Etag returned by the service.
+func GetObject(ctx context.Context, c Client, uri string, obj interface{}) error {
+	return GetObjectWithOptions(ctx, c, uri, nil, obj)
+}
+
+// GetObjectWithOptions is the same as GetObject, but adds the query options
+// opts, if not nil, to the request.
+func GetObjectWithOptions(ctx context.Context, c Client, uri string, opts *QueryOptions, obj interface{}) error {
+	if opts != nil {
+		if err := opts.check(c); err != nil {
 			return err
 		}
+		uri = addQuery(uri, opts.resourceParams()...)
+	}
+
+	return getObject(ctx, c, uri, obj)
+}
+
+// getObject retrieves the resource at uri and decodes it into obj.
+func getObject(ctx context.Context, c Client, uri string, obj interface{}) error {
+	resp, err := c.GetWithContext(ctx, uri)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	err = decodeObject(c, data, obj)
+	if err != nil {
+		return err
+	}
+
+	// The Etag header takes precedence over @odata.etag.
+	if etag := resp.Header.Get("Etag"); etag != "" {
+		if entity, ok := obj.(interface{ setETag(string) }); ok {
+			entity.setETag(etag)
+		}
+	}
+	return nil
+}
+
+// decodeObject decodes the JSON in data into obj. If obj is an entity, it is
+// set to use c for any further requests and records the @odata.etag.
+func decodeObject(c Client, data []byte, obj interface{}) error {
+	if err := json.Unmarshal(data, obj); err != nil {
+		return err
 	}
 
+	setObjectClient(c, obj)
+	if entity, ok := obj.(interface{ setETag(string) }); ok {
+		var t struct {
+			ODataEtag string `json:"@odata.etag"`
+		}
+		if err := json.Unmarshal(data, &t); err == nil {
+			entity.setETag(t.ODataEtag)
+		}
+	}
 	return nil
 }
 
+// setObjectClient sets obj to use c if it is an entity.
+func setObjectClient(c Client, obj interface{}) {
+	if entity, ok := obj.(interface{ SetClient(Client) }); ok {
+		entity.SetClient(c)
+	}
+}
+
 // Link is an OData link reference
 type Link string
 
@@ -752,8 +981,26 @@ func (e *Error) Error() string {
 	return string(e.rawData)
 }
 
+// PreconditionFailedError is returned when a change sent with If-Match is
+// rejected because the resource was modified after it was retrieved. The
+// resource should be retrieved again before retrying the change.
+type PreconditionFailedError struct {
+	// ETag is the entity tag the change was sent with.
+	ETag string
+	// Err is the error returned by the service.
+	Err *Error
+}
+
+func (e *PreconditionFailedError) Error() string {
+	return fmt.Sprintf("resource was modified since it was retrieved (ETag %s): %s", e.ETag, e.Err)
+}
+
+// Unwrap returns the error returned by the service.
+func (e *PreconditionFailedError) Unwrap() error {
+	return e.Err
+}
+
 // ErrExtendedInfo is for redfish ExtendedInfo error response
-// TODO: support RelatedProperties
 type ErrExtendedInfo struct {
 	// Indicating a specific error or message (not to be confused with the HTTP status code).
 	// This code can be used to access a detailed message from a message registry.
@@ -768,4 +1015,7 @@ type ErrExtendedInfo struct {
 	Severity string
 	// An optional string describing recommended action(s) to take to resolve the error.
 	Resolution string
+	// An optional array of JSON Pointers identifying the properties in the
+	// request body that caused the error.
+	RelatedProperties []string `json:",omitempty"`
 }
@@ -0,0 +1,200 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestGetObjectETag tests that the Etag header takes precedence over
+// @odata.etag.
+func TestGetObjectETag(t *testing.T) {
+	body := `{"@odata.id": "/redfish/v1/Systems/1", "@odata.etag": "W/\"body\""}`
+	testClient := &TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				&http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(body))},
+				&http.Response{
+					StatusCode: 200,
+					Body:       io.NopCloser(strings.NewReader(body)),
+					Header:     http.Header{"Etag": []string{`W/"header"`}},
+				},
+			},
+		},
+	}
+
+	var entity Entity
+	if err := GetObject(context.Background(), testClient, "/redfish/v1/Systems/1", &entity); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if entity.ETag() != `W/"body"` {
+		t.Errorf("Expected the @odata.etag, got: %s", entity.ETag())
+	}
+
+	if err := GetObject(context.Background(), testClient, "/redfish/v1/Systems/1", &entity); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if entity.ETag() != `W/"header"` {
+		t.Errorf("Expected the Etag header, got: %s", entity.ETag())
+	}
+}
+
+// TestEntityIfMatch tests that changes are sent with If-Match.
+func TestEntityIfMatch(t *testing.T) {
+	testClient := &TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodPatch: {
+				&http.Response{
+					StatusCode: 200,
+					Body:       io.NopCloser(strings.NewReader("")),
+					Header:     http.Header{"Etag": []string{`W/"2"`}},
+				},
+				&http.Response{StatusCode: 204, Body: io.NopCloser(strings.NewReader(""))},
+			},
+		},
+	}
+	entity := Entity{ODataID: "/redfish/v1/Systems/1", Client: testClient, etag: `W/"1"`}
+
+	if err := entity.post(context.Background(), "/redfish/v1/Systems/1/Actions/Reset", nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := entity.patch(context.Background(), map[string]string{"AssetTag": "new"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	calls := testClient.CapturedCalls()
+	for _, call := range calls {
+		if call.CustomHeaders["If-Match"] != `W/"1"` {
+			t.Errorf("Expected %s to be sent with If-Match, got: %v", call.Action, call.CustomHeaders)
+		}
+	}
+
+	if entity.ETag() != `W/"2"` {
+		t.Errorf("Expected the new entity tag after the change, got: %s", entity.ETag())
+	}
+
+	// Services not returning the new entity tag do not clear it.
+	if err := entity.patch(context.Background(), map[string]string{"AssetTag": "other"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if entity.ETag() != `W/"2"` {
+		t.Errorf("Expected the entity tag to be kept, got: %s", entity.ETag())
+	}
+}
+
+// TestEntityPreconditionFailed tests that a 412 is returned as a
+// *PreconditionFailedError.
+func TestEntityPreconditionFailed(t *testing.T) {
+	testClient := &TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodPost: {
+				&http.Response{StatusCode: http.StatusPreconditionFailed, Body: io.NopCloser(strings.NewReader(""))},
+			},
+		},
+	}
+	entity := Entity{ODataID: "/redfish/v1/Systems/1", Client: testClient, etag: `W/"1"`}
+
+	err := entity.post(context.Background(), "/redfish/v1/Systems/1/Actions/Reset", nil)
+
+	var preconditionFailed *PreconditionFailedError
+	if !errors.As(err, &preconditionFailed) {
+		t.Fatalf("Expected a PreconditionFailedError, got: %v", err)
+	}
+	if preconditionFailed.ETag != `W/"1"` {
+		t.Errorf("Unexpected entity tag: %s", preconditionFailed.ETag)
+	}
+
+	var serviceError *Error
+	if !errors.As(err, &serviceError) || serviceError.HTTPReturnedStatusCode != http.StatusPreconditionFailed {
+		t.Errorf("The service error should be available: %v", err)
+	}
+}
+
+type testSettings struct {
+	Enabled bool
+	Servers []string
+}
+
+// Embedded is exported so its fields are visible to Update.
+type Embedded struct {
+	Description string
+}
+
+type testResource struct {
+	Entity
+	Embedded
+	Count    int `json:",omitempty"`
+	Settings testSettings
+	Limits   *testSettings
+	Renamed  string `json:"NewName"`
+	Ignored  string `json:"-"`
+}
+
+// TestEntityUpdateNested tests that only the changed nested properties are
+// sent.
+func TestEntityUpdateNested(t *testing.T) {
+	original := testResource{
+		Settings: testSettings{Enabled: true, Servers: []string{"a"}},
+		Limits:   &testSettings{Servers: []string{"b"}},
+	}
+	current := original
+	current.Limits = &testSettings{Servers: []string{"b"}}
+	current.Settings.Servers = []string{"a", "c"}
+	current.Limits.Enabled = true
+	current.Count = 2
+	current.Renamed = "new"
+	current.Ignored = "ignored"
+	current.ID = "ignored"
+	current.Description = "changed"
+
+	testClient := &TestClient{}
+	current.SetClient(testClient)
+
+	err := current.Update(reflect.ValueOf(original), reflect.ValueOf(current),
+		[]string{"Count", "Description", "Settings.Servers", "Limits", "NewName"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	calls := testClient.CapturedCalls()
+	expected := "map[Count:2 Description:changed Limits:map[Enabled:true] NewName:new Settings:map[Servers:[a c]]]"
+	if len(calls) != 1 || calls[0].Payload != expected {
+		t.Errorf("Expected payload %s, got: %v", expected, calls)
+	}
+
+	current = original
+	current.SetClient(testClient)
+	current.Description = "changed"
+	current.Settings.Enabled = false
+	err = current.Update(reflect.ValueOf(original), reflect.ValueOf(current), []string{"Description"})
+	if err == nil || err.Error() != "Settings.Enabled field is read only" {
+		t.Errorf("Expected read only error, got: %v", err)
+	}
+
+	current = original
+	current.SetClient(testClient)
+	current.Limits = &testSettings{Servers: []string{"b", "c"}}
+	err = current.Update(reflect.ValueOf(original), reflect.ValueOf(current), []string{"Description"})
+	if err == nil || err.Error() != "Limits.Servers field is read only" {
+		t.Errorf("Expected read only error, got: %v", err)
+	}
+	if calls := testClient.CapturedCalls(); len(calls) != 1 {
+		t.Errorf("Expected nothing to be sent with read only changes, got: %v", calls)
+	}
+
+	current = original
+	current.SetClient(testClient)
+	current.Count = 3
+	err = current.Update(reflect.ValueOf(original), reflect.ValueOf(current), []string{"Description"})
+	if err == nil || err.Error() != "Count field is read only" {
+		t.Errorf("Expected read only error, got: %v", err)
+	}
+}
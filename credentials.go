@@ -0,0 +1,326 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"bufio"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+)
+
+const (
+	// DefaultUsernameEnv is the environment variable holding the user name
+	// if EnvCredentials.UsernameEnv is not set.
+	DefaultUsernameEnv = "REDFISH_USERNAME"
+	// DefaultPasswordEnv is the environment variable holding the password
+	// if EnvCredentials.PasswordEnv is not set.
+	DefaultPasswordEnv = "REDFISH_PASSWORD"
+)
+
+// keyfileKeySize is the size of the AES-256 key of a keyfile.
+const keyfileKeySize = 32
+
+// Credentials are the user name and password to authenticate with.
+type Credentials struct {
+	Username string
+	Password string
+}
+
+// CredentialProvider provides the credentials to authenticate with a
+// service. It is called when connecting, whenever a new session is created
+// and, with basic authentication, when the service rejects the credentials,
+// so changed passwords are used without creating a new client.
+type CredentialProvider interface {
+	// Credentials returns the credentials for the service at endpoint.
+	Credentials(ctx context.Context, endpoint string) (Credentials, error)
+}
+
+// staticCredentials provides the Username and Password of a ClientConfig.
+type staticCredentials Credentials
+
+// Credentials returns the configured credentials.
+func (s staticCredentials) Credentials(ctx context.Context, endpoint string) (Credentials, error) {
+	return Credentials(s), nil
+}
+
+// EnvCredentials reads the credentials from environment variables.
+type EnvCredentials struct {
+	// UsernameEnv is the variable holding the user name. Defaults to
+	// DefaultUsernameEnv.
+	UsernameEnv string
+	// PasswordEnv is the variable holding the password. Defaults to
+	// DefaultPasswordEnv.
+	PasswordEnv string
+}
+
+// Credentials returns the credentials in the environment variables.
+func (e *EnvCredentials) Credentials(ctx context.Context, endpoint string) (Credentials, error) {
+	usernameEnv := e.UsernameEnv
+	if usernameEnv == "" {
+		usernameEnv = DefaultUsernameEnv
+	}
+	passwordEnv := e.PasswordEnv
+	if passwordEnv == "" {
+		passwordEnv = DefaultPasswordEnv
+	}
+
+	username, ok := os.LookupEnv(usernameEnv)
+	if !ok || username == "" {
+		return Credentials{}, fmt.Errorf("environment variable %s is not set", usernameEnv)
+	}
+	return Credentials{Username: username, Password: os.Getenv(passwordEnv)}, nil
+}
+
+// NetrcCredentials reads the credentials from a file in the netrc format,
+// using the login and password of the machine matching the host of the
+// endpoint, with or without its port, or else the default entry.
+type NetrcCredentials struct {
+	// Path is the path of the file. Defaults to the NETRC environment
+	// variable, or else .netrc in the home directory.
+	Path string
+}
+
+// Credentials returns the credentials of the machine of endpoint.
+func (n *NetrcCredentials) Credentials(ctx context.Context, endpoint string) (Credentials, error) {
+	name := n.Path
+	if name == "" {
+		name = os.Getenv("NETRC")
+	}
+	if name == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return Credentials{}, err
+		}
+		name = filepath.Join(home, ".netrc")
+	}
+
+	f, err := os.Open(name)
+	if err != nil {
+		return Credentials{}, err
+	}
+	defer f.Close()
+
+	entries, err := parseNetrc(f)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("invalid netrc file %s: %w", name, err)
+	}
+
+	credentials, ok := lookupCredentials(entries, endpoint)
+	if !ok {
+		return Credentials{}, fmt.Errorf("no credentials for %s in %s", endpoint, name)
+	}
+	return credentials, nil
+}
+
+// parseNetrc returns the credentials of the machines of a netrc file, with
+// the default entry as "*".
+func parseNetrc(r io.Reader) (map[string]Credentials, error) {
+	entries := make(map[string]Credentials)
+
+	var machine, field string
+	var current Credentials
+	inMacro := false
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		// Macro definitions end with an empty line.
+		if inMacro {
+			inMacro = strings.TrimSpace(line) != ""
+			continue
+		}
+
+		for _, token := range strings.Fields(line) {
+			if field != "" {
+				switch field {
+				case "machine":
+					machine = token
+				case "login":
+					current.Username = token
+				case "password":
+					current.Password = token
+				}
+				field = ""
+				continue
+			}
+
+			switch token {
+			case "machine", "default":
+				if machine != "" {
+					entries[machine] = current
+				}
+				machine, current = "*", Credentials{}
+				if token == "machine" {
+					field = token
+				}
+			case "login", "password", "account":
+				field = token
+			case "macdef":
+				inMacro = true
+			}
+			if inMacro {
+				break
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if field != "" {
+		return nil, fmt.Errorf("missing value of %s", field)
+	}
+	if machine != "" {
+		entries[machine] = current
+	}
+
+	return entries, nil
+}
+
+// KeyfileCredentials reads the credentials from a file encrypted with
+// AES-256-GCM, as written by WriteKeyfile. The file maps hosts, with or
+// without their port, to credentials. The "*" entry is used for any other
+// host.
+type KeyfileCredentials struct {
+	// Path is the path of the keyfile.
+	Path string
+	// Key is the 32 byte key the keyfile is encrypted with.
+	Key []byte
+}
+
+// Credentials returns the credentials of the host of endpoint.
+func (k *KeyfileCredentials) Credentials(ctx context.Context, endpoint string) (Credentials, error) {
+	gcm, err := keyfileCipher(k.Key)
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	data, err := os.ReadFile(k.Path)
+	if err != nil {
+		return Credentials{}, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return Credentials{}, fmt.Errorf("invalid keyfile %s", k.Path)
+	}
+	plaintext, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("failed to decrypt keyfile %s: %w", k.Path, err)
+	}
+
+	var entries map[string]Credentials
+	if err := json.Unmarshal(plaintext, &entries); err != nil {
+		return Credentials{}, fmt.Errorf("invalid keyfile %s: %w", k.Path, err)
+	}
+
+	credentials, ok := lookupCredentials(entries, endpoint)
+	if !ok {
+		return Credentials{}, fmt.Errorf("no credentials for %s in %s", endpoint, k.Path)
+	}
+	return credentials, nil
+}
+
+// WriteKeyfile writes the credentials of each host to the file name,
+// encrypted with key, which must be 32 bytes long. The file is only
+// readable by its owner.
+func WriteKeyfile(name string, key []byte, credentials map[string]Credentials) error {
+	gcm, err := keyfileCipher(key)
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := json.Marshal(credentials)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	return os.WriteFile(name, gcm.Seal(nonce, nonce, plaintext, nil), 0o600)
+}
+
+func keyfileCipher(key []byte) (cipher.AEAD, error) {
+	if len(key) != keyfileKeySize {
+		return nil, fmt.Errorf("keyfile key must be %d bytes long", keyfileKeySize)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// refreshCredentials gets the credentials again to replace the failed basic
+// auth information. If another request already replaced it, the new
+// credentials are reused.
+func (c *APIClient) refreshCredentials(ctx context.Context, failed *redfish.AuthToken) error {
+	// Only one request calls the provider, the others wait for its
+	// credentials. Requests still sent with the failed ones are not blocked.
+	c.refreshLock.Lock()
+	defer c.refreshLock.Unlock()
+
+	if c.currentAuth() != failed {
+		return nil
+	}
+
+	credentials, err := c.credentials.Credentials(ctx, c.endpoint)
+	if err != nil {
+		return err
+	}
+
+	c.authLock.Lock()
+	defer c.authLock.Unlock()
+	c.basicCredentials = &credentials
+	c.auth = &redfish.AuthToken{BasicAuth: true}
+	return nil
+}
+
+// currentCredentials returns the credentials sent with basic authentication.
+func (c *APIClient) currentCredentials() *Credentials {
+	c.authLock.Lock()
+	defer c.authLock.Unlock()
+	return c.basicCredentials
+}
+
+// shouldRefreshCredentials checks if a failed request should be retried with
+// credentials requested again from the CredentialProvider.
+func (c *APIClient) shouldRefreshCredentials(auth *redfish.AuthToken, err error) bool {
+	if c.credentials == nil || auth == nil || !auth.BasicAuth {
+		return false
+	}
+	// Configured credentials never change.
+	if _, ok := c.credentials.(staticCredentials); ok {
+		return false
+	}
+	return common.IsUnauthorized(err)
+}
+
+// lookupCredentials returns the credentials of the host of endpoint, trying
+// the host with its port first, and then the "*" entry.
+func lookupCredentials(entries map[string]Credentials, endpoint string) (Credentials, bool) {
+	keys := []string{endpoint}
+	if u, err := url.Parse(endpoint); err == nil && u.Host != "" {
+		keys = []string{u.Host, u.Hostname()}
+	}
+	keys = append(keys, "*")
+
+	for _, key := range keys {
+		if credentials, ok := entries[key]; ok {
+			return credentials, true
+		}
+	}
+	return Credentials{}, false
+}
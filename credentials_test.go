@@ -0,0 +1,238 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stmcginnis/gofish/mockserver"
+)
+
+// setenv sets an environment variable for the duration of the test.
+func setenv(t *testing.T, key, value string) {
+	previous, ok := os.LookupEnv(key)
+	os.Setenv(key, value)
+	t.Cleanup(func() {
+		if ok {
+			os.Setenv(key, previous)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+}
+
+// TestEnvCredentials tests reading the credentials from the environment.
+func TestEnvCredentials(t *testing.T) {
+	setenv(t, DefaultUsernameEnv, "admin")
+	setenv(t, DefaultPasswordEnv, "secret")
+	setenv(t, "BMC_USER", "operator")
+	setenv(t, "BMC_PASSWORD", "other")
+
+	credentials, err := (&EnvCredentials{}).Credentials(context.Background(), "https://bmc")
+	if err != nil || credentials != (Credentials{Username: "admin", Password: "secret"}) {
+		t.Errorf("Unexpected default credentials: %v %v", credentials, err)
+	}
+
+	credentials, err = (&EnvCredentials{UsernameEnv: "BMC_USER", PasswordEnv: "BMC_PASSWORD"}).Credentials(context.Background(), "https://bmc")
+	if err != nil || credentials != (Credentials{Username: "operator", Password: "other"}) {
+		t.Errorf("Unexpected credentials: %v %v", credentials, err)
+	}
+
+	if _, err := (&EnvCredentials{UsernameEnv: "GOFISH_MISSING"}).Credentials(context.Background(), "https://bmc"); err == nil {
+		t.Error("Expected a missing variable to fail")
+	}
+}
+
+// TestNetrcCredentials tests reading the credentials from a netrc file.
+func TestNetrcCredentials(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "netrc")
+	netrc := `machine bmc1.example.com login admin password secret1
+machine bmc2.example.com:8443
+	login operator
+	password secret2
+macdef init
+	machine ignored login ignored password ignored
+
+machine bmc2.example.com login root password secret3 account ignored
+default login guest password guest
+`
+	if err := os.WriteFile(name, []byte(netrc), 0o600); err != nil {
+		t.Fatalf("Error writing netrc: %s", err)
+	}
+
+	tests := []struct {
+		endpoint string
+		expected Credentials
+	}{
+		{"https://bmc1.example.com", Credentials{"admin", "secret1"}},
+		{"https://bmc1.example.com:443", Credentials{"admin", "secret1"}},
+		{"https://bmc2.example.com:8443", Credentials{"operator", "secret2"}},
+		{"https://bmc2.example.com", Credentials{"root", "secret3"}},
+		{"https://ignored", Credentials{"guest", "guest"}},
+	}
+	provider := &NetrcCredentials{Path: name}
+	for _, test := range tests {
+		credentials, err := provider.Credentials(context.Background(), test.endpoint)
+		if err != nil || credentials != test.expected {
+			t.Errorf("%s: expected %v, got %v %v", test.endpoint, test.expected, credentials, err)
+		}
+	}
+
+	setenv(t, "NETRC", name)
+	if credentials, err := (&NetrcCredentials{}).Credentials(context.Background(), "https://bmc1.example.com"); err != nil || credentials.Username != "admin" {
+		t.Errorf("Expected the NETRC file to be used, got: %v %v", credentials, err)
+	}
+
+	if _, err := parseNetrc(strings.NewReader("machine bmc login")); err == nil {
+		t.Error("Expected a missing login to fail")
+	}
+}
+
+// TestKeyfileCredentials tests reading the credentials from an encrypted
+// keyfile.
+func TestKeyfileCredentials(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "credentials.key")
+	key := bytes.Repeat([]byte{7}, 32)
+	err := WriteKeyfile(name, key, map[string]Credentials{
+		"bmc1.example.com": {Username: "admin", Password: "secret1"},
+		"*":                {Username: "guest", Password: "guest"},
+	})
+	if err != nil {
+		t.Fatalf("Error writing keyfile: %s", err)
+	}
+
+	info, err := os.Stat(name)
+	if err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("Expected the keyfile to be private, got: %v %v", info.Mode(), err)
+	}
+	data, _ := os.ReadFile(name)
+	if bytes.Contains(data, []byte("secret1")) {
+		t.Error("Expected the keyfile to be encrypted")
+	}
+
+	provider := &KeyfileCredentials{Path: name, Key: key}
+	credentials, err := provider.Credentials(context.Background(), "https://bmc1.example.com:443")
+	if err != nil || credentials != (Credentials{Username: "admin", Password: "secret1"}) {
+		t.Errorf("Unexpected credentials: %v %v", credentials, err)
+	}
+	credentials, err = provider.Credentials(context.Background(), "https://bmc2.example.com")
+	if err != nil || credentials.Username != "guest" {
+		t.Errorf("Expected the default credentials, got: %v %v", credentials, err)
+	}
+
+	provider.Key = bytes.Repeat([]byte{8}, 32)
+	if _, err := provider.Credentials(context.Background(), "https://bmc1.example.com"); err == nil {
+		t.Error("Expected the wrong key to fail")
+	}
+	if err := WriteKeyfile(name, []byte("short"), nil); err == nil {
+		t.Error("Expected a short key to fail")
+	}
+}
+
+// rotatingCredentials returns the current password of a rotated account.
+type rotatingCredentials struct {
+	lock     sync.Mutex
+	password string
+	calls    int
+}
+
+func (r *rotatingCredentials) Credentials(ctx context.Context, endpoint string) (Credentials, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.calls++
+	return Credentials{Username: "admin", Password: r.password}, nil
+}
+
+func (r *rotatingCredentials) rotate(password string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.password = password
+}
+
+// TestCredentialProviderRotation tests using rotated passwords when
+// re-authenticating and with basic authentication.
+func TestCredentialProviderRotation(t *testing.T) {
+	for _, basicAuth := range []bool{false, true} {
+		server, err := mockserver.New("mockserver/testdata/simple")
+		if err != nil {
+			t.Fatalf("Error loading mockup: %s", err)
+		}
+		server.Username, server.Password = "admin", "first"
+		ts := server.Start()
+		defer ts.Close()
+
+		provider := &rotatingCredentials{password: "first"}
+		c, err := Connect(ClientConfig{
+			Endpoint:           ts.URL,
+			CredentialProvider: provider,
+			BasicAuth:          basicAuth,
+			ReAuthenticate:     true,
+		})
+		if err != nil {
+			t.Fatalf("Error connecting: %s", err)
+		}
+		if auth := c.currentAuth(); auth.Password != "" {
+			t.Error("The password should not be kept on the AuthToken")
+		}
+		for i := 0; i < 2; i++ {
+			if _, err := c.Service.Systems(); err != nil {
+				t.Fatalf("BasicAuth %t: error using the credentials: %s", basicAuth, err)
+			}
+		}
+		if provider.calls != 1 {
+			t.Errorf("BasicAuth %t: expected the credentials to be reused, got %d calls", basicAuth, provider.calls)
+		}
+
+		// The password is rotated and the session expires.
+		server.Password = "second"
+		provider.rotate("second")
+		server.Inject(mockserver.Fault{Times: 1, ExpireSession: true})
+
+		if _, err := c.Service.Systems(); err != nil {
+			t.Errorf("BasicAuth %t: expected the new password to be used, got: %v", basicAuth, err)
+		}
+		if provider.calls != 2 {
+			t.Errorf("BasicAuth %t: expected the credentials to be requested again, got %d calls", basicAuth, provider.calls)
+		}
+	}
+}
+
+// TestCloneWithSessionCredentials tests that cloned clients get the
+// credentials from the provider rather than copying them.
+func TestCloneWithSessionCredentials(t *testing.T) {
+	server, err := mockserver.New("mockserver/testdata/simple")
+	if err != nil {
+		t.Fatalf("Error loading mockup: %s", err)
+	}
+	server.Username, server.Password = "admin", "first"
+	ts := server.Start()
+	defer ts.Close()
+
+	provider := &rotatingCredentials{password: "first"}
+	c, err := Connect(ClientConfig{Endpoint: ts.URL, CredentialProvider: provider, BasicAuth: true})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	clone, err := c.CloneWithSession()
+	if err != nil {
+		t.Fatalf("Error cloning the client: %s", err)
+	}
+	if provider.calls < 2 || clone.basicCredentials != nil {
+		t.Errorf("Expected the clone to request its own credentials, got %d calls", provider.calls)
+	}
+	if _, err := clone.GetSession(); err != nil {
+		t.Errorf("Expected the clone to use a session: %s", err)
+	}
+	if _, err := clone.Service.Systems(); err != nil {
+		t.Errorf("Error using the session: %s", err)
+	}
+}
@@ -0,0 +1,301 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// harVersion is the version of the HTTP Archive format written.
+const harVersion = "1.2"
+
+// HARRecorder records the requests made by a client in the HTTP Archive
+// (HAR) format, which can be opened by browser developer tools and other
+// HTTP tools. Attach it with ClientConfig.HARRecorder or
+// APIClient.SetHARRecorder, then save the traffic with WriteFile:
+//
+//	recorder := gofish.NewHARRecorder()
+//	c, err := gofish.Connect(gofish.ClientConfig{..., HARRecorder: recorder})
+//	...
+//	err = recorder.WriteFile("bmc.har")
+//
+// Every attempt of a request is recorded, including retries and failures to
+// reach the service. Credentials are redacted as in dumps.
+type HARRecorder struct {
+	lock    sync.Mutex
+	entries []HAREntry
+}
+
+// NewHARRecorder creates an empty HARRecorder.
+func NewHARRecorder() *HARRecorder {
+	return &HARRecorder{}
+}
+
+// HAR is the root of an HTTP Archive.
+type HAR struct {
+	Log HARLog `json:"log"`
+}
+
+// HARLog holds the recorded entries.
+type HARLog struct {
+	Version string     `json:"version"`
+	Creator HARCreator `json:"creator"`
+	Entries []HAREntry `json:"entries"`
+}
+
+// HARCreator identifies the application that created the archive.
+type HARCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// HAREntry is a single request and its response.
+type HAREntry struct {
+	StartedDateTime time.Time `json:"startedDateTime"`
+	// Time is the total time of the request in milliseconds.
+	Time     float64     `json:"time"`
+	Request  HARRequest  `json:"request"`
+	Response HARResponse `json:"response"`
+	Cache    struct{}    `json:"cache"`
+	Timings  HARTimings  `json:"timings"`
+	// Error is the reason no response was received, if any.
+	Error string `json:"_error,omitempty"`
+}
+
+// HARRequest describes a request.
+type HARRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	QueryString []HARNameValue `json:"queryString"`
+	PostData    *HARPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+// HARResponse describes a response. The status is 0 if no response was
+// received.
+type HARResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	Content     HARContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+// HARNameValue is a header, cookie or query parameter.
+type HARNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// HARPostData is the body of a request.
+type HARPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+// HARContent is the body of a response.
+type HARContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+// HARTimings holds the time spent in each phase of a request in
+// milliseconds.
+type HARTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// Entries returns a copy of the entries recorded so far.
+func (r *HARRecorder) Entries() []HAREntry {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return append([]HAREntry{}, r.entries...)
+}
+
+// Reset removes the entries recorded so far.
+func (r *HARRecorder) Reset() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.entries = nil
+}
+
+// HAR returns the archive of the entries recorded so far.
+func (r *HARRecorder) HAR() *HAR {
+	entries := r.Entries()
+	if entries == nil {
+		entries = []HAREntry{}
+	}
+
+	return &HAR{
+		Log: HARLog{
+			Version: harVersion,
+			Creator: HARCreator{Name: "gofish", Version: "1.0"},
+			Entries: entries,
+		},
+	}
+}
+
+// WriteTo writes the archive to w as JSON.
+func (r *HARRecorder) WriteTo(w io.Writer) (int64, error) {
+	data, err := json.MarshalIndent(r.HAR(), "", "  ")
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := w.Write(data)
+	return int64(n), err
+}
+
+// WriteFile writes the archive to the named file, usually with a .har
+// extension.
+func (r *HARRecorder) WriteFile(name string) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+
+	if _, err := r.WriteTo(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// record adds an entry for req, sent at start, and its response or error.
+// The response body is read so it can be recorded and is replaced by a
+// buffered copy. The error reading the body, if any, is returned.
+func (r *HARRecorder) record(req *http.Request, start time.Time, resp *http.Response, err error, redact *redactor) error {
+	responded := time.Now()
+
+	entry := HAREntry{
+		StartedDateTime: start,
+		Request: HARRequest{
+			Method:      req.Method,
+			URL:         req.URL.String(),
+			HTTPVersion: req.Proto,
+			Cookies:     []HARNameValue{},
+			Headers:     harHeaders(req.Header, redact),
+			QueryString: []HARNameValue{},
+			HeadersSize: -1,
+			BodySize:    0,
+		},
+		Response: HARResponse{
+			Cookies:     []HARNameValue{},
+			Headers:     []HARNameValue{},
+			HeadersSize: -1,
+			BodySize:    -1,
+		},
+		Timings: HARTimings{
+			Wait:    milliseconds(responded.Sub(start)),
+			Receive: 0,
+		},
+	}
+
+	for _, param := range strings.Split(req.URL.RawQuery, "&") {
+		if param == "" {
+			continue
+		}
+		name, value := param, ""
+		if i := strings.IndexByte(param, '='); i >= 0 {
+			name, value = param[:i], param[i+1:]
+		}
+		if unescaped, err := url.QueryUnescape(value); err == nil {
+			value = unescaped
+		}
+		entry.Request.QueryString = append(entry.Request.QueryString, HARNameValue{Name: name, Value: value})
+	}
+
+	if req.GetBody != nil {
+		if body, getErr := req.GetBody(); getErr == nil {
+			data, _ := io.ReadAll(body)
+			body.Close()
+			entry.Request.BodySize = len(data)
+			entry.Request.PostData = &HARPostData{
+				MimeType: req.Header.Get("Content-Type"),
+				Text:     string(redact.redactBody(data)),
+			}
+		}
+	}
+
+	var readErr error
+	if err != nil {
+		entry.Error = err.Error()
+	} else {
+		var data []byte
+		data, readErr = io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(data))
+		if readErr != nil {
+			entry.Error = readErr.Error()
+		}
+
+		entry.Timings.Receive = milliseconds(time.Since(responded))
+		entry.Response.Status = resp.StatusCode
+		entry.Response.StatusText = http.StatusText(resp.StatusCode)
+		entry.Response.HTTPVersion = resp.Proto
+		entry.Response.Headers = harHeaders(resp.Header, redact)
+		if resp.StatusCode >= 300 && resp.StatusCode < 400 {
+			entry.Response.RedirectURL = resp.Header.Get("Location")
+		}
+		entry.Response.BodySize = len(data)
+		entry.Response.Content = HARContent{
+			Size:     len(data),
+			MimeType: resp.Header.Get("Content-Type"),
+			Text:     string(redact.redactBody(data)),
+		}
+	}
+	entry.Time = entry.Timings.Send + entry.Timings.Wait + entry.Timings.Receive
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.entries = append(r.entries, entry)
+	return readErr
+}
+
+// harHeaders converts headers, sorted by name, redacting the sensitive ones.
+func harHeaders(headers http.Header, redact *redactor) []HARNameValue {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := []HARNameValue{}
+	for _, name := range names {
+		for _, value := range headers[name] {
+			if redact.redactHeader(name) {
+				value = redacted
+			}
+			result = append(result, HARNameValue{Name: name, Value: value})
+		}
+	}
+	return result
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,128 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stmcginnis/gofish/common"
+)
+
+// TestHARRecorder tests recording requests, responses and failures.
+func TestHARRecorder(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPatch {
+			body, _ := io.ReadAll(r.Body)
+			w.Write(body) //nolint
+			return
+		}
+		w.Write([]byte(`{"Name": "Root Service"}`)) //nolint
+	}))
+
+	recorder := NewHARRecorder()
+	c, err := Connect(ClientConfig{Endpoint: ts.URL, HTTPClient: ts.Client(), HARRecorder: recorder})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	resp, err := c.Patch("/redfish/v1/AccountService/Accounts/1?$select=Name", map[string]string{"Password": "secret"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != `{"Password":"secret"}` {
+		t.Errorf("The response body should still be readable, got: %s", body)
+	}
+
+	ts.Close()
+	if _, err := c.Get("/redfish/v1/"); err == nil { //nolint:bodyclose
+		t.Error("Expected the request to fail")
+	}
+
+	entries := recorder.Entries()
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(entries))
+	}
+
+	root := entries[0]
+	if root.Request.Method != http.MethodGet || root.Response.Status != 200 ||
+		root.Response.Content.Text != `{"Name": "Root Service"}` ||
+		root.Response.Content.MimeType != "application/json" {
+		t.Errorf("Unexpected service root entry: %#v", root)
+	}
+
+	patch := entries[1]
+	if patch.Request.PostData == nil || patch.Request.PostData.Text != `{"Password":"[REDACTED]"}` {
+		t.Errorf("Expected the request body to be recorded and redacted: %#v", patch.Request.PostData)
+	}
+	if patch.Response.Content.Text != `{"Password":"[REDACTED]"}` {
+		t.Errorf("Expected the response body to be redacted: %s", patch.Response.Content.Text)
+	}
+	if len(patch.Request.QueryString) != 1 || patch.Request.QueryString[0] != (HARNameValue{Name: "$select", Value: "Name"}) {
+		t.Errorf("Unexpected query string: %v", patch.Request.QueryString)
+	}
+
+	failed := entries[2]
+	if failed.Response.Status != 0 || failed.Error == "" {
+		t.Errorf("Expected the failure to be recorded: %#v", failed)
+	}
+
+	name := filepath.Join(t.TempDir(), "traffic.har")
+	if err := recorder.WriteFile(name); err != nil {
+		t.Fatalf("Error writing HAR file: %s", err)
+	}
+	data, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatalf("Error reading HAR file: %s", err)
+	}
+
+	var har map[string]map[string]interface{}
+	if err := json.Unmarshal(data, &har); err != nil {
+		t.Fatalf("Invalid HAR file: %s", err)
+	}
+	if har["log"]["version"] != "1.2" || len(har["log"]["entries"].([]interface{})) != 3 {
+		t.Errorf("Unexpected HAR log: %v", har["log"])
+	}
+}
+
+// TestHARRecorderTruncatedBody tests that a response body cut off while it is
+// recorded fails the request.
+func TestHARRecorderTruncatedBody(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redfish/v1/" {
+			w.Write([]byte(`{"Name": "Root Service"}`)) //nolint
+			return
+		}
+		w.Header().Set("Content-Length", "100")
+		w.Write([]byte(`{"Name":`)) //nolint
+		w.(http.Flusher).Flush()
+		panic(http.ErrAbortHandler)
+	}))
+	defer ts.Close()
+
+	recorder := NewHARRecorder()
+	c, err := Connect(ClientConfig{Endpoint: ts.URL, HTTPClient: ts.Client(), HARRecorder: recorder})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	_, err = c.Get("/redfish/v1/Systems") //nolint:bodyclose
+	if !common.IsTransportError(err) {
+		t.Errorf("Expected a transport error, got: %v", err)
+	}
+
+	entries := recorder.Entries()
+	if len(entries) != 2 || entries[1].Error == "" {
+		t.Errorf("Expected the truncated response to be recorded with its error, got: %#v", entries)
+	}
+}
@@ -0,0 +1,109 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/stmcginnis/gofish/redfish"
+)
+
+// DefaultSessionTimeout is the session timeout assumed for the keep-alive
+// when the SessionService does not report one.
+const DefaultSessionTimeout = 30 * time.Minute
+
+// sessionKeepAlive touches the session of a client when it was idle for the
+// keep-alive interval.
+type sessionKeepAlive struct {
+	// lastRequest is when the client last sent a request, in nanoseconds
+	// since the epoch. It is accessed atomically, so it comes first to be
+	// 64-bit aligned.
+	lastRequest int64
+
+	interval time.Duration
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// startSessionKeepAlive starts touching the session every interval, or half
+// of the SessionTimeout if interval is zero, unless other requests were sent
+// meanwhile.
+func (c *APIClient) startSessionKeepAlive(interval time.Duration) {
+	if interval <= 0 {
+		timeout := DefaultSessionTimeout
+		if link := c.Service.sessionService; link != "" {
+			sessionService, err := redfish.GetSessionServiceContext(c.ctx, c, link)
+			if err == nil && sessionService.SessionTimeout > 0 {
+				timeout = time.Duration(sessionService.SessionTimeout) * time.Second
+			}
+		}
+		interval = timeout / 2 //nolint:gomnd // well before the timeout
+	}
+
+	c.keepAlive = &sessionKeepAlive{
+		lastRequest: time.Now().UnixNano(),
+		interval:    interval,
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+	go c.runSessionKeepAlive(c.keepAlive)
+}
+
+func (c *APIClient) runSessionKeepAlive(k *sessionKeepAlive) {
+	defer close(k.done)
+
+	var ctxDone <-chan struct{}
+	if c.ctx != nil {
+		ctxDone = c.ctx.Done()
+	}
+
+	timer := time.NewTimer(k.interval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-k.stop:
+			return
+		case <-ctxDone:
+			return
+		case <-timer.C:
+		}
+
+		if idle := time.Since(time.Unix(0, atomic.LoadInt64(&k.lastRequest))); idle < k.interval {
+			timer.Reset(k.interval - idle)
+			continue
+		}
+
+		// Reading the session counts as activity. Failures are ignored,
+		// requests re-authenticate if needed and the next attempt may
+		// succeed.
+		if auth := c.currentAuth(); auth != nil && auth.Session != "" {
+			resp, err := c.GetWithContext(c.ctx, auth.Session)
+			if err == nil {
+				resp.Body.Close()
+			}
+		}
+		timer.Reset(k.interval)
+	}
+}
+
+// touch records that the client sent a request.
+func (k *sessionKeepAlive) touch() {
+	atomic.StoreInt64(&k.lastRequest, time.Now().UnixNano())
+}
+
+// stopSessionKeepAlive stops the keep-alive and waits until any request it
+// was sending is done.
+func (c *APIClient) stopSessionKeepAlive() {
+	if c.keepAlive == nil {
+		return
+	}
+	c.keepAlive.stopOnce.Do(func() {
+		close(c.keepAlive.stop)
+	})
+	<-c.keepAlive.done
+}
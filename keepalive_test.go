@@ -0,0 +1,78 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stmcginnis/gofish/mockserver"
+)
+
+// TestSessionKeepAlive tests reading the session while the client is idle.
+func TestSessionKeepAlive(t *testing.T) {
+	server, err := mockserver.New("mockserver/testdata/simple")
+	if err != nil {
+		t.Fatalf("Error loading mockup: %s", err)
+	}
+	server.Username, server.Password = "admin", "password"
+
+	var touches int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, mockSessionsURI+"/") {
+			atomic.AddInt32(&touches, 1)
+		}
+		server.ServeHTTP(w, r)
+	}))
+	defer ts.Close()
+
+	c, err := Connect(ClientConfig{Endpoint: ts.URL, Username: "admin", Password: "password", SessionKeepAlive: true})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+	// The mockup has a SessionTimeout of 30 seconds.
+	if c.keepAlive.interval != 15*time.Second {
+		t.Errorf("Expected half the session timeout, got: %s", c.keepAlive.interval)
+	}
+	c.Logout()
+
+	c, err = Connect(ClientConfig{
+		Endpoint:                 ts.URL,
+		Username:                 "admin",
+		Password:                 "password",
+		SessionKeepAlive:         true,
+		SessionKeepAliveInterval: 50 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	// The session is not read while the client is busy.
+	for i := 0; i < 10; i++ {
+		if _, err := c.Service.Systems(); err != nil {
+			t.Fatalf("Error listing systems: %s", err)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if got := atomic.LoadInt32(&touches); got != 0 {
+		t.Errorf("Expected no keep-alive while busy, got %d", got)
+	}
+
+	time.Sleep(250 * time.Millisecond)
+	if got := atomic.LoadInt32(&touches); got < 2 {
+		t.Errorf("Expected the session to be read while idle, got %d", got)
+	}
+
+	c.Logout()
+	stopped := atomic.LoadInt32(&touches)
+	time.Sleep(150 * time.Millisecond)
+	if got := atomic.LoadInt32(&touches); got != stopped {
+		t.Errorf("Expected the keep-alive to stop on logout, got %d more", got-stopped)
+	}
+}
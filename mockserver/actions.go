@@ -0,0 +1,119 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package mockserver
+
+import (
+	"net/http"
+	"path"
+)
+
+// ActionHandler performs an action on resource, which it may modify, using
+// the parameters of the request. It returns an *Error to fail the action.
+type ActionHandler func(resource, params map[string]interface{}) error
+
+// HandleAction sets the handler of the action name, such as
+// "ComputerSystem.Reset", replacing the default one if any.
+func (s *Server) HandleAction(name string, handler ActionHandler) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.actions[name] = handler
+}
+
+func defaultActions() map[string]ActionHandler {
+	return map[string]ActionHandler{
+		"ComputerSystem.Reset":     resetAction("ComputerSystem.Reset"),
+		"Chassis.Reset":            resetAction("Chassis.Reset"),
+		"Manager.Reset":            resetAction("Manager.Reset"),
+		"VirtualMedia.InsertMedia": insertMediaAction,
+		"VirtualMedia.EjectMedia":  ejectMediaAction,
+	}
+}
+
+// resetAction changes the PowerState of the resource according to the
+// ResetType.
+func resetAction(name string) ActionHandler {
+	return func(resource, params map[string]interface{}) error {
+		resetType, err := stringParam(resource, params, name, "ResetType", true)
+		if err != nil {
+			return err
+		}
+
+		switch resetType {
+		case "On", "ForceOn", "ForceRestart", "GracefulRestart", "PowerCycle":
+			resource["PowerState"] = "On"
+		case "ForceOff", "GracefulShutdown":
+			resource["PowerState"] = "Off"
+		case "PushPowerButton":
+			if resource["PowerState"] == "On" {
+				resource["PowerState"] = "Off"
+			} else {
+				resource["PowerState"] = "On"
+			}
+		}
+		return nil
+	}
+}
+
+func insertMediaAction(resource, params map[string]interface{}) error {
+	image, err := stringParam(resource, params, "VirtualMedia.InsertMedia", "Image", true)
+	if err != nil {
+		return err
+	}
+
+	inserted := true
+	if value, ok := params["Inserted"].(bool); ok {
+		inserted = value
+	}
+	writeProtected := true
+	if value, ok := params["WriteProtected"].(bool); ok {
+		writeProtected = value
+	}
+
+	resource["Image"] = image
+	resource["ImageName"] = path.Base(image)
+	resource["Inserted"] = inserted
+	resource["WriteProtected"] = writeProtected
+	resource["ConnectedVia"] = "URI"
+	return nil
+}
+
+func ejectMediaAction(resource, params map[string]interface{}) error {
+	resource["Image"] = nil
+	resource["ImageName"] = ""
+	resource["Inserted"] = false
+	resource["ConnectedVia"] = "NotConnected"
+	return nil
+}
+
+// stringParam returns the string parameter of an action, checking it is in
+// the allowable values advertised by the resource, if any.
+func stringParam(resource, params map[string]interface{}, action, name string, required bool) (string, error) {
+	value, ok := params[name]
+	if !ok {
+		if required {
+			return "", &Error{StatusCode: http.StatusBadRequest, MessageID: "ActionParameterMissing", Args: []string{action, name}}
+		}
+		return "", nil
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return "", &Error{StatusCode: http.StatusBadRequest, MessageID: "ActionParameterValueTypeError", Args: []string{"", name, action}}
+	}
+
+	actions, _ := resource["Actions"].(map[string]interface{})
+	info, _ := actions["#"+action].(map[string]interface{})
+	if allowed, ok := info[name+"@Redfish.AllowableValues"].([]interface{}); ok {
+		for _, a := range allowed {
+			if a == s {
+				return s, nil
+			}
+		}
+		return "", &Error{StatusCode: http.StatusBadRequest, MessageID: "ActionParameterValueNotInList", Args: []string{s, name, action}}
+	}
+
+	return s, nil
+}
@@ -0,0 +1,81 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package mockserver
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/stmcginnis/gofish/redfish"
+)
+
+// baseRegistry is the Base message registry version used in errors.
+const baseRegistry = "Base.1.15"
+
+// Error is an error response of the Server, with a message of the Base
+// message registry. Action handlers return it to fail an action.
+type Error struct {
+	// StatusCode is the HTTP status of the response.
+	StatusCode int
+	// MessageID is the key of the message in the Base registry, such as
+	// "ActionParameterMissing".
+	MessageID string
+	// Args are the arguments of the message.
+	Args []string
+}
+
+func (e *Error) Error() string {
+	return e.message()
+}
+
+// message formats the registry message, or returns the MessageId if it is
+// not a known message.
+func (e *Error) message() string {
+	m, err := redfish.StandardMessage(e.messageID())
+	if err != nil {
+		return e.messageID()
+	}
+	return m.Format(e.Args)
+}
+
+func (e *Error) messageID() string {
+	return baseRegistry + "." + e.MessageID
+}
+
+// writeError writes err as a Redfish error response. Errors other than
+// *Error are returned as internal errors.
+func writeError(w http.ResponseWriter, err error) {
+	var redfishErr *Error
+	if !errors.As(err, &redfishErr) {
+		redfishErr = &Error{StatusCode: http.StatusInternalServerError, MessageID: "InternalError"}
+	}
+
+	args := redfishErr.Args
+	if args == nil {
+		args = []string{}
+	}
+	body := map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    baseRegistry + ".GeneralError",
+			"message": "A general error has occurred. See ExtendedInfo for more information.",
+			"@Message.ExtendedInfo": []interface{}{
+				map[string]interface{}{
+					"MessageId":   redfishErr.messageID(),
+					"Message":     redfishErr.message(),
+					"MessageArgs": args,
+				},
+			},
+		},
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(redfishErr.StatusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func notFound(uri string) error {
+	return &Error{StatusCode: http.StatusNotFound, MessageID: "ResourceMissingAtURI", Args: []string{uri}}
+}
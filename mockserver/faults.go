@@ -0,0 +1,345 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package mockserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Fault is a misbehaviour of the service, injected in the responses to the
+// requests it matches. Faults are applied deterministically: a fault skips
+// the first Skip matching requests, then affects the next Times ones.
+//
+// Fault scripts can be declared in JSON, see ReadFaults:
+//
+//	[
+//	  {"Path": "/redfish/v1/Systems/*", "Times": 2, "StatusCode": 503},
+//	  {"Method": "GET", "Path": "/redfish/v1/**", "Skip": 5, "Times": 1, "ExpireSession": true},
+//	  {"Path": "/redfish/v1/Chassis", "Latency": "200ms"}
+//	]
+type Fault struct {
+	// Method is the method of the requests affected, or any method if empty.
+	Method string
+	// Path is the pattern of the paths of the requests affected, or any
+	// path if empty. A * matches any part of a path segment and ** matches
+	// any number of segments.
+	Path string
+	// Skip is the number of matching requests served normally before the
+	// fault applies.
+	Skip int
+	// Times is the number of matching requests affected once Skip requests
+	// were served, or all of them if zero.
+	Times int
+
+	// Latency delays the response.
+	Latency time.Duration
+	// Drop closes the connection without sending any response.
+	Drop bool
+	// ExpireSession ends the session of the request, which then fails with
+	// a 401 as do all further requests with the same session.
+	ExpireSession bool
+	// StatusCode is the status of an error response sent instead of the
+	// resource, such as 500, 503 or 429.
+	StatusCode int
+	// MessageID is the Base registry message of the error response. It
+	// defaults to ServiceTemporarilyUnavailable for 429 and 503 responses
+	// and InternalError otherwise.
+	MessageID string
+	// RetryAfter is the number of seconds sent in the Retry-After header of
+	// the error response, if not zero.
+	RetryAfter int
+	// MalformedJSON truncates the JSON body of the response.
+	MalformedJSON bool
+	// MissingODataID removes the @odata.id property of the resource.
+	MissingODataID bool
+	// TaskStates are the TaskState values reported by successive responses,
+	// in the given order. The last state is repeated once all were used.
+	TaskStates []string
+}
+
+// UnmarshalJSON unmarshals a Fault, with the Latency as a duration string
+// such as "150ms".
+func (f *Fault) UnmarshalJSON(b []byte) error {
+	type temp Fault
+	var t struct {
+		temp
+		Latency string
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*f = Fault(t.temp)
+	if t.Latency != "" {
+		f.Latency, err = time.ParseDuration(t.Latency)
+		if err != nil {
+			return fmt.Errorf("invalid latency of fault %s %s: %w", f.Method, f.Path, err)
+		}
+	}
+
+	return nil
+}
+
+// ReadFaults reads a fault script, a JSON array of faults.
+func ReadFaults(r io.Reader) ([]Fault, error) {
+	var faults []Fault
+	if err := json.NewDecoder(r).Decode(&faults); err != nil {
+		return nil, err
+	}
+	return faults, nil
+}
+
+// LoadFaults reads the fault script in the file name.
+func LoadFaults(name string) ([]Fault, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadFaults(f)
+}
+
+// injectedFault is a Fault with the number of requests it matched.
+type injectedFault struct {
+	Fault
+	path    *regexp.Regexp
+	matches int
+}
+
+// activeFault is a fault affecting a request, which is the index-th request
+// it affects.
+type activeFault struct {
+	*injectedFault
+	index int
+}
+
+// Inject adds faults to the service. Each fault counts the requests it
+// matched on its own, starting from zero.
+func (s *Server) Inject(faults ...Fault) {
+	s.faultLock.Lock()
+	defer s.faultLock.Unlock()
+
+	for i := range faults {
+		s.faults = append(s.faults, &injectedFault{
+			Fault: faults[i],
+			path:  pathPattern(faults[i].Path),
+		})
+	}
+}
+
+// ClearFaults removes all injected faults.
+func (s *Server) ClearFaults() {
+	s.faultLock.Lock()
+	defer s.faultLock.Unlock()
+
+	s.faults = nil
+}
+
+// pathPattern converts a Path pattern to a regular expression.
+func pathPattern(pattern string) *regexp.Regexp {
+	if pattern == "" {
+		return nil
+	}
+
+	expr := regexp.QuoteMeta(normalizeURI(pattern))
+	expr = strings.ReplaceAll(expr, `\*\*`, `.*`)
+	expr = strings.ReplaceAll(expr, `\*`, `[^/]*`)
+	return regexp.MustCompile("^" + expr + "$")
+}
+
+// activeFaults returns the faults affecting a request, counting it for all
+// the faults it matches.
+func (s *Server) activeFaults(r *http.Request, uri string) []activeFault {
+	s.faultLock.Lock()
+	defer s.faultLock.Unlock()
+
+	var active []activeFault
+	for _, f := range s.faults {
+		if f.Method != "" && !strings.EqualFold(f.Method, r.Method) {
+			continue
+		}
+		if f.path != nil && !f.path.MatchString(uri) {
+			continue
+		}
+
+		f.matches++
+		index := f.matches - f.Skip - 1
+		if index < 0 || (f.Times > 0 && index >= f.Times) {
+			continue
+		}
+		active = append(active, activeFault{injectedFault: f, index: index})
+	}
+
+	return active
+}
+
+// serveFaults applies the faults affecting a request before it is served.
+// It returns false if the request was answered, and otherwise the writer
+// to use to serve it.
+func (s *Server) serveFaults(w http.ResponseWriter, r *http.Request, faults []activeFault) (http.ResponseWriter, bool) {
+	var latency time.Duration
+	for _, f := range faults {
+		latency += f.Latency
+	}
+	if latency > 0 {
+		timer := time.NewTimer(latency)
+		defer timer.Stop()
+		select {
+		case <-r.Context().Done():
+			return nil, false
+		case <-timer.C:
+		}
+	}
+
+	for _, f := range faults {
+		if f.Drop {
+			dropConnection(w)
+			return nil, false
+		}
+	}
+
+	for _, f := range faults {
+		if f.ExpireSession {
+			s.expireSession(r.Header.Get("X-Auth-Token"))
+		}
+	}
+
+	for _, f := range faults {
+		if f.StatusCode != 0 {
+			writeFault(w, &f.Fault)
+			return nil, false
+		}
+	}
+
+	for _, f := range faults {
+		if f.MalformedJSON || f.MissingODataID || len(f.TaskStates) > 0 {
+			return &bufferedWriter{header: make(http.Header)}, true
+		}
+	}
+
+	return w, true
+}
+
+// expireSession ends the session with the given token, if any.
+func (s *Server) expireSession(token string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if uri, ok := s.sessions[token]; ok {
+		delete(s.sessions, token)
+		s.removeMember(uri)
+	}
+}
+
+// dropConnection closes the connection of a request without any response.
+func dropConnection(w http.ResponseWriter) {
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		panic(http.ErrAbortHandler)
+	}
+
+	conn, _, err := hijacker.Hijack()
+	if err != nil {
+		panic(http.ErrAbortHandler)
+	}
+	conn.Close()
+}
+
+// writeFault writes the error response of a fault.
+func writeFault(w http.ResponseWriter, f *Fault) {
+	err := &Error{StatusCode: f.StatusCode, MessageID: f.MessageID}
+	if err.MessageID == "" {
+		switch f.StatusCode {
+		case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+			err.MessageID = "ServiceTemporarilyUnavailable"
+			err.Args = []string{strconv.Itoa(f.RetryAfter)}
+		default:
+			err.MessageID = "InternalError"
+		}
+	}
+
+	if f.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(f.RetryAfter))
+	}
+	writeError(w, err)
+}
+
+// writeFaultyResponse writes the response buffered in b, altered by the
+// faults.
+func writeFaultyResponse(w http.ResponseWriter, b *bufferedWriter, faults []activeFault) {
+	body := b.body.Bytes()
+
+	var resource map[string]interface{}
+	if b.status == http.StatusOK && json.Unmarshal(body, &resource) == nil {
+		changed := false
+		for _, f := range faults {
+			if f.MissingODataID {
+				delete(resource, "@odata.id")
+				changed = true
+			}
+			if len(f.TaskStates) > 0 {
+				index := f.index
+				if index >= len(f.TaskStates) {
+					index = len(f.TaskStates) - 1
+				}
+				resource["TaskState"] = f.TaskStates[index]
+				changed = true
+			}
+		}
+		if changed {
+			body, _ = json.Marshal(resource)
+		}
+	}
+
+	for _, f := range faults {
+		if f.MalformedJSON && len(body) > 0 {
+			body = body[:len(body)/2]
+		}
+	}
+
+	for key, values := range b.header {
+		w.Header()[key] = values
+	}
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	if b.status == 0 {
+		b.status = http.StatusOK
+	}
+	w.WriteHeader(b.status)
+	_, _ = w.Write(body)
+}
+
+// bufferedWriter is a http.ResponseWriter keeping the response in memory.
+type bufferedWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (b *bufferedWriter) Header() http.Header {
+	return b.header
+}
+
+func (b *bufferedWriter) WriteHeader(status int) {
+	if b.status == 0 {
+		b.status = status
+	}
+}
+
+func (b *bufferedWriter) Write(data []byte) (int, error) {
+	b.WriteHeader(http.StatusOK)
+	return b.body.Write(data)
+}
@@ -0,0 +1,195 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package mockserver
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+)
+
+// fastRetries retries requests without waiting.
+var fastRetries = &gofish.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+// TestFaultRetries tests retrying requests failing with transient errors.
+func TestFaultRetries(t *testing.T) {
+	tests := []struct {
+		name  string
+		fault Fault
+	}{
+		{"503 burst", Fault{Times: 2, StatusCode: http.StatusServiceUnavailable}},
+		{"429 burst", Fault{Times: 2, StatusCode: http.StatusTooManyRequests, RetryAfter: 1}},
+		{"dropped connection", Fault{Times: 1, Drop: true}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, _, c := connectWith(t, gofish.ClientConfig{RetryPolicy: fastRetries})
+			test.fault.Method = http.MethodGet
+			test.fault.Path = systemURI
+			server.Inject(test.fault)
+
+			system, err := redfish.GetComputerSystem(c, systemURI)
+			if err != nil {
+				t.Fatalf("Expected the request to be retried, got: %v", err)
+			}
+			if system.ID != "437XR1138R2" {
+				t.Errorf("Unexpected system: %s", system.ID)
+			}
+		})
+	}
+
+	server, _, c := connect(t)
+	server.Inject(Fault{Path: "/redfish/v1/Systems/*", Times: 1, StatusCode: http.StatusServiceUnavailable})
+	if _, err := redfish.GetComputerSystem(c, systemURI); !common.IsServiceUnavailable(err) {
+		t.Errorf("Expected the fault without retries, got: %v", err)
+	}
+	if _, err := redfish.GetComputerSystem(c, systemURI); err != nil {
+		t.Errorf("Expected the fault to be over, got: %v", err)
+	}
+}
+
+// TestFaultExpiredSession tests re-authenticating after the session expired.
+func TestFaultExpiredSession(t *testing.T) {
+	var sessions []*gofish.Session
+	server, _, c := connectWith(t, gofish.ClientConfig{
+		ReAuthenticate: true,
+		ReAuthHandler: func(session *gofish.Session, err error) {
+			sessions = append(sessions, session)
+		},
+	})
+	before, _ := c.GetSession()
+
+	server.Inject(Fault{Method: http.MethodGet, Path: "/redfish/v1/**", Skip: 1, Times: 1, ExpireSession: true})
+	for i := 0; i < 3; i++ {
+		if _, err := redfish.GetComputerSystem(c, systemURI); err != nil {
+			t.Fatalf("Expected the client to re-authenticate, got: %v", err)
+		}
+	}
+
+	after, _ := c.GetSession()
+	if len(sessions) != 1 || sessions[0] == nil || after.Token == before.Token {
+		t.Errorf("Expected a single new session, got: %v", sessions)
+	}
+}
+
+// TestFaultCollectionError tests listing collections with failing members.
+func TestFaultCollectionError(t *testing.T) {
+	server, _, c := connect(t)
+	resp, err := c.Post("/redfish/v1/Systems", map[string]string{"Name": "Broken"})
+	if err != nil {
+		t.Fatalf("Error creating system: %s", err)
+	}
+	resp.Body.Close()
+
+	server.Inject(Fault{Path: "/redfish/v1/Systems/1", StatusCode: http.StatusInternalServerError})
+	systems, err := c.Service.Systems()
+	var collectionErr *common.CollectionError
+	if !errors.As(err, &collectionErr) || len(collectionErr.Failures) != 1 || collectionErr.Failures["/redfish/v1/Systems/1"] == nil {
+		t.Fatalf("Expected a collection error, got: %v", err)
+	}
+	if len(systems) != 1 || systems[0].ID != "437XR1138R2" {
+		t.Errorf("Expected the other system, got: %v", systems)
+	}
+
+	server.ClearFaults()
+	if systems, err := c.Service.Systems(); err != nil || len(systems) != 2 {
+		t.Errorf("Expected both systems once the faults are cleared, got: %v", err)
+	}
+}
+
+// TestFaultResponses tests altering the responses.
+func TestFaultResponses(t *testing.T) {
+	server, _, c := connect(t)
+
+	server.Inject(Fault{Path: systemURI, Times: 1, MalformedJSON: true})
+	if _, err := redfish.GetComputerSystem(c, systemURI); err == nil {
+		t.Error("Expected the malformed response to fail")
+	}
+
+	server.Inject(Fault{Path: systemURI, Times: 1, MissingODataID: true})
+	system, err := redfish.GetComputerSystem(c, systemURI)
+	if err != nil {
+		t.Fatalf("Error getting system: %s", err)
+	}
+	if system.ODataID != "" || system.ID != "437XR1138R2" {
+		t.Errorf("Expected the @odata.id to be missing, got: %q", system.ODataID)
+	}
+}
+
+// TestFaultTaskStates tests reporting task states out of order.
+func TestFaultTaskStates(t *testing.T) {
+	server, _, c := connect(t)
+	const taskURI = "/redfish/v1/TaskService/Tasks/1"
+	server.SetResource(taskURI, map[string]interface{}{
+		"@odata.id": taskURI,
+		"Id":        "1",
+		"TaskState": "New",
+	})
+
+	server.Inject(Fault{Path: taskURI, TaskStates: []string{"Running", "Completed", "Running"}})
+	var states []string
+	for i := 0; i < 4; i++ {
+		task, err := redfish.GetTask(c, taskURI)
+		if err != nil {
+			t.Fatalf("Error getting task: %s", err)
+		}
+		states = append(states, string(task.TaskState))
+	}
+
+	if strings.Join(states, " ") != "Running Completed Running Running" {
+		t.Errorf("Unexpected task states: %v", states)
+	}
+}
+
+// TestFaultScript tests declaring faults in JSON.
+func TestFaultScript(t *testing.T) {
+	faults, err := ReadFaults(strings.NewReader(`[
+		{"Method": "GET", "Path": "/redfish/v1/Chassis/*", "Latency": "20ms"},
+		{"Path": "/redfish/v1/Managers/**", "Skip": 1, "Times": 2, "StatusCode": 502}
+	]`))
+	if err != nil {
+		t.Fatalf("Error reading faults: %s", err)
+	}
+	if len(faults) != 2 || faults[0].Latency != 20*time.Millisecond || faults[1].StatusCode != http.StatusBadGateway {
+		t.Fatalf("Unexpected faults: %#v", faults)
+	}
+
+	server, _, c := connect(t)
+	server.Inject(faults...)
+
+	start := time.Now()
+	if _, err := redfish.GetChassis(c, "/redfish/v1/Chassis/1U"); err != nil {
+		t.Fatalf("Error getting chassis: %s", err)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("Expected the latency to be applied, took %s", elapsed)
+	}
+
+	var statuses []int
+	for i := 0; i < 4; i++ {
+		status := http.StatusOK
+		if _, err := redfish.GetManager(c, "/redfish/v1/Managers/BMC"); err != nil {
+			var redfishErr *common.Error
+			if !errors.As(err, &redfishErr) {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			status = redfishErr.HTTPReturnedStatusCode
+		}
+		statuses = append(statuses, status)
+	}
+	if statuses[0] != 200 || statuses[1] != 502 || statuses[2] != 502 || statuses[3] != 200 {
+		t.Errorf("Unexpected statuses: %v", statuses)
+	}
+
+	if _, err := ReadFaults(strings.NewReader(`[{"Latency": "soon"}]`)); err == nil {
+		t.Error("Expected an invalid latency to fail")
+	}
+}
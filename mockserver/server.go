@@ -0,0 +1,539 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+// Package mockserver provides an in-process Redfish service for tests. It
+// serves the resources of a mockup in the DMTF layout, one index.json file
+// per resource, keeping changes in memory:
+//
+//	server, err := mockserver.New("testdata/public-rackmount1")
+//	ts := server.Start()
+//	defer ts.Close()
+//
+//	c, err := gofish.Connect(gofish.ClientConfig{Endpoint: ts.URL, HTTPClient: ts.Client()})
+package mockserver
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const serviceRootURI = "/redfish/v1"
+
+// Server is a Redfish service serving the resources of a mockup. It
+// supports:
+//
+//   - GET of any resource, with an ETag.
+//   - PATCH and PUT of resources, honoring If-Match.
+//   - POST to collections to create members and DELETE of members.
+//   - Sessions created by a POST to the sessions collection, and the
+//     X-Auth-Token or basic authentication if Username is set.
+//   - Actions, see HandleAction. ComputerSystem.Reset, Chassis.Reset,
+//     Manager.Reset, VirtualMedia.InsertMedia and VirtualMedia.EjectMedia
+//     are simulated by default, and other advertised actions are accepted
+//     without any effect.
+//   - Faults injected to simulate a misbehaving service, see Inject.
+type Server struct {
+	// Username and Password are the credentials accepted to create a
+	// session or for basic authentication. If Username is empty,
+	// authentication is not required and sessions are created for any
+	// credentials.
+	Username string
+	Password string
+
+	lock        sync.Mutex
+	resources   map[string]map[string]interface{}
+	versions    map[string]int
+	sessions    map[string]string
+	sessionsURI string
+	actions     map[string]ActionHandler
+
+	faultLock sync.Mutex
+	faults    []*injectedFault
+}
+
+// New creates a Server for the mockup in the directory dir.
+func New(dir string) (*Server, error) {
+	return NewFS(os.DirFS(dir))
+}
+
+// NewFS creates a Server for the mockup in fsys, which may be an embed.FS.
+// The mockup either contains the redfish/v1 directory, or is itself the
+// content of redfish/v1.
+func NewFS(fsys fs.FS) (*Server, error) {
+	s := &Server{
+		resources: make(map[string]map[string]interface{}),
+		versions:  make(map[string]int),
+		sessions:  make(map[string]string),
+		actions:   defaultActions(),
+	}
+
+	prefix := serviceRootURI
+	if _, err := fs.Stat(fsys, "redfish/v1/index.json"); err == nil {
+		prefix = ""
+	}
+
+	err := fs.WalkDir(fsys, ".", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || entry.Name() != "index.json" {
+			return err
+		}
+
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		var resource map[string]interface{}
+		if err := json.Unmarshal(data, &resource); err != nil {
+			return fmt.Errorf("invalid resource %s: %w", name, err)
+		}
+
+		uri := normalizeURI(prefix + "/" + path.Dir(name))
+		s.resources[uri] = resource
+		s.versions[uri] = 1
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	root, ok := s.resources[serviceRootURI]
+	if !ok {
+		return nil, fmt.Errorf("the mockup has no service root")
+	}
+	if _, ok := s.resources["/redfish"]; !ok {
+		s.resources["/redfish"] = map[string]interface{}{"v1": serviceRootURI + "/"}
+	}
+	s.sessionsURI = normalizeURI(linkURI(root, "Links", "Sessions"))
+	if service, ok := s.resources[normalizeURI(linkURI(root, "SessionService"))]; ok && s.sessionsURI == "" {
+		s.sessionsURI = normalizeURI(linkURI(service, "Sessions"))
+	}
+
+	return s, nil
+}
+
+// Start starts an httptest.Server for s. The caller should Close it.
+func (s *Server) Start() *httptest.Server {
+	return httptest.NewServer(s)
+}
+
+// StartTLS starts an httptest.Server using TLS for s. The caller should
+// Close it.
+func (s *Server) StartTLS() *httptest.Server {
+	return httptest.NewTLSServer(s)
+}
+
+// Resource returns a copy of the resource at uri, or nil if there is none.
+func (s *Server) Resource(uri string) map[string]interface{} {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	resource, ok := s.resources[normalizeURI(uri)]
+	if !ok {
+		return nil
+	}
+	return copyResource(resource)
+}
+
+// URIs returns the URIs of all resources, sorted.
+func (s *Server) URIs() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	uris := make([]string, 0, len(s.resources))
+	for uri := range s.resources {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+	return uris
+}
+
+// SetResource adds or replaces the resource at uri. Collections are not
+// updated.
+func (s *Server) SetResource(uri string, resource map[string]interface{}) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	uri = normalizeURI(uri)
+	s.resources[uri] = copyResource(resource)
+	s.versions[uri]++
+}
+
+// ServeHTTP handles a Redfish request.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	uri := normalizeURI(r.URL.Path)
+
+	faults := s.activeFaults(r, uri)
+	if len(faults) == 0 {
+		s.serve(w, r, uri)
+		return
+	}
+
+	rw, ok := s.serveFaults(w, r, faults)
+	if !ok {
+		return
+	}
+	s.serve(rw, r, uri)
+	if buffered, ok := rw.(*bufferedWriter); ok {
+		writeFaultyResponse(w, buffered, faults)
+	}
+}
+
+// serve handles a request without any fault.
+func (s *Server) serve(w http.ResponseWriter, r *http.Request, uri string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if !s.authorized(r, uri) {
+		writeError(w, &Error{StatusCode: http.StatusUnauthorized, MessageID: "NoValidSession"})
+		return
+	}
+
+	var err error
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		err = s.get(w, r, uri)
+	case http.MethodPatch, http.MethodPut:
+		err = s.update(w, r, uri)
+	case http.MethodPost:
+		err = s.post(w, r, uri)
+	case http.MethodDelete:
+		err = s.delete(w, uri)
+	default:
+		err = &Error{StatusCode: http.StatusMethodNotAllowed, MessageID: "OperationNotAllowed"}
+	}
+
+	if err != nil {
+		writeError(w, err)
+	}
+}
+
+// authorized checks if the request is authenticated, if required.
+func (s *Server) authorized(r *http.Request, uri string) bool {
+	if s.Username == "" {
+		return true
+	}
+
+	// The service root and session login are always available.
+	if r.Method == http.MethodGet && (uri == "/redfish" || uri == serviceRootURI) {
+		return true
+	}
+	if r.Method == http.MethodPost && uri == s.sessionsURI {
+		return true
+	}
+
+	if _, ok := s.sessions[r.Header.Get("X-Auth-Token")]; ok {
+		return true
+	}
+	username, password, ok := r.BasicAuth()
+	return ok && username == s.Username && password == s.Password
+}
+
+func (s *Server) get(w http.ResponseWriter, r *http.Request, uri string) error {
+	resource, ok := s.resources[uri]
+	if !ok {
+		return notFound(uri)
+	}
+
+	s.writeResource(w, uri, resource, http.StatusOK, r.Method == http.MethodHead)
+	return nil
+}
+
+// update applies a PATCH, or a PUT replacing the resource.
+func (s *Server) update(w http.ResponseWriter, r *http.Request, uri string) error {
+	resource, ok := s.resources[uri]
+	if !ok {
+		return notFound(uri)
+	}
+	if isCollection(resource) {
+		return &Error{StatusCode: http.StatusMethodNotAllowed, MessageID: "OperationNotAllowed"}
+	}
+	if err := s.checkIfMatch(r, uri); err != nil {
+		return err
+	}
+
+	body, err := readBody(r)
+	if err != nil {
+		return err
+	}
+	for _, property := range []string{"@odata.id", "@odata.type", "Id"} {
+		if _, ok := body[property]; ok {
+			return &Error{StatusCode: http.StatusBadRequest, MessageID: "PropertyNotWritable", Args: []string{property}}
+		}
+	}
+
+	if r.Method == http.MethodPut {
+		replacement := copyResource(body)
+		for _, property := range []string{"@odata.id", "@odata.type", "Id"} {
+			if value, ok := resource[property]; ok {
+				replacement[property] = value
+			}
+		}
+		resource = replacement
+	} else {
+		for property := range body {
+			if _, ok := resource[property]; !ok {
+				return &Error{StatusCode: http.StatusBadRequest, MessageID: "PropertyUnknown", Args: []string{property}}
+			}
+		}
+		merge(resource, body)
+	}
+
+	s.resources[uri] = resource
+	s.versions[uri]++
+	s.writeResource(w, uri, resource, http.StatusOK, false)
+	return nil
+}
+
+// post creates a collection member, a session, or performs an action.
+func (s *Server) post(w http.ResponseWriter, r *http.Request, uri string) error {
+	if i := strings.Index(uri, "/Actions/"); i >= 0 {
+		return s.action(w, r, uri[:i], path.Base(uri))
+	}
+
+	collection, ok := s.resources[uri]
+	if !ok {
+		return notFound(uri)
+	}
+	if !isCollection(collection) {
+		return &Error{StatusCode: http.StatusMethodNotAllowed, MessageID: "OperationNotAllowed"}
+	}
+
+	body, err := readBody(r)
+	if err != nil {
+		return err
+	}
+
+	var token string
+	if uri == s.sessionsURI {
+		username, _ := body["UserName"].(string)
+		password, _ := body["Password"].(string)
+		if s.Username != "" && (username != s.Username || password != s.Password) {
+			return &Error{StatusCode: http.StatusUnauthorized, MessageID: "NoValidSession"}
+		}
+		delete(body, "Password")
+		body["@odata.type"] = "#Session.v1_1_0.Session"
+		body["Name"] = "User Session"
+		token = newToken()
+	}
+
+	id, _ := body["Id"].(string)
+	if id == "" {
+		id = s.newMemberID(uri)
+	}
+	memberURI := uri + "/" + id
+	if _, exists := s.resources[memberURI]; exists {
+		return &Error{StatusCode: http.StatusConflict, MessageID: "ResourceAlreadyExists", Args: []string{"Resource", "Id", id}}
+	}
+
+	body["@odata.id"] = memberURI
+	body["Id"] = id
+	s.resources[memberURI] = body
+	s.versions[memberURI] = 1
+
+	members, _ := collection["Members"].([]interface{})
+	collection["Members"] = append(members, map[string]interface{}{"@odata.id": memberURI})
+	collection["Members@odata.count"] = len(members) + 1
+	s.versions[uri]++
+
+	if token != "" {
+		s.sessions[token] = memberURI
+		w.Header().Set("X-Auth-Token", token)
+	}
+	w.Header().Set("Location", memberURI)
+	s.writeResource(w, memberURI, body, http.StatusCreated, false)
+	return nil
+}
+
+// action performs the action name on the resource at uri.
+func (s *Server) action(w http.ResponseWriter, r *http.Request, uri, name string) error {
+	resource, ok := s.resources[uri]
+	if !ok {
+		return notFound(uri)
+	}
+	if actions, _ := resource["Actions"].(map[string]interface{}); actions == nil || actions["#"+name] == nil {
+		return &Error{StatusCode: http.StatusBadRequest, MessageID: "ActionNotSupported", Args: []string{name}}
+	}
+	if err := s.checkIfMatch(r, uri); err != nil {
+		return err
+	}
+
+	params, err := readBody(r)
+	if err != nil {
+		return err
+	}
+
+	if handler, ok := s.actions[name]; ok {
+		if err := handler(resource, params); err != nil {
+			return err
+		}
+		s.versions[uri]++
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// delete removes a collection member.
+func (s *Server) delete(w http.ResponseWriter, uri string) error {
+	resource, ok := s.resources[uri]
+	if !ok {
+		return notFound(uri)
+	}
+
+	if isCollection(resource) || !isCollection(s.resources[path.Dir(uri)]) {
+		return &Error{StatusCode: http.StatusMethodNotAllowed, MessageID: "OperationNotAllowed"}
+	}
+
+	s.removeMember(uri)
+	for token, sessionURI := range s.sessions {
+		if sessionURI == uri {
+			delete(s.sessions, token)
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// removeMember removes the resource at uri from its collection.
+func (s *Server) removeMember(uri string) {
+	delete(s.resources, uri)
+	delete(s.versions, uri)
+
+	parentURI := path.Dir(uri)
+	parent, ok := s.resources[parentURI]
+	if !ok {
+		return
+	}
+
+	members, _ := parent["Members"].([]interface{})
+	var remaining []interface{}
+	for _, member := range members {
+		if link, _ := member.(map[string]interface{}); link == nil || link["@odata.id"] != uri {
+			remaining = append(remaining, member)
+		}
+	}
+	if remaining == nil {
+		remaining = []interface{}{}
+	}
+	parent["Members"] = remaining
+	parent["Members@odata.count"] = len(remaining)
+	s.versions[parentURI]++
+}
+
+// checkIfMatch fails with a 412 if the request has an If-Match header that
+// does not match the current ETag of the resource at uri.
+func (s *Server) checkIfMatch(r *http.Request, uri string) error {
+	ifMatch := r.Header.Get("If-Match")
+	if ifMatch == "" || ifMatch == "*" || ifMatch == s.etag(uri) {
+		return nil
+	}
+	return &Error{StatusCode: http.StatusPreconditionFailed, MessageID: "PreconditionFailed"}
+}
+
+func (s *Server) etag(uri string) string {
+	return `W/"` + strconv.Itoa(s.versions[uri]) + `"`
+}
+
+// newMemberID returns the lowest unused numeric ID in a collection.
+func (s *Server) newMemberID(collectionURI string) string {
+	for i := 1; ; i++ {
+		id := strconv.Itoa(i)
+		if _, exists := s.resources[collectionURI+"/"+id]; !exists {
+			return id
+		}
+	}
+}
+
+func (s *Server) writeResource(w http.ResponseWriter, uri string, resource map[string]interface{}, status int, headOnly bool) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("OData-Version", "4.0")
+	w.Header().Set("ETag", s.etag(uri))
+	w.WriteHeader(status)
+	if !headOnly {
+		_ = json.NewEncoder(w).Encode(resource)
+	}
+}
+
+// readBody decodes the JSON object in the request body, if any.
+func readBody(r *http.Request) (map[string]interface{}, error) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	body := make(map[string]interface{})
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return body, nil
+	}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return nil, &Error{StatusCode: http.StatusBadRequest, MessageID: "MalformedJSON"}
+	}
+	return body, nil
+}
+
+// normalizeURI removes the query and trailing slash of uri.
+func normalizeURI(uri string) string {
+	if i := strings.IndexByte(uri, '?'); i >= 0 {
+		uri = uri[:i]
+	}
+	if uri == "" {
+		return ""
+	}
+	return strings.TrimSuffix(path.Clean(uri), "/")
+}
+
+// linkURI returns the @odata.id of the link at the given property path.
+func linkURI(resource map[string]interface{}, properties ...string) string {
+	value := interface{}(resource)
+	for _, property := range properties {
+		object, _ := value.(map[string]interface{})
+		value = object[property]
+	}
+	link, _ := value.(map[string]interface{})
+	uri, _ := link["@odata.id"].(string)
+	return uri
+}
+
+func isCollection(resource map[string]interface{}) bool {
+	_, ok := resource["Members"]
+	return ok
+}
+
+// merge applies the properties of patch to resource, merging objects.
+func merge(resource, patch map[string]interface{}) {
+	for property, value := range patch {
+		object, isObject := value.(map[string]interface{})
+		current, currentIsObject := resource[property].(map[string]interface{})
+		if isObject && currentIsObject {
+			merge(current, object)
+			continue
+		}
+		resource[property] = value
+	}
+}
+
+func copyResource(resource map[string]interface{}) map[string]interface{} {
+	data, _ := json.Marshal(resource)
+	var result map[string]interface{}
+	_ = json.Unmarshal(data, &result)
+	return result
+}
+
+func newToken() string {
+	data := make([]byte, 16) //nolint:gomnd // 128 bit token
+	_, _ = rand.Read(data)
+	return hex.EncodeToString(data)
+}
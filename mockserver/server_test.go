@@ -0,0 +1,197 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package mockserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+)
+
+const systemURI = "/redfish/v1/Systems/437XR1138R2"
+
+// connect starts a server for the simple mockup and connects to it with a
+// session.
+func connect(t *testing.T) (*Server, *httptest.Server, *gofish.APIClient) {
+	return connectWith(t, gofish.ClientConfig{})
+}
+
+// connectWith is the same as connect, but uses the other settings of
+// config.
+func connectWith(t *testing.T, config gofish.ClientConfig) (*Server, *httptest.Server, *gofish.APIClient) { //nolint:gocritic
+	server, err := New("testdata/simple")
+	if err != nil {
+		t.Fatalf("Error loading mockup: %s", err)
+	}
+	server.Username = "admin"
+	server.Password = "password"
+
+	ts := server.Start()
+	t.Cleanup(ts.Close)
+
+	config.Endpoint = ts.URL
+	config.Username = "admin"
+	config.Password = "password"
+	config.HTTPClient = ts.Client()
+	c, err := gofish.Connect(config)
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+	t.Cleanup(c.Logout)
+	return server, ts, c
+}
+
+// TestServerSystems tests reading, updating and resetting a system.
+func TestServerSystems(t *testing.T) {
+	server, _, c := connect(t)
+
+	systems, err := c.Service.Systems()
+	if err != nil {
+		t.Fatalf("Error listing systems: %s", err)
+	}
+	if len(systems) != 1 || systems[0].ID != "437XR1138R2" || systems[0].PowerState != redfish.OnPowerState {
+		t.Fatalf("Unexpected systems: %#v", systems)
+	}
+
+	system := systems[0]
+	if err := system.Reset(redfish.ForceOffResetType); err != nil {
+		t.Fatalf("Error resetting system: %s", err)
+	}
+	if state := server.Resource(systemURI)["PowerState"]; state != "Off" {
+		t.Errorf("Expected the system to be off, got: %v", state)
+	}
+
+	// The reset changed the ETag, so the system must be read again.
+	system.AssetTag = "stale"
+	if err := system.Update(); !common.IsPreconditionFailed(err) {
+		t.Errorf("Expected the stale update to fail, got: %v", err)
+	}
+
+	system, err = redfish.GetComputerSystem(c, systemURI)
+	if err != nil {
+		t.Fatalf("Error getting system: %s", err)
+	}
+	if system.PowerState != redfish.OffPowerState {
+		t.Errorf("Expected the system to be off, got: %s", system.PowerState)
+	}
+	system.AssetTag = "Rack-12"
+	if err := system.Update(); err != nil {
+		t.Fatalf("Error updating system: %s", err)
+	}
+	if tag := server.Resource(systemURI)["AssetTag"]; tag != "Rack-12" {
+		t.Errorf("Expected the new asset tag, got: %v", tag)
+	}
+
+	if err := system.Reset("Bogus"); err == nil {
+		t.Error("Expected an unsupported reset type to fail")
+	}
+}
+
+// TestServerVirtualMedia tests inserting and ejecting media.
+func TestServerVirtualMedia(t *testing.T) {
+	server, _, c := connect(t)
+	const mediaURI = "/redfish/v1/Managers/BMC/VirtualMedia/CD1"
+
+	media, err := redfish.GetVirtualMedia(c, mediaURI)
+	if err != nil {
+		t.Fatalf("Error getting virtual media: %s", err)
+	}
+	if err := media.InsertMedia("http://images/os.iso", true, false); err != nil {
+		t.Fatalf("Error inserting media: %s", err)
+	}
+
+	media, err = redfish.GetVirtualMedia(c, mediaURI)
+	if err != nil {
+		t.Fatalf("Error getting virtual media: %s", err)
+	}
+	if !media.Inserted || media.Image != "http://images/os.iso" || media.ImageName != "os.iso" || media.WriteProtected {
+		t.Errorf("Unexpected inserted media: %#v", media)
+	}
+
+	if err := media.EjectMedia(); err != nil {
+		t.Fatalf("Error ejecting media: %s", err)
+	}
+	if inserted := server.Resource(mediaURI)["Inserted"]; inserted != false {
+		t.Errorf("Expected the media to be ejected, got: %v", inserted)
+	}
+}
+
+// TestServerCollections tests creating and deleting collection members.
+func TestServerCollections(t *testing.T) {
+	server, _, c := connect(t)
+
+	resp, err := c.Post("/redfish/v1/Systems", map[string]string{"Name": "New"})
+	if err != nil {
+		t.Fatalf("Error creating system: %s", err)
+	}
+	resp.Body.Close()
+	location := resp.Header.Get("Location")
+	if resp.StatusCode != http.StatusCreated || location != "/redfish/v1/Systems/1" {
+		t.Fatalf("Unexpected response: %d %s", resp.StatusCode, location)
+	}
+
+	systems, err := c.Service.Systems()
+	if err != nil || len(systems) != 2 {
+		t.Fatalf("Expected two systems, got: %d %v", len(systems), err)
+	}
+
+	resp, err = c.Delete(location)
+	if err != nil {
+		t.Fatalf("Error deleting system: %s", err)
+	}
+	resp.Body.Close()
+	if server.Resource(location) != nil {
+		t.Error("Expected the system to be deleted")
+	}
+
+	if _, err := c.Delete(location); !common.IsNotFound(err) { //nolint:bodyclose
+		t.Errorf("Expected deleting again to fail, got: %v", err)
+	}
+	if _, err := c.Post("/redfish/v1/Systems/437XR1138R2/Actions/Bogus.Action", map[string]string{}); !common.IsActionNotSupported(err) { //nolint:bodyclose
+		t.Errorf("Expected an unknown action to fail, got: %v", err)
+	}
+}
+
+// TestServerAuthentication tests requests without a session.
+func TestServerAuthentication(t *testing.T) {
+	_, ts, c := connect(t)
+
+	resp, err := ts.Client().Get(ts.URL + systemURI)
+	if err != nil {
+		t.Fatalf("Error getting system: %s", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected an unauthenticated request to fail, got: %d", resp.StatusCode)
+	}
+
+	_, err = gofish.Connect(gofish.ClientConfig{Endpoint: ts.URL, Username: "admin", Password: "wrong", HTTPClient: ts.Client()})
+	if !common.IsUnauthorized(err) {
+		t.Errorf("Expected wrong credentials to fail, got: %v", err)
+	}
+
+	c.Logout()
+	if _, err := c.Get(systemURI); !common.IsUnauthorized(err) { //nolint:bodyclose
+		t.Errorf("Expected the logged out session to fail, got: %v", err)
+	}
+}
+
+// TestNewFS tests loading a mockup without the redfish/v1 directories.
+func TestNewFS(t *testing.T) {
+	server, err := NewFS(os.DirFS("testdata/simple/redfish/v1"))
+	if err != nil {
+		t.Fatalf("Error loading mockup: %s", err)
+	}
+	uris := strings.Join(server.URIs(), " ")
+	if !strings.Contains(uris, systemURI) || !strings.Contains(uris, "/redfish/v1 ") {
+		t.Errorf("Unexpected resources: %s", uris)
+	}
+}
@@ -0,0 +1,207 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+)
+
+// tokenExpiryDelta is how long before its expiry a bearer token is
+// refreshed, so it does not expire while a request is sent.
+const tokenExpiryDelta = 10 * time.Second
+
+// OAuth2Token is an OAuth 2.0 access token sent as a bearer token.
+type OAuth2Token struct {
+	// AccessToken is the token sent in the Authorization header.
+	AccessToken string
+	// Expiry is when the token expires. The zero value means the token does
+	// not expire.
+	Expiry time.Time
+}
+
+// expired checks if the token expires within tokenExpiryDelta.
+func (t *OAuth2Token) expired() bool {
+	return !t.Expiry.IsZero() && time.Until(t.Expiry) < tokenExpiryDelta
+}
+
+// TokenSource provides the OAuth 2.0 access tokens to authenticate with a
+// service, such as tokens issued by the authorization server configured in
+// the OAuth2 external account provider of the AccountService. The client
+// reuses a token until it expires or the service rejects it, and then asks
+// for a new one.
+type TokenSource interface {
+	// Token returns a new access token.
+	Token(ctx context.Context) (*OAuth2Token, error)
+}
+
+// staticTokenSource provides the BearerToken of a ClientConfig.
+type staticTokenSource string
+
+// Token returns the configured token.
+func (s staticTokenSource) Token(ctx context.Context) (*OAuth2Token, error) {
+	return &OAuth2Token{AccessToken: string(s)}, nil
+}
+
+// ClientCredentialsTokenSource requests access tokens from an authorization
+// server with the OAuth 2.0 client credentials grant.
+type ClientCredentialsTokenSource struct {
+	// TokenURL is the URL of the token endpoint of the authorization server.
+	TokenURL string
+	// ClientID and ClientSecret authenticate the client with the
+	// authorization server.
+	ClientID     string
+	ClientSecret string
+	// Scopes are the optional scopes requested for the tokens.
+	Scopes []string
+	// HTTPClient is the optional client to connect to the authorization
+	// server with. Defaults to the HTTP client of the APIClient using the
+	// source, so the TLS settings of its ClientConfig apply, or to
+	// http.DefaultClient when Token is called directly.
+	HTTPClient *http.Client
+}
+
+// tokenResponse is the response of a token endpoint, either a token or an
+// error.
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	TokenType        string `json:"token_type"`
+	ExpiresIn        int64  `json:"expires_in"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Token requests a new access token from the token endpoint.
+func (s *ClientCredentialsTokenSource) Token(ctx context.Context) (*OAuth2Token, error) {
+	client := s.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return s.token(ctx, client)
+}
+
+// token requests a new access token from the token endpoint using client.
+func (s *ClientCredentialsTokenSource) token(ctx context.Context, client *http.Client) (*OAuth2Token, error) {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(s.Scopes) > 0 {
+		form.Set("scope", strings.Join(s.Scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", applicationJSON)
+	req.Header.Set("User-Agent", userAgent)
+	// The client credentials are form encoded before being used for basic
+	// authentication, as required by RFC 6749.
+	req.SetBasicAuth(url.QueryEscape(s.ClientID), url.QueryEscape(s.ClientSecret))
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var token tokenResponse
+	if err := json.Unmarshal(body, &token); err != nil || token.Error != "" || resp.StatusCode != http.StatusOK {
+		if token.Error != "" {
+			return nil, fmt.Errorf("token request failed: %s: %s", token.Error, token.ErrorDescription)
+		}
+		return nil, fmt.Errorf("token request failed: %s", resp.Status)
+	}
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("token request failed: no access token returned")
+	}
+	if token.TokenType != "" && !strings.EqualFold(token.TokenType, "bearer") {
+		return nil, fmt.Errorf("unsupported token type: %s", token.TokenType)
+	}
+
+	result := &OAuth2Token{AccessToken: token.AccessToken}
+	if token.ExpiresIn > 0 {
+		result.Expiry = start.Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+	return result, nil
+}
+
+// requestAuth returns the auth information to send a request with, first
+// refreshing the bearer token if it expired.
+func (c *APIClient) requestAuth(ctx context.Context) (*redfish.AuthToken, error) {
+	c.authLock.Lock()
+	auth, expired := c.auth, c.token != nil && c.token.expired()
+	c.authLock.Unlock()
+
+	if !expired {
+		return auth, nil
+	}
+	if err := c.refreshToken(ctx, auth); err != nil {
+		return nil, err
+	}
+	return c.currentAuth(), nil
+}
+
+// refreshToken gets a new bearer token to replace the failed auth
+// information. If another request already replaced it, the new token is
+// reused.
+func (c *APIClient) refreshToken(ctx context.Context, failed *redfish.AuthToken) error {
+	// Only one request calls the token endpoint, the others wait for its
+	// token. Requests still sent with the current token are not blocked by
+	// a slow authorization server.
+	c.refreshLock.Lock()
+	defer c.refreshLock.Unlock()
+
+	if c.currentAuth() != failed {
+		return nil
+	}
+
+	token, err := c.newToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.authLock.Lock()
+	defer c.authLock.Unlock()
+	c.token = token
+	c.auth = &redfish.AuthToken{Token: token.AccessToken, BearerAuth: true}
+	return nil
+}
+
+// newToken gets a token from the token source, using the HTTP client of c
+// for a ClientCredentialsTokenSource without its own.
+func (c *APIClient) newToken(ctx context.Context) (*OAuth2Token, error) {
+	if source, ok := c.tokenSource.(*ClientCredentialsTokenSource); ok && source.HTTPClient == nil {
+		return source.token(ctx, c.HTTPClient)
+	}
+	return c.tokenSource.Token(ctx)
+}
+
+// shouldRefreshToken checks if a failed request should be retried with a new
+// bearer token.
+func (c *APIClient) shouldRefreshToken(auth *redfish.AuthToken, err error) bool {
+	if c.tokenSource == nil || auth == nil || !auth.BearerAuth {
+		return false
+	}
+	// A fixed token would only be rejected again.
+	if _, ok := c.tokenSource.(staticTokenSource); ok {
+		return false
+	}
+	return common.IsUnauthorized(err)
+}
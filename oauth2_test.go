@@ -0,0 +1,259 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/mockserver"
+)
+
+// tokenServer is a stand-in authorization server, along with a Redfish
+// service only accepting the tokens it issued.
+type tokenServer struct {
+	lock      sync.Mutex
+	expiresIn int
+	issued    int
+	rejected  int
+	valid     map[string]bool
+}
+
+// start starts the token endpoint and the Redfish service.
+func (s *tokenServer) start(t *testing.T) (tokenURL, endpoint string) {
+	s.valid = make(map[string]bool)
+
+	tokens := httptest.NewServer(http.HandlerFunc(s.serveToken))
+	t.Cleanup(tokens.Close)
+
+	service, err := mockserver.New("mockserver/testdata/simple")
+	if err != nil {
+		t.Fatalf("Error loading mockup: %s", err)
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		s.lock.Lock()
+		valid := s.valid[token]
+		if !valid && r.URL.Path != "/redfish/v1/" {
+			s.rejected++
+			s.lock.Unlock()
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		s.lock.Unlock()
+		service.ServeHTTP(w, r)
+	}))
+	t.Cleanup(ts.Close)
+
+	return tokens.URL + "/token", ts.URL
+}
+
+func (s *tokenServer) serveToken(w http.ResponseWriter, r *http.Request) {
+	// The client credentials are form encoded.
+	id, secret, _ := r.BasicAuth()
+	id, _ = url.QueryUnescape(id)
+	secret, _ = url.QueryUnescape(secret)
+	if id != "gofish" || secret != "s3cr%t" || r.FormValue("grant_type") != "client_credentials" {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error": "invalid_client", "error_description": "Unknown client"}`))
+		return
+	}
+
+	s.lock.Lock()
+	s.issued++
+	token := fmt.Sprintf("token-%d-%s", s.issued, r.FormValue("scope"))
+	s.valid[token] = true
+	expiresIn := s.expiresIn
+	s.lock.Unlock()
+
+	w.Header().Set("Content-Type", applicationJSON)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"access_token": token,
+		"token_type":   "Bearer",
+		"expires_in":   expiresIn,
+	})
+}
+
+// revoke invalidates all the issued tokens.
+func (s *tokenServer) revoke() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.valid = make(map[string]bool)
+}
+
+func (s *tokenServer) issuedTokens() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.issued
+}
+
+func (s *tokenServer) rejectedRequests() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.rejected
+}
+
+// TestClientCredentialsTokenSource tests requesting tokens.
+func TestClientCredentialsTokenSource(t *testing.T) {
+	server := &tokenServer{expiresIn: 3600}
+	tokenURL, _ := server.start(t)
+
+	source := &ClientCredentialsTokenSource{TokenURL: tokenURL, ClientID: "gofish", ClientSecret: "s3cr%t", Scopes: []string{"redfish", "read"}}
+	token, err := source.Token(context.Background())
+	if err != nil {
+		t.Fatalf("Error getting token: %s", err)
+	}
+	if token.AccessToken != "token-1-redfish read" || token.Expiry.IsZero() || token.expired() {
+		t.Errorf("Unexpected token: %+v", token)
+	}
+
+	source.ClientSecret = "wrong"
+	_, err = source.Token(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "invalid_client: Unknown client") {
+		t.Errorf("Expected the token error, got: %v", err)
+	}
+}
+
+// TestBearerToken tests authenticating with a fixed token.
+func TestBearerToken(t *testing.T) {
+	server := &tokenServer{}
+	_, endpoint := server.start(t)
+	server.valid["static"] = true
+
+	c, err := Connect(ClientConfig{Endpoint: endpoint, BearerToken: "static"})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+	if _, err := c.Service.Systems(); err != nil {
+		t.Errorf("Error using the token: %s", err)
+	}
+	if _, err := c.GetSession(); err == nil {
+		t.Error("Expected no session with bearer token authentication")
+	}
+
+	server.revoke()
+	if _, err := c.Service.Systems(); !common.IsUnauthorized(err) {
+		t.Errorf("Expected revoked tokens to be rejected, got: %v", err)
+	}
+	if server.rejectedRequests() != 1 {
+		t.Errorf("Expected the rejected request not to be replayed, got %d requests", server.rejectedRequests())
+	}
+}
+
+// TestTokenSourceConcurrentRefresh tests that concurrent requests rejected
+// with the same token only get one new token.
+func TestTokenSourceConcurrentRefresh(t *testing.T) {
+	server := &tokenServer{expiresIn: 3600}
+	tokenURL, endpoint := server.start(t)
+	source := &ClientCredentialsTokenSource{TokenURL: tokenURL, ClientID: "gofish", ClientSecret: "s3cr%t"}
+
+	c, err := Connect(ClientConfig{Endpoint: endpoint, TokenSource: source})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	server.revoke()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := c.Get("/redfish/v1/Systems")
+			if err != nil {
+				t.Errorf("Error using the refreshed token: %s", err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if server.issuedTokens() != 2 {
+		t.Errorf("Expected a single new token, got %d tokens", server.issuedTokens())
+	}
+}
+
+// TestTokenSourceTLS tests that tokens are requested with the TLS settings of
+// the client.
+func TestTokenSourceTLS(t *testing.T) {
+	server := &tokenServer{expiresIn: 3600}
+	_, endpoint := server.start(t)
+	tokens := httptest.NewTLSServer(http.HandlerFunc(server.serveToken))
+	defer tokens.Close()
+
+	source := &ClientCredentialsTokenSource{TokenURL: tokens.URL + "/token", ClientID: "gofish", ClientSecret: "s3cr%t"}
+	if _, err := source.Token(context.Background()); err == nil {
+		t.Error("Expected the self-signed certificate to be rejected by default")
+	}
+
+	c, err := Connect(ClientConfig{Endpoint: endpoint, TokenSource: source, Insecure: true})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+	if _, err := c.Service.Systems(); err != nil {
+		t.Errorf("Error using the token: %s", err)
+	}
+}
+
+// TestTokenSourceRefresh tests refreshing expired and revoked tokens.
+func TestTokenSourceRefresh(t *testing.T) {
+	server := &tokenServer{expiresIn: 3600}
+	tokenURL, endpoint := server.start(t)
+	source := &ClientCredentialsTokenSource{TokenURL: tokenURL, ClientID: "gofish", ClientSecret: "s3cr%t"}
+
+	c, err := Connect(ClientConfig{Endpoint: endpoint, TokenSource: source})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := c.Service.Systems(); err != nil {
+			t.Fatalf("Error using the token: %s", err)
+		}
+	}
+	if server.issuedTokens() != 1 {
+		t.Errorf("Expected the token to be reused, got %d tokens", server.issuedTokens())
+	}
+
+	// The service no longer accepts the token.
+	server.revoke()
+	if _, err := c.Service.Systems(); err != nil {
+		t.Errorf("Error using the refreshed token: %s", err)
+	}
+	if server.issuedTokens() != 2 {
+		t.Errorf("Expected a new token, got %d tokens", server.issuedTokens())
+	}
+
+	// Tokens about to expire are refreshed before they are used.
+	server.lock.Lock()
+	server.expiresIn = 1
+	server.lock.Unlock()
+	server.revoke()
+	if _, err := c.Service.Systems(); err != nil {
+		t.Fatalf("Error using the refreshed token: %s", err)
+	}
+	issued := server.issuedTokens()
+	resp, err := c.Get("/redfish/v1/Systems")
+	if err != nil {
+		t.Fatalf("Error using the refreshed token: %s", err)
+	}
+	resp.Body.Close()
+	if server.issuedTokens() != issued+1 {
+		t.Errorf("Expected the expired token to be refreshed, got %d tokens", server.issuedTokens()-issued)
+	}
+
+	// Errors getting a token are returned.
+	source.ClientSecret = "wrong"
+	if _, err := c.Service.Systems(); err == nil || !strings.Contains(err.Error(), "invalid_client") {
+		t.Errorf("Expected the token error, got: %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+)
+
+// redacted replaces the sensitive values in dumps.
+const redacted = "[REDACTED]"
+
+// defaultRedactedHeaders are the headers always redacted in dumps.
+var defaultRedactedHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"X-Auth-Token",
+	"Cookie",
+	"Set-Cookie",
+}
+
+// defaultRedactedFields are the JSON properties always redacted in dumps:
+// account and BIOS passwords, tokens, and SNMP community strings and keys.
+var defaultRedactedFields = []string{
+	"Password",
+	"OldPassword",
+	"NewPassword",
+	"Token",
+	"CommunityString",
+	"TrapCommunity",
+	"AuthenticationKey",
+	"EncryptionKey",
+}
+
+// redactor replaces the values of sensitive headers and JSON properties in
+// HTTP dumps.
+type redactor struct {
+	headers map[string]bool
+	fields  *regexp.Regexp
+}
+
+// defaultRedactor is used by clients created without a ClientConfig.
+var defaultRedactor = newRedactor(nil, nil)
+
+// newRedactor creates a redactor for the default headers and properties as
+// well as the extra ones given. Names are matched regardless of case.
+func newRedactor(extraHeaders, extraFields []string) *redactor {
+	r := &redactor{headers: make(map[string]bool)}
+	for _, header := range append(append([]string{}, defaultRedactedHeaders...), extraHeaders...) {
+		r.headers[strings.ToLower(header)] = true
+	}
+
+	var fields []string
+	for _, field := range append(append([]string{}, defaultRedactedFields...), extraFields...) {
+		fields = append(fields, regexp.QuoteMeta(field))
+	}
+	// Matches "Field": "value", keeping everything up to the value.
+	r.fields = regexp.MustCompile(`(?i)("(?:` + strings.Join(fields, "|") + `)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+	return r
+}
+
+// redact returns dump with the sensitive values replaced.
+func (r *redactor) redact(dump []byte) []byte {
+	head, body := dump, []byte(nil)
+	if i := bytes.Index(dump, []byte("\r\n\r\n")); i >= 0 {
+		head, body = dump[:i], dump[i:]
+	}
+
+	lines := bytes.Split(head, []byte("\r\n"))
+	// The first line is the request or status line.
+	for i := 1; i < len(lines); i++ {
+		colon := bytes.IndexByte(lines[i], ':')
+		if colon < 0 {
+			continue
+		}
+		name := lines[i][:colon]
+		if r.redactHeader(string(bytes.TrimSpace(name))) {
+			lines[i] = []byte(string(name) + ": " + redacted)
+		}
+	}
+
+	result := bytes.Join(lines, []byte("\r\n"))
+	return append(result, r.redactBody(body)...)
+}
+
+// redactHeader checks if the value of the header name should be redacted.
+func (r *redactor) redactHeader(name string) bool {
+	return r.headers[strings.ToLower(name)]
+}
+
+// redactBody returns body with the values of the sensitive JSON properties
+// replaced.
+func (r *redactor) redactBody(body []byte) []byte {
+	return r.fields.ReplaceAll(body, []byte(`${1}"`+redacted+`"`))
+}
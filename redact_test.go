@@ -0,0 +1,92 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestRedact tests redacting headers and JSON properties.
+func TestRedact(t *testing.T) {
+	dump := "POST /redfish/v1/SessionService/Sessions HTTP/1.1\r\n" +
+		"Host: bmc\r\n" +
+		"Authorization: Basic dXNlcjpwYXNz\r\n" +
+		"x-auth-token: secret\r\n" +
+		"X-Vendor-Key: vendor\r\n" +
+		"\r\n" +
+		`{"UserName": "admin", "Password": "p\"w", "SNMP": {"CommunityStrings": [{"CommunityString": "public"}]}, ` +
+		`"Oem": {"Secret": "hidden"}, "PasswordName": "Admin", "oldpassword":"old", ` +
+		`"SNMPUser": {"AuthenticationKey": "auth", "EncryptionKey": "priv", "AuthenticationProtocol": "HMAC_SHA96"}}`
+	expected := "POST /redfish/v1/SessionService/Sessions HTTP/1.1\r\n" +
+		"Host: bmc\r\n" +
+		"Authorization: [REDACTED]\r\n" +
+		"x-auth-token: [REDACTED]\r\n" +
+		"X-Vendor-Key: [REDACTED]\r\n" +
+		"\r\n" +
+		`{"UserName": "admin", "Password": "[REDACTED]", "SNMP": {"CommunityStrings": [{"CommunityString": "[REDACTED]"}]}, ` +
+		`"Oem": {"Secret": "[REDACTED]"}, "PasswordName": "Admin", "oldpassword":"[REDACTED]", ` +
+		`"SNMPUser": {"AuthenticationKey": "[REDACTED]", "EncryptionKey": "[REDACTED]", "AuthenticationProtocol": "HMAC_SHA96"}}`
+
+	result := string(newRedactor([]string{"X-Vendor-Key"}, []string{"Secret"}).redact([]byte(dump)))
+	if result != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, result)
+	}
+
+	result = string(defaultRedactor.redact([]byte(dump)))
+	if !strings.Contains(result, "X-Vendor-Key: vendor") || !strings.Contains(result, `"Secret": "hidden"`) {
+		t.Errorf("Only the default values should be redacted: %s", result)
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+// TestDumpWriter tests that credentials are not dumped and that failing to
+// write a dump does not fail the request.
+func TestDumpWriter(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.Header().Set("X-Auth-Token", "session-token")
+			w.Header().Set("Location", "/redfish/v1/SessionService/Sessions/1")
+			w.WriteHeader(http.StatusCreated)
+		}
+		w.Write([]byte(`{"Links": {"Sessions": {"@odata.id": "/redfish/v1/SessionService/Sessions"}}}`)) //nolint
+	}))
+	defer ts.Close()
+
+	var dump bytes.Buffer
+	c, err := Connect(ClientConfig{
+		Endpoint:   ts.URL,
+		HTTPClient: ts.Client(),
+		Username:   "admin",
+		Password:   "secret-password",
+		DumpWriter: &dump,
+	})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	if strings.Contains(dump.String(), "secret-password") || strings.Contains(dump.String(), "session-token") {
+		t.Errorf("Credentials should be redacted:\n%s", dump.String())
+	}
+	if !strings.Contains(dump.String(), `"Password":"[REDACTED]"`) {
+		t.Errorf("Expected the password to be redacted:\n%s", dump.String())
+	}
+
+	c.SetDumpWriter(failingWriter{})
+	resp, err := c.Get("/redfish/v1/")
+	if err != nil {
+		t.Fatalf("A failing dump writer should not fail the request: %s", err)
+	}
+	resp.Body.Close()
+}
@@ -5,6 +5,7 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 	"reflect"
 
@@ -301,6 +302,10 @@ func (accountservice *AccountService) Update() error {
 		"AccountLockoutDuration",
 		"AccountLockoutThreshold",
 		"AuthFailureLoggingThreshold",
+		"LDAP.Authentication",
+		"LDAP.RemoteRoleMapping",
+		"LDAP.ServiceAddresses",
+		"LDAP.ServiceEnabled",
 		"LocalAccountAuth",
 		"ServiceEnabled",
 	}
@@ -314,19 +319,22 @@ func (accountservice *AccountService) Update() error {
 // GetAccountService will get the AccountService instance from the Redfish
 // service.
 func GetAccountService(c common.Client, uri string) (*AccountService, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetAccountServiceContext(context.Background(), c, uri)
+}
 
+// GetAccountServiceContext is the same as GetAccountService, but uses ctx for
+// the request.
+func GetAccountServiceContext(ctx context.Context, c common.Client, uri string) (*AccountService, error) {
+	return GetAccountServiceWithOptions(ctx, c, uri, nil)
+}
+
+// GetAccountServiceWithOptions is the same as GetAccountServiceContext, but
+// adds the query options opts to the request.
+func GetAccountServiceWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*AccountService, error) {
 	var t AccountService
-	err = json.NewDecoder(resp.Body).Decode(&t)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &t); err != nil {
 		return nil, err
 	}
-
-	t.SetClient(c)
 	return &t, nil
 }
 
@@ -5,6 +5,7 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 	"reflect"
 
@@ -73,50 +74,47 @@ func (assembly *Assembly) Update() error {
 
 // GetAssembly will get a Assembly instance from the service.
 func GetAssembly(c common.Client, uri string) (*Assembly, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetAssemblyContext(context.Background(), c, uri)
+}
+
+// GetAssemblyContext is the same as GetAssembly, but uses ctx for the request.
+func GetAssemblyContext(ctx context.Context, c common.Client, uri string) (*Assembly, error) {
+	return GetAssemblyWithOptions(ctx, c, uri, nil)
+}
 
+// GetAssemblyWithOptions is the same as GetAssemblyContext, but adds the query
+// options opts to the request.
+func GetAssemblyWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*Assembly, error) {
 	var assembly Assembly
-	err = json.NewDecoder(resp.Body).Decode(&assembly)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &assembly); err != nil {
 		return nil, err
 	}
-
-	assembly.SetClient(c)
 	return &assembly, nil
 }
 
 // ListReferencedAssemblys gets the collection of Assembly from
 // a provided reference.
-func ListReferencedAssemblys(c common.Client, link string) ([]*Assembly, error) { //nolint:dupl
-	var result []*Assembly
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedAssemblys(c common.Client, link string) ([]*Assembly, error) {
+	return ListReferencedAssemblysContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, assemblyLink := range links.ItemLinks {
-		assembly, err := GetAssembly(c, assemblyLink)
-		if err != nil {
-			collectionError.Failures[assemblyLink] = err
-		} else {
-			result = append(result, assembly)
-		}
-	}
+// ListReferencedAssemblysContext is the same as ListReferencedAssemblys, but
+// uses ctx for the requests.
+func ListReferencedAssemblysContext(ctx context.Context, c common.Client, link string) ([]*Assembly, error) {
+	return ListReferencedAssemblysWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedAssemblysWithOptions is the same as
+// ListReferencedAssemblysContext, but adds the query options opts to the
+// requests.
+func ListReferencedAssemblysWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*Assembly, error) { //nolint:dupl
+	var result []*Assembly
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
 
 // AssemblyData is information about an assembly.
@@ -5,6 +5,7 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -142,49 +143,45 @@ func (bios *Bios) UnmarshalJSON(b []byte) error {
 
 // GetBios will get a Bios instance from the service.
 func GetBios(c common.Client, uri string) (*Bios, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetBiosContext(context.Background(), c, uri)
+}
+
+// GetBiosContext is the same as GetBios, but uses ctx for the request.
+func GetBiosContext(ctx context.Context, c common.Client, uri string) (*Bios, error) {
+	return GetBiosWithOptions(ctx, c, uri, nil)
+}
 
+// GetBiosWithOptions is the same as GetBiosContext, but adds the query options
+// opts to the request.
+func GetBiosWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*Bios, error) {
 	var bios Bios
-	err = json.NewDecoder(resp.Body).Decode(&bios)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &bios); err != nil {
 		return nil, err
 	}
-
-	bios.SetClient(c)
 	return &bios, nil
 }
 
 // ListReferencedBioss gets the collection of Bios from a provided reference.
-func ListReferencedBioss(c common.Client, link string) ([]*Bios, error) { //nolint:dupl
-	var result []*Bios
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedBioss(c common.Client, link string) ([]*Bios, error) {
+	return ListReferencedBiossContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, biosLink := range links.ItemLinks {
-		bios, err := GetBios(c, biosLink)
-		if err != nil {
-			collectionError.Failures[biosLink] = err
-		} else {
-			result = append(result, bios)
-		}
-	}
+// ListReferencedBiossContext is the same as ListReferencedBioss, but uses ctx
+// for the requests.
+func ListReferencedBiossContext(ctx context.Context, c common.Client, link string) ([]*Bios, error) {
+	return ListReferencedBiossWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedBiossWithOptions is the same as ListReferencedBiossContext, but
+// adds the query options opts to the requests.
+func ListReferencedBiossWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*Bios, error) { //nolint:dupl
+	var result []*Bios
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
 
 // ChangePassword shall change the selected BIOS password.
@@ -210,23 +207,14 @@ func (bios *Bios) ChangePassword(passwordName, oldPassword, newPassword string)
 		NewPassword:  newPassword,
 	}
 
-	resp, err := bios.Client.Post(bios.changePasswordTarget, t)
-	if err == nil {
-		defer resp.Body.Close()
-	}
-
-	return err
+	return common.PostAction(context.Background(), &bios.Entity, bios.changePasswordTarget, t)
 }
 
 // ResetBios shall perform a reset of the BIOS attributes to their default values.
 // A system reset may be required for the default values to be applied. This
 // action may impact other resources.
 func (bios *Bios) ResetBios() error {
-	resp, err := bios.Client.Post(bios.resetBiosTarget, nil)
-	if err == nil {
-		defer resp.Body.Close()
-	}
-	return err
+	return common.PostAction(context.Background(), &bios.Entity, bios.resetBiosTarget, nil)
 }
 
 // AllowedAttributeUpdateApplyTimes returns the set of allowed apply times to request when
@@ -5,6 +5,7 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"reflect"
@@ -318,45 +319,41 @@ func (chassis *Chassis) Update() error {
 
 // GetChassis will get a Chassis instance from the Redfish service.
 func GetChassis(c common.Client, uri string) (*Chassis, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetChassisContext(context.Background(), c, uri)
+}
+
+// GetChassisContext is the same as GetChassis, but uses ctx for the request.
+func GetChassisContext(ctx context.Context, c common.Client, uri string) (*Chassis, error) {
+	return GetChassisWithOptions(ctx, c, uri, nil)
+}
 
+// GetChassisWithOptions is the same as GetChassisContext, but adds the query
+// options opts to the request.
+func GetChassisWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*Chassis, error) {
 	var chassis Chassis
-	err = json.NewDecoder(resp.Body).Decode(&chassis)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &chassis); err != nil {
 		return nil, err
 	}
-
-	chassis.SetClient(c)
 	return &chassis, nil
 }
 
 // ListReferencedChassis gets the collection of Chassis from a provided reference.
 func ListReferencedChassis(c common.Client, link string) ([]*Chassis, error) {
-	var result []*Chassis
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, chassisLink := range links.ItemLinks {
-		chassis, err := GetChassis(c, chassisLink)
-		if err != nil {
-			collectionError.Failures[chassisLink] = err
-		} else {
-			result = append(result, chassis)
-		}
-	}
+	return ListReferencedChassisContext(context.Background(), c, link)
+}
 
-	if collectionError.Empty() {
-		return result, nil
-	}
+// ListReferencedChassisContext is the same as ListReferencedChassis, but uses
+// ctx for the requests.
+func ListReferencedChassisContext(ctx context.Context, c common.Client, link string) ([]*Chassis, error) {
+	return ListReferencedChassisWithOptions(ctx, c, link, nil)
+}
 
-	return result, collectionError
+// ListReferencedChassisWithOptions is the same as ListReferencedChassisContext,
+// but adds the query options opts to the requests.
+func ListReferencedChassisWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*Chassis, error) {
+	var result []*Chassis
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
 
 // Drives gets the drives attached to the storage controllers that this
@@ -379,21 +376,8 @@ func (chassis *Chassis) Drives() ([]*Drive, error) {
 		driveLinks = drives.ItemLinks
 	}
 
-	collectionError := common.NewCollectionError()
-	for _, driveLink := range driveLinks {
-		drive, err := GetDrive(chassis.Client, driveLink)
-		if err != nil {
-			collectionError.Failures[driveLink] = err
-		} else {
-			result = append(result, drive)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	err := common.GetObjects(context.Background(), chassis.Client, driveLinks, &result)
+	return result, err
 }
 
 // Thermal gets the thermal temperature and cooling information for the chassis
@@ -427,43 +411,15 @@ func (chassis *Chassis) Power() (*Power, error) {
 // ComputerSystems returns the collection of systems from this chassis
 func (chassis *Chassis) ComputerSystems() ([]*ComputerSystem, error) {
 	var result []*ComputerSystem
-
-	collectionError := common.NewCollectionError()
-	for _, uri := range chassis.computerSystems {
-		cs, err := GetComputerSystem(chassis.Client, uri)
-		if err != nil {
-			collectionError.Failures[uri] = err
-		} else {
-			result = append(result, cs)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	err := common.GetObjects(context.Background(), chassis.Client, chassis.computerSystems, &result)
+	return result, err
 }
 
 // ManagedBy gets the collection of managers of this chassis
 func (chassis *Chassis) ManagedBy() ([]*Manager, error) {
 	var result []*Manager
-
-	collectionError := common.NewCollectionError()
-	for _, uri := range chassis.managedBy {
-		manager, err := GetManager(chassis.Client, uri)
-		if err != nil {
-			collectionError.Failures[uri] = err
-		} else {
-			result = append(result, manager)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	err := common.GetObjects(context.Background(), chassis.Client, chassis.managedBy, &result)
+	return result, err
 }
 
 // NetworkAdapters gets the collection of network adapters of this chassis
@@ -481,6 +437,11 @@ func (chassis *Chassis) Assembly() (*Assembly, error) {
 // Reset shall reset the chassis. This action shall not reset Systems or other
 // contained resource, although side effects may occur which affect those resources.
 func (chassis *Chassis) Reset(resetType ResetType) error {
+	return chassis.ResetContext(context.Background(), resetType)
+}
+
+// ResetContext is the same as Reset, but uses ctx for the request.
+func (chassis *Chassis) ResetContext(ctx context.Context, resetType ResetType) error {
 	// Make sure the requested reset type is supported by the chassis
 	valid := false
 	if len(chassis.SupportedResetTypes) > 0 {
@@ -507,9 +468,5 @@ func (chassis *Chassis) Reset(resetType ResetType) error {
 		ResetType: resetType,
 	}
 
-	resp, err := chassis.Client.Post(chassis.resetTarget, t)
-	if err == nil {
-		defer resp.Body.Close()
-	}
-	return err
+	return common.PostAction(ctx, &chassis.Entity, chassis.resetTarget, t)
 }
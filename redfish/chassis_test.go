@@ -6,6 +6,7 @@ package redfish
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -278,6 +279,59 @@ func TestChassisUpdate(t *testing.T) {
 	}
 }
 
+// TestChassisUpdateIfMatch tests that updates are sent with the entity tag
+// the chassis was retrieved with.
+func TestChassisUpdateIfMatch(t *testing.T) {
+	resp := getCall(chassisBody)
+	resp.Header.Set("Etag", `W/"12345"`)
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {resp},
+		},
+	}
+
+	result, err := GetChassis(testClient, TestChassisPath)
+	if err != nil {
+		t.Fatalf("Error getting chassis: %s", err)
+	}
+
+	result.AssetTag = TestAssetTag
+	err = result.Update()
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 2 || calls[1].CustomHeaders["If-Match"] != `W/"12345"` {
+		t.Errorf("Expected update to be sent with If-Match: %v", calls)
+	}
+}
+
+// TestChassisResetContext tests that a cancelled context stops the reset.
+func TestChassisResetContext(t *testing.T) {
+	var result Chassis
+	err := json.NewDecoder(strings.NewReader(chassisBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err = result.ResetContext(ctx, ForceOffResetType)
+	if err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got: %v", err)
+	}
+
+	if len(testClient.CapturedCalls()) != 0 {
+		t.Errorf("Expected no calls, captured: %v", testClient.CapturedCalls())
+	}
+}
+
 // getCall returns an http.Response for a GET request.
 func getCall(body string) *http.Response {
 	return &http.Response{
@@ -363,3 +417,23 @@ func TestChassisLinkedDrives(t *testing.T) {
 		t.Errorf("Expected 4 drives to be returned, got %d", len(drives))
 	}
 }
+
+// TestGetChassisWithOptions tests that query options are added to the
+// request.
+func TestGetChassisWithOptions(t *testing.T) {
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {getCall(chassisBody)},
+		},
+	}
+
+	opts := &common.QueryOptions{Select: []string{"PowerState", "Status"}}
+	if _, err := GetChassisWithOptions(context.Background(), testClient, TestChassisPath, opts); err != nil {
+		t.Fatalf("Error getting chassis: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 1 || calls[0].URL != TestChassisPath+"?$select=PowerState%2CStatus" {
+		t.Errorf("Expected the $select query parameter: %v", calls)
+	}
+}
@@ -5,6 +5,7 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 	"reflect"
 
@@ -89,48 +90,46 @@ func (compositionservice *CompositionService) Update() error {
 
 // GetCompositionService will get a CompositionService instance from the service.
 func GetCompositionService(c common.Client, uri string) (*CompositionService, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetCompositionServiceContext(context.Background(), c, uri)
+}
+
+// GetCompositionServiceContext is the same as GetCompositionService, but uses
+// ctx for the request.
+func GetCompositionServiceContext(ctx context.Context, c common.Client, uri string) (*CompositionService, error) {
+	return GetCompositionServiceWithOptions(ctx, c, uri, nil)
+}
 
+// GetCompositionServiceWithOptions is the same as GetCompositionServiceContext,
+// but adds the query options opts to the request.
+func GetCompositionServiceWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*CompositionService, error) {
 	var compositionservice CompositionService
-	err = json.NewDecoder(resp.Body).Decode(&compositionservice)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &compositionservice); err != nil {
 		return nil, err
 	}
-
-	compositionservice.SetClient(c)
 	return &compositionservice, nil
 }
 
 // ListReferencedCompositionServices gets the collection of CompositionService from
 // a provided reference.
-func ListReferencedCompositionServices(c common.Client, link string) ([]*CompositionService, error) { //nolint:dupl
-	var result []*CompositionService
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedCompositionServices(c common.Client, link string) ([]*CompositionService, error) {
+	return ListReferencedCompositionServicesContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, compositionserviceLink := range links.ItemLinks {
-		compositionservice, err := GetCompositionService(c, compositionserviceLink)
-		if err != nil {
-			collectionError.Failures[compositionserviceLink] = err
-		} else {
-			result = append(result, compositionservice)
-		}
-	}
+// ListReferencedCompositionServicesContext is the same as
+// ListReferencedCompositionServices, but uses ctx for the requests.
+func ListReferencedCompositionServicesContext(ctx context.Context, c common.Client, link string) ([]*CompositionService, error) {
+	return ListReferencedCompositionServicesWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedCompositionServicesWithOptions is the same as
+// ListReferencedCompositionServicesContext, but adds the query options opts to
+// the requests.
+func ListReferencedCompositionServicesWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*CompositionService, error) { //nolint:dupl
+	var result []*CompositionService
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
@@ -5,6 +5,7 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"reflect"
@@ -377,19 +378,22 @@ type BootOption struct {
 
 // GetBootOption will get a BootOption instance from the service.
 func GetBootOption(c common.Client, uri string) (*BootOption, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetBootOptionContext(context.Background(), c, uri)
+}
 
+// GetBootOptionContext is the same as GetBootOption, but uses ctx for the
+// request.
+func GetBootOptionContext(ctx context.Context, c common.Client, uri string) (*BootOption, error) {
+	return GetBootOptionWithOptions(ctx, c, uri, nil)
+}
+
+// GetBootOptionWithOptions is the same as GetBootOptionContext, but adds the
+// query options opts to the request.
+func GetBootOptionWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*BootOption, error) {
 	var bootoption BootOption
-	err = json.NewDecoder(resp.Body).Decode(&bootoption)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &bootoption); err != nil {
 		return nil, err
 	}
-
-	bootoption.SetClient(c)
 	return &bootoption, nil
 }
 
@@ -559,10 +563,6 @@ type ComputerSystem struct {
 	ManagedBy []string
 	// rawData holds the original serialized JSON so we can compare updates.
 	rawData []byte
-	// etag contains the etag header when fetching the object. This is used to
-	// control updates to make sure the object has not been modified my a different
-	// process between fetching and updating that could cause conflicts.
-	etag string
 }
 
 // UnmarshalJSON unmarshals a ComputerSystem object from the raw JSON.
@@ -630,6 +630,11 @@ func (computersystem *ComputerSystem) UnmarshalJSON(b []byte) error {
 
 // Update commits updates to this object's properties to the running system.
 func (computersystem *ComputerSystem) Update() error {
+	return computersystem.UpdateContext(context.Background())
+}
+
+// UpdateContext is the same as Update, but uses ctx for the request.
+func (computersystem *ComputerSystem) UpdateContext(ctx context.Context) error {
 	// Get a representation of the object's original state so we can find what
 	// to update.
 	cs := new(ComputerSystem)
@@ -640,7 +645,20 @@ func (computersystem *ComputerSystem) Update() error {
 
 	readWriteFields := []string{
 		"AssetTag",
+		"Boot.AliasBootOrder",
+		"Boot.AutomaticRetryAttempts",
+		"Boot.AutomaticRetryConfig",
+		"Boot.BootNext",
+		"Boot.BootOrder",
+		"Boot.BootOrderPropertySelection",
+		"Boot.BootSourceOverrideEnabled",
+		"Boot.BootSourceOverrideMode",
+		"Boot.BootSourceOverrideTarget",
+		"Boot.UefiTargetBootSourceOverride",
 		"HostName",
+		"HostWatchdogTimer.FunctionEnabled",
+		"HostWatchdogTimer.TimeoutAction",
+		"HostWatchdogTimer.WarningAction",
 		"IndicatorLED",
 		"PowerRestorePolicy",
 	}
@@ -648,55 +666,49 @@ func (computersystem *ComputerSystem) Update() error {
 	originalElement := reflect.ValueOf(cs).Elem()
 	currentElement := reflect.ValueOf(computersystem).Elem()
 
-	return computersystem.Entity.Update(originalElement, currentElement, readWriteFields)
+	return computersystem.Entity.UpdateContext(ctx, originalElement, currentElement, readWriteFields)
 }
 
 // GetComputerSystem will get a ComputerSystem instance from the service.
 func GetComputerSystem(c common.Client, uri string) (*ComputerSystem, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetComputerSystemContext(context.Background(), c, uri)
+}
+
+// GetComputerSystemContext is the same as GetComputerSystem, but uses ctx for
+// the request.
+func GetComputerSystemContext(ctx context.Context, c common.Client, uri string) (*ComputerSystem, error) {
+	return GetComputerSystemWithOptions(ctx, c, uri, nil)
+}
 
+// GetComputerSystemWithOptions is the same as GetComputerSystemContext, but
+// adds the query options opts to the request.
+func GetComputerSystemWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*ComputerSystem, error) {
 	var computersystem ComputerSystem
-	err = json.NewDecoder(resp.Body).Decode(&computersystem)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &computersystem); err != nil {
 		return nil, err
 	}
-
-	if resp.Header["Etag"] != nil {
-		computersystem.etag = resp.Header["Etag"][0]
-	}
-
-	computersystem.SetClient(c)
 	return &computersystem, nil
 }
 
 // ListReferencedComputerSystems gets the collection of ComputerSystem from
 // a provided reference.
 func ListReferencedComputerSystems(c common.Client, link string) ([]*ComputerSystem, error) {
-	var result []*ComputerSystem
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, computersystemLink := range links.ItemLinks {
-		computersystem, err := GetComputerSystem(c, computersystemLink)
-		if err != nil {
-			collectionError.Failures[computersystemLink] = err
-		} else {
-			result = append(result, computersystem)
-		}
-	}
+	return ListReferencedComputerSystemsContext(context.Background(), c, link)
+}
 
-	if collectionError.Empty() {
-		return result, nil
-	}
+// ListReferencedComputerSystemsContext is the same as
+// ListReferencedComputerSystems, but uses ctx for the requests.
+func ListReferencedComputerSystemsContext(ctx context.Context, c common.Client, link string) ([]*ComputerSystem, error) {
+	return ListReferencedComputerSystemsWithOptions(ctx, c, link, nil)
+}
 
-	return result, collectionError
+// ListReferencedComputerSystemsWithOptions is the same as
+// ListReferencedComputerSystemsContext, but adds the query options opts to the
+// requests.
+func ListReferencedComputerSystemsWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*ComputerSystem, error) {
+	var result []*ComputerSystem
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
 
 // Bios gets the Bios information for this ComputerSystem.
@@ -716,21 +728,8 @@ func (computersystem *ComputerSystem) BootOptions() ([]*BootOption, error) {
 		return result, err
 	}
 
-	collectionError := common.NewCollectionError()
-	for _, bootOptionLink := range links.ItemLinks {
-		bootOption, err := GetBootOption(computersystem.Client, bootOptionLink)
-		if err != nil {
-			collectionError.Failures[bootOptionLink] = err
-		} else {
-			result = append(result, bootOption)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	err = common.GetObjects(context.Background(), computersystem.Client, links.ItemLinks, &result)
+	return result, err
 }
 
 // EthernetInterfaces get this system's ethernet interfaces.
@@ -761,43 +760,15 @@ func (computersystem *ComputerSystem) NetworkInterfaces() ([]*NetworkInterface,
 // PCIeDevices gets all PCIeDevices for this system.
 func (computersystem *ComputerSystem) PCIeDevices() ([]*PCIeDevice, error) {
 	var result []*PCIeDevice
-
-	collectionError := common.NewCollectionError()
-	for _, pciedeviceLink := range computersystem.pcieDevices {
-		pciedevice, err := GetPCIeDevice(computersystem.Client, pciedeviceLink)
-		if err != nil {
-			collectionError.Failures[pciedeviceLink] = err
-		} else {
-			result = append(result, pciedevice)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	err := common.GetObjects(context.Background(), computersystem.Client, computersystem.pcieDevices, &result)
+	return result, err
 }
 
 // PCIeFunctions gets all PCIeFunctions for this system.
 func (computersystem *ComputerSystem) PCIeFunctions() ([]*PCIeFunction, error) {
 	var result []*PCIeFunction
-
-	collectionError := common.NewCollectionError()
-	for _, pciefunctionLink := range computersystem.pcieFunctions {
-		pciefunction, err := GetPCIeFunction(computersystem.Client, pciefunctionLink)
-		if err != nil {
-			collectionError.Failures[pciefunctionLink] = err
-		} else {
-			result = append(result, pciefunction)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	err := common.GetObjects(context.Background(), computersystem.Client, computersystem.pcieFunctions, &result)
+	return result, err
 }
 
 // Processors returns a collection of processors from this system
@@ -816,6 +787,11 @@ func (computersystem *ComputerSystem) SecureBoot() (*SecureBoot, error) {
 
 // SetBoot set a boot object based on a payload request
 func (computersystem *ComputerSystem) SetBoot(b Boot) error { //nolint
+	return computersystem.SetBootContext(context.Background(), b)
+}
+
+// SetBootContext is the same as SetBoot, but uses ctx for the request.
+func (computersystem *ComputerSystem) SetBootContext(ctx context.Context, b Boot) error { //nolint
 	type temp struct {
 		Boot Boot
 	}
@@ -823,16 +799,7 @@ func (computersystem *ComputerSystem) SetBoot(b Boot) error { //nolint
 		Boot: b,
 	}
 
-	var header = make(map[string]string)
-	if computersystem.etag != "" {
-		header["If-Match"] = computersystem.etag
-	}
-
-	resp, err := computersystem.Client.PatchWithHeaders(computersystem.ODataID, t, header)
-	if err == nil {
-		return resp.Body.Close()
-	}
-	return err
+	return common.PatchEntity(ctx, &computersystem.Entity, t)
 }
 
 // Reset shall perform a reset of the ComputerSystem. For systems which implement
@@ -842,6 +809,11 @@ func (computersystem *ComputerSystem) SetBoot(b Boot) error { //nolint
 // 4-second hold of the Power Button). The ForceRestart value shall perform a
 // ForceOff action followed by a On action.
 func (computersystem *ComputerSystem) Reset(resetType ResetType) error {
+	return computersystem.ResetContext(context.Background(), resetType)
+}
+
+// ResetContext is the same as Reset, but uses ctx for the request.
+func (computersystem *ComputerSystem) ResetContext(ctx context.Context, resetType ResetType) error {
 	// Make sure the requested reset type is supported by the system
 	valid := false
 	if len(computersystem.SupportedResetTypes) > 0 {
@@ -868,16 +840,7 @@ func (computersystem *ComputerSystem) Reset(resetType ResetType) error {
 		ResetType: resetType,
 	}
 
-	var header = make(map[string]string)
-	if computersystem.etag != "" {
-		header["If-Match"] = computersystem.etag
-	}
-
-	resp, err := computersystem.Client.PostWithHeaders(computersystem.resetTarget, t, header)
-	if err == nil {
-		defer resp.Body.Close()
-	}
-	return err
+	return common.PostAction(ctx, &computersystem.Entity, computersystem.resetTarget, t)
 }
 
 // SetDefaultBootOrder shall set the BootOrder array to the default settings.
@@ -887,16 +850,7 @@ func (computersystem *ComputerSystem) SetDefaultBootOrder() error {
 		return fmt.Errorf("SetDefaultBootOrder is not supported by this system") //nolint:golint
 	}
 
-	var header = make(map[string]string)
-	if computersystem.etag != "" {
-		header["If-Match"] = computersystem.etag
-	}
-
-	resp, err := computersystem.Client.PostWithHeaders(computersystem.resetTarget, nil, header)
-	if err == nil {
-		defer resp.Body.Close()
-	}
-	return err
+	return common.PostAction(context.Background(), &computersystem.Entity, computersystem.resetTarget, nil)
 }
 
 // SimpleStorages gets all simple storage services of this system.
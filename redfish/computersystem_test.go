@@ -6,6 +6,7 @@ package redfish
 
 import (
 	"encoding/json"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -309,6 +310,41 @@ func TestComputerSystemUpdate(t *testing.T) {
 	}
 }
 
+// TestComputerSystemUpdateNested tests that changes to nested properties are
+// sent as a minimal nested update.
+func TestComputerSystemUpdateNested(t *testing.T) {
+	var result ComputerSystem
+	err := json.NewDecoder(strings.NewReader(computerSystemBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.Boot.BootSourceOverrideTarget = HddBootSourceOverrideTarget
+	result.HostWatchdogTimer.FunctionEnabled = !result.HostWatchdogTimer.FunctionEnabled
+	err = result.Update()
+
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+	expected := fmt.Sprintf("map[Boot:map[BootSourceOverrideTarget:Hdd] HostWatchdogTimer:map[FunctionEnabled:%t]]",
+		result.HostWatchdogTimer.FunctionEnabled)
+	if len(calls) != 1 || calls[0].Payload != expected {
+		t.Errorf("Unexpected update payload: %v", calls)
+	}
+
+	result.Status.Health = common.CriticalHealth
+	err = result.Update()
+	if err == nil || err.Error() != "Status.Health field is read only" {
+		t.Errorf("Expected read only error, got: %v", err)
+	}
+}
+
 var bootOptionBody = `{
 	"@odata.context": "/redfish/v1/$metadata#BootOption.BootOption",
 	"@odata.etag": "W/\"A3A6BF43\"",
@@ -5,6 +5,7 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 	"reflect"
 
@@ -314,49 +315,45 @@ func (drive *Drive) Update() error {
 
 // GetDrive will get a Drive instance from the service.
 func GetDrive(c common.Client, uri string) (*Drive, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetDriveContext(context.Background(), c, uri)
+}
+
+// GetDriveContext is the same as GetDrive, but uses ctx for the request.
+func GetDriveContext(ctx context.Context, c common.Client, uri string) (*Drive, error) {
+	return GetDriveWithOptions(ctx, c, uri, nil)
+}
 
+// GetDriveWithOptions is the same as GetDriveContext, but adds the query
+// options opts to the request.
+func GetDriveWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*Drive, error) {
 	var drive Drive
-	err = json.NewDecoder(resp.Body).Decode(&drive)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &drive); err != nil {
 		return nil, err
 	}
-
-	drive.SetClient(c)
 	return &drive, nil
 }
 
 // ListReferencedDrives gets the collection of Drives from a provided reference.
-func ListReferencedDrives(c common.Client, link string) ([]*Drive, error) { //nolint:dupl
-	var result []*Drive
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedDrives(c common.Client, link string) ([]*Drive, error) {
+	return ListReferencedDrivesContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, driveLink := range links.ItemLinks {
-		drive, err := GetDrive(c, driveLink)
-		if err != nil {
-			collectionError.Failures[driveLink] = err
-		} else {
-			result = append(result, drive)
-		}
-	}
+// ListReferencedDrivesContext is the same as ListReferencedDrives, but uses ctx
+// for the requests.
+func ListReferencedDrivesContext(ctx context.Context, c common.Client, link string) ([]*Drive, error) {
+	return ListReferencedDrivesWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedDrivesWithOptions is the same as ListReferencedDrivesContext,
+// but adds the query options opts to the requests.
+func ListReferencedDrivesWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*Drive, error) { //nolint:dupl
+	var result []*Drive
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
 
 // Assembly gets the Assembly for this drive.
@@ -380,64 +377,22 @@ func (drive *Drive) Chassis() (*Chassis, error) {
 // Endpoints references the Endpoints that this drive is associated with.
 func (drive *Drive) Endpoints() ([]*Endpoint, error) {
 	var result []*Endpoint
-
-	collectionError := common.NewCollectionError()
-	for _, endpointLink := range drive.endpoints {
-		endpoint, err := GetEndpoint(drive.Client, endpointLink)
-		if err != nil {
-			collectionError.Failures[endpointLink] = err
-		} else {
-			result = append(result, endpoint)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	err := common.GetObjects(context.Background(), drive.Client, drive.endpoints, &result)
+	return result, err
 }
 
 // Volumes references the Volumes that this drive is associated with.
 func (drive *Drive) Volumes() ([]*Volume, error) {
 	var result []*Volume
-
-	collectionError := common.NewCollectionError()
-	for _, volumeLink := range drive.volumes {
-		volume, err := GetVolume(drive.Client, volumeLink)
-		if err != nil {
-			collectionError.Failures[volumeLink] = err
-		} else {
-			result = append(result, volume)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	err := common.GetObjects(context.Background(), drive.Client, drive.volumes, &result)
+	return result, err
 }
 
 // PCIeFunctions references the PCIeFunctions that this drive is associated with.
 func (drive *Drive) PCIeFunctions() ([]*PCIeFunction, error) {
 	var result []*PCIeFunction
-
-	collectionError := common.NewCollectionError()
-	for _, pcieFunctionLink := range drive.pcieFunctions {
-		pcieFunction, err := GetPCIeFunction(drive.Client, pcieFunctionLink)
-		if err != nil {
-			collectionError.Failures[pcieFunctionLink] = err
-		} else {
-			result = append(result, pcieFunction)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	err := common.GetObjects(context.Background(), drive.Client, drive.pcieFunctions, &result)
+	return result, err
 }
 
 // // StoragePools references the StoragePools that this drive is associated with.
@@ -457,9 +412,5 @@ func (drive *Drive) PCIeFunctions() ([]*PCIeFunction, error) {
 
 // SecureErase shall perform a secure erase of the drive.
 func (drive *Drive) SecureErase() error {
-	resp, err := drive.Client.Post(drive.secureEraseTarget, nil)
-	if err == nil {
-		defer resp.Body.Close()
-	}
-	return err
+	return common.PostAction(context.Background(), &drive.Entity, drive.secureEraseTarget, nil)
 }
@@ -5,6 +5,7 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/stmcginnis/gofish/common"
@@ -217,50 +218,47 @@ func (endpoint *Endpoint) UnmarshalJSON(b []byte) error {
 
 // GetEndpoint will get a Endpoint instance from the service.
 func GetEndpoint(c common.Client, uri string) (*Endpoint, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetEndpointContext(context.Background(), c, uri)
+}
+
+// GetEndpointContext is the same as GetEndpoint, but uses ctx for the request.
+func GetEndpointContext(ctx context.Context, c common.Client, uri string) (*Endpoint, error) {
+	return GetEndpointWithOptions(ctx, c, uri, nil)
+}
 
+// GetEndpointWithOptions is the same as GetEndpointContext, but adds the query
+// options opts to the request.
+func GetEndpointWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*Endpoint, error) {
 	var endpoint Endpoint
-	err = json.NewDecoder(resp.Body).Decode(&endpoint)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &endpoint); err != nil {
 		return nil, err
 	}
-
-	endpoint.SetClient(c)
 	return &endpoint, nil
 }
 
 // ListReferencedEndpoints gets the collection of Endpoint from
 // a provided reference.
-func ListReferencedEndpoints(c common.Client, link string) ([]*Endpoint, error) { //nolint:dupl
-	var result []*Endpoint
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedEndpoints(c common.Client, link string) ([]*Endpoint, error) {
+	return ListReferencedEndpointsContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, endpointLink := range links.ItemLinks {
-		endpoint, err := GetEndpoint(c, endpointLink)
-		if err != nil {
-			collectionError.Failures[endpointLink] = err
-		} else {
-			result = append(result, endpoint)
-		}
-	}
+// ListReferencedEndpointsContext is the same as ListReferencedEndpoints, but
+// uses ctx for the requests.
+func ListReferencedEndpointsContext(ctx context.Context, c common.Client, link string) ([]*Endpoint, error) {
+	return ListReferencedEndpointsWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedEndpointsWithOptions is the same as
+// ListReferencedEndpointsContext, but adds the query options opts to the
+// requests.
+func ListReferencedEndpointsWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*Endpoint, error) { //nolint:dupl
+	var result []*Endpoint
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
 
 // GCID shall contain the Gen-Z Core Specification-defined Global
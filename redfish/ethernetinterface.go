@@ -5,6 +5,7 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 	"reflect"
 
@@ -261,9 +262,12 @@ func (ethernetinterface *EthernetInterface) Update() error {
 
 	readWriteFields := []string{
 		"AutoNeg",
+		"DHCPv4",
+		"DHCPv6",
 		"FQDN",
 		"FullDuplex",
 		"HostName",
+		"IPv4StaticAddresses",
 		"InterfaceEnabled",
 		"MACAddress",
 		"MTUSize",
@@ -279,50 +283,48 @@ func (ethernetinterface *EthernetInterface) Update() error {
 
 // GetEthernetInterface will get a EthernetInterface instance from the service.
 func GetEthernetInterface(c common.Client, uri string) (*EthernetInterface, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetEthernetInterfaceContext(context.Background(), c, uri)
+}
+
+// GetEthernetInterfaceContext is the same as GetEthernetInterface, but uses ctx
+// for the request.
+func GetEthernetInterfaceContext(ctx context.Context, c common.Client, uri string) (*EthernetInterface, error) {
+	return GetEthernetInterfaceWithOptions(ctx, c, uri, nil)
+}
 
+// GetEthernetInterfaceWithOptions is the same as GetEthernetInterfaceContext,
+// but adds the query options opts to the request.
+func GetEthernetInterfaceWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*EthernetInterface, error) {
 	var ethernetinterface EthernetInterface
-	err = json.NewDecoder(resp.Body).Decode(&ethernetinterface)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &ethernetinterface); err != nil {
 		return nil, err
 	}
-
-	ethernetinterface.SetClient(c)
 	return &ethernetinterface, nil
 }
 
 // ListReferencedEthernetInterfaces gets the collection of EthernetInterface from
 // a provided reference.
-func ListReferencedEthernetInterfaces(c common.Client, link string) ([]*EthernetInterface, error) { //nolint:dupl
-	var result []*EthernetInterface
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedEthernetInterfaces(c common.Client, link string) ([]*EthernetInterface, error) {
+	return ListReferencedEthernetInterfacesContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, ethernetinterfaceLink := range links.ItemLinks {
-		ethernetinterface, err := GetEthernetInterface(c, ethernetinterfaceLink)
-		if err != nil {
-			collectionError.Failures[ethernetinterfaceLink] = err
-		} else {
-			result = append(result, ethernetinterface)
-		}
-	}
+// ListReferencedEthernetInterfacesContext is the same as
+// ListReferencedEthernetInterfaces, but uses ctx for the requests.
+func ListReferencedEthernetInterfacesContext(ctx context.Context, c common.Client, link string) ([]*EthernetInterface, error) {
+	return ListReferencedEthernetInterfacesWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedEthernetInterfacesWithOptions is the same as
+// ListReferencedEthernetInterfacesContext, but adds the query options opts to
+// the requests.
+func ListReferencedEthernetInterfacesWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*EthernetInterface, error) { //nolint:dupl
+	var result []*EthernetInterface
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
 
 // IPv6AddressPolicyEntry describes and entry in the Address Selection Policy
@@ -5,6 +5,7 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
@@ -261,24 +262,27 @@ func (eventdestination *EventDestination) Update() error {
 
 // GetEventDestination will get a EventDestination instance from the service.
 func GetEventDestination(c common.Client, uri string) (*EventDestination, error) {
+	return GetEventDestinationContext(context.Background(), c, uri)
+}
+
+// GetEventDestinationContext is the same as GetEventDestination, but uses ctx
+// for the request.
+func GetEventDestinationContext(ctx context.Context, c common.Client, uri string) (*EventDestination, error) {
+	return GetEventDestinationWithOptions(ctx, c, uri, nil)
+}
+
+// GetEventDestinationWithOptions is the same as GetEventDestinationContext, but
+// adds the query options opts to the request.
+func GetEventDestinationWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*EventDestination, error) {
 	// validate uri
 	if strings.TrimSpace(uri) == "" {
 		return nil, fmt.Errorf("uri should not be empty")
 	}
 
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var eventdestination EventDestination
-	err = json.NewDecoder(resp.Body).Decode(&eventdestination)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &eventdestination); err != nil {
 		return nil, err
 	}
-
-	eventdestination.SetClient(c)
 	return &eventdestination, nil
 }
 
@@ -410,32 +414,27 @@ func DeleteEventDestination(c common.Client, uri string) error {
 
 // ListReferencedEventDestinations gets the collection of EventDestination from
 // a provided reference.
-func ListReferencedEventDestinations(c common.Client, link string) ([]*EventDestination, error) { //nolint:dupl
-	var result []*EventDestination
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedEventDestinations(c common.Client, link string) ([]*EventDestination, error) {
+	return ListReferencedEventDestinationsContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, eventdestinationLink := range links.ItemLinks {
-		eventdestination, err := GetEventDestination(c, eventdestinationLink)
-		if err != nil {
-			collectionError.Failures[eventdestinationLink] = err
-		} else {
-			result = append(result, eventdestination)
-		}
-	}
+// ListReferencedEventDestinationsContext is the same as
+// ListReferencedEventDestinations, but uses ctx for the requests.
+func ListReferencedEventDestinationsContext(ctx context.Context, c common.Client, link string) ([]*EventDestination, error) {
+	return ListReferencedEventDestinationsWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedEventDestinationsWithOptions is the same as
+// ListReferencedEventDestinationsContext, but adds the query options opts to
+// the requests.
+func ListReferencedEventDestinationsWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*EventDestination, error) { //nolint:dupl
+	var result []*EventDestination
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
 
 // HTTPHeaderProperty shall a names and value of an HTTP header to be included
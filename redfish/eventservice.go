@@ -5,6 +5,7 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"reflect"
@@ -228,50 +229,48 @@ func (eventservice *EventService) Update() error {
 
 // GetEventService will get a EventService instance from the service.
 func GetEventService(c common.Client, uri string) (*EventService, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetEventServiceContext(context.Background(), c, uri)
+}
+
+// GetEventServiceContext is the same as GetEventService, but uses ctx for the
+// request.
+func GetEventServiceContext(ctx context.Context, c common.Client, uri string) (*EventService, error) {
+	return GetEventServiceWithOptions(ctx, c, uri, nil)
+}
 
+// GetEventServiceWithOptions is the same as GetEventServiceContext, but adds
+// the query options opts to the request.
+func GetEventServiceWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*EventService, error) {
 	var eventservice EventService
-	err = json.NewDecoder(resp.Body).Decode(&eventservice)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &eventservice); err != nil {
 		return nil, err
 	}
-
-	eventservice.SetClient(c)
 	return &eventservice, nil
 }
 
 // ListReferencedEventServices gets the collection of EventService from
 // a provided reference.
-func ListReferencedEventServices(c common.Client, link string) ([]*EventService, error) { //nolint:dupl
-	var result []*EventService
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedEventServices(c common.Client, link string) ([]*EventService, error) {
+	return ListReferencedEventServicesContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, eventserviceLink := range links.ItemLinks {
-		eventservice, err := GetEventService(c, eventserviceLink)
-		if err != nil {
-			collectionError.Failures[eventserviceLink] = err
-		} else {
-			result = append(result, eventservice)
-		}
-	}
+// ListReferencedEventServicesContext is the same as
+// ListReferencedEventServices, but uses ctx for the requests.
+func ListReferencedEventServicesContext(ctx context.Context, c common.Client, link string) ([]*EventService, error) {
+	return ListReferencedEventServicesWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedEventServicesWithOptions is the same as
+// ListReferencedEventServicesContext, but adds the query options opts to the
+// requests.
+func ListReferencedEventServicesWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*EventService, error) { //nolint:dupl
+	var result []*EventService
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
 
 // GetEventSubscriptions gets all the subscriptions using the event service.
@@ -355,11 +354,7 @@ func (eventservice *EventService) SubmitTestEvent(message string) error {
 		Severity:          "Informational",
 	}
 
-	resp, err := eventservice.Client.Post(eventservice.SubmitTestEventTarget, t)
-	if err == nil {
-		defer resp.Body.Close()
-	}
-	return err
+	return common.PostAction(context.Background(), &eventservice.Entity, eventservice.SubmitTestEventTarget, t)
 }
 
 // SSEFilterPropertiesSupported shall contain a set of properties that indicate
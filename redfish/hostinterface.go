@@ -5,6 +5,7 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 	"reflect"
 
@@ -183,71 +184,55 @@ func (hostinterface *HostInterface) Update() error {
 
 // GetHostInterface will get a HostInterface instance from the service.
 func GetHostInterface(c common.Client, uri string) (*HostInterface, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetHostInterfaceContext(context.Background(), c, uri)
+}
 
+// GetHostInterfaceContext is the same as GetHostInterface, but uses ctx for the
+// request.
+func GetHostInterfaceContext(ctx context.Context, c common.Client, uri string) (*HostInterface, error) {
+	return GetHostInterfaceWithOptions(ctx, c, uri, nil)
+}
+
+// GetHostInterfaceWithOptions is the same as GetHostInterfaceContext, but adds
+// the query options opts to the request.
+func GetHostInterfaceWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*HostInterface, error) {
 	var hostinterface HostInterface
-	err = json.NewDecoder(resp.Body).Decode(&hostinterface)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &hostinterface); err != nil {
 		return nil, err
 	}
-
-	hostinterface.SetClient(c)
 	return &hostinterface, nil
 }
 
 // ListReferencedHostInterfaces gets the collection of HostInterface from
 // a provided reference.
-func ListReferencedHostInterfaces(c common.Client, link string) ([]*HostInterface, error) { //nolint:dupl
-	var result []*HostInterface
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedHostInterfaces(c common.Client, link string) ([]*HostInterface, error) {
+	return ListReferencedHostInterfacesContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, hostinterfaceLink := range links.ItemLinks {
-		hostinterface, err := GetHostInterface(c, hostinterfaceLink)
-		if err != nil {
-			collectionError.Failures[hostinterfaceLink] = err
-		} else {
-			result = append(result, hostinterface)
-		}
-	}
+// ListReferencedHostInterfacesContext is the same as
+// ListReferencedHostInterfaces, but uses ctx for the requests.
+func ListReferencedHostInterfacesContext(ctx context.Context, c common.Client, link string) ([]*HostInterface, error) {
+	return ListReferencedHostInterfacesWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedHostInterfacesWithOptions is the same as
+// ListReferencedHostInterfacesContext, but adds the query options opts to the
+// requests.
+func ListReferencedHostInterfacesWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*HostInterface, error) { //nolint:dupl
+	var result []*HostInterface
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
 
 // ComputerSystems references the ComputerSystems that this host interface is associated with.
 func (hostinterface *HostInterface) ComputerSystems() ([]*ComputerSystem, error) {
 	var result []*ComputerSystem
-
-	collectionError := common.NewCollectionError()
-	for _, computerSystemLink := range hostinterface.computerSystems {
-		computerSystem, err := GetComputerSystem(hostinterface.Client, computerSystemLink)
-		if err != nil {
-			collectionError.Failures[computerSystemLink] = err
-		} else {
-			result = append(result, computerSystem)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	err := common.GetObjects(context.Background(), hostinterface.Client, hostinterface.computerSystems, &result)
+	return result, err
 }
 
 // HostNetworkInterfaces gets the network interface controllers or cards (NICs)
@@ -5,6 +5,7 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/stmcginnis/gofish/common"
@@ -418,48 +419,129 @@ func (logentry *LogEntry) UnmarshalJSON(b []byte) error {
 
 // GetLogEntry will get a LogEntry instance from the service.
 func GetLogEntry(c common.Client, uri string) (*LogEntry, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetLogEntryContext(context.Background(), c, uri)
+}
 
+// GetLogEntryContext is the same as GetLogEntry, but uses ctx for the request.
+func GetLogEntryContext(ctx context.Context, c common.Client, uri string) (*LogEntry, error) {
+	return GetLogEntryWithOptions(ctx, c, uri, nil)
+}
+
+// GetLogEntryWithOptions is the same as GetLogEntryContext, but adds the query
+// options opts to the request.
+func GetLogEntryWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*LogEntry, error) {
 	var logentry LogEntry
-	err = json.NewDecoder(resp.Body).Decode(&logentry)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &logentry); err != nil {
 		return nil, err
 	}
-
-	logentry.SetClient(c)
 	return &logentry, nil
 }
 
 // ListReferencedLogEntrys gets the collection of LogEntry from
 // a provided reference.
-func ListReferencedLogEntrys(c common.Client, link string) ([]*LogEntry, error) { //nolint:dupl
+func ListReferencedLogEntrys(c common.Client, link string) ([]*LogEntry, error) {
+	return ListReferencedLogEntrysContext(context.Background(), c, link)
+}
+
+// ListReferencedLogEntrysContext is the same as ListReferencedLogEntrys, but
+// uses ctx for the requests.
+func ListReferencedLogEntrysContext(ctx context.Context, c common.Client, link string) ([]*LogEntry, error) {
+	return ListReferencedLogEntrysWithOptions(ctx, c, link, nil)
+}
+
+// ListReferencedLogEntrysWithOptions is the same as
+// ListReferencedLogEntrysContext, but adds the query options opts to the
+// requests.
+func ListReferencedLogEntrysWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*LogEntry, error) { //nolint:dupl
 	var result []*LogEntry
 	if link == "" {
 		return result, nil
 	}
 
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
+}
+
+// LogEntryIterator walks the entries of a log service one page at a time, so
+// large logs do not need to be held in memory.
+//
+//	entries := logservice.EntriesIterator()
+//	for entries.Next() {
+//		entry := entries.Entry()
+//		...
+//	}
+//	if err := entries.Err(); err != nil {
+//		...
+//	}
+type LogEntryIterator struct {
+	pages    *common.CollectionIterator
+	entries  []*LogEntry
+	entry    *LogEntry
+	failures *common.CollectionError
+	err      error
+}
+
+// NewLogEntryIterator returns an iterator over the LogEntry collection at
+// link.
+func NewLogEntryIterator(ctx context.Context, c common.Client, link string) *LogEntryIterator {
+	return NewLogEntryIteratorWithOptions(ctx, c, link, nil)
+}
+
+// NewLogEntryIteratorWithOptions is the same as NewLogEntryIterator, but adds
+// the query options opts to the requests, for example a $filter on the
+// entry Severity.
+func NewLogEntryIteratorWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) *LogEntryIterator {
+	it := &LogEntryIterator{failures: common.NewCollectionError()}
+	if link != "" {
+		it.pages = common.NewCollectionIteratorWithOptions(ctx, c, link, opts)
 	}
+	return it
+}
 
-	collectionError := common.NewCollectionError()
-	for _, logentryLink := range links.ItemLinks {
-		logentry, err := GetLogEntry(c, logentryLink)
-		if err != nil {
-			collectionError.Failures[logentryLink] = err
-		} else {
-			result = append(result, logentry)
+// Next advances to the next entry, retrieving the next page of entries if
+// needed. It returns false when there are no more entries or a page could
+// not be retrieved. Entries that could not be retrieved are skipped and
+// reported by Err.
+func (it *LogEntryIterator) Next() bool {
+	for len(it.entries) == 0 {
+		if it.pages == nil || it.err != nil || !it.pages.Next() {
+			it.entry = nil
+			return false
 		}
-	}
 
-	if collectionError.Empty() {
-		return result, nil
+		var entries []*LogEntry
+		err := it.pages.Objects(&entries)
+		if collectionError, ok := err.(*common.CollectionError); ok {
+			for link, failure := range collectionError.Failures {
+				it.failures.Failures[link] = failure
+			}
+		} else if err != nil {
+			it.err = err
+		}
+		it.entries = entries
 	}
 
-	return result, collectionError
+	it.entry = it.entries[0]
+	it.entries = it.entries[1:]
+	return true
+}
+
+// Entry returns the current entry.
+func (it *LogEntryIterator) Entry() *LogEntry {
+	return it.entry
+}
+
+// Err returns the error that stopped the iteration, or a
+// *common.CollectionError listing any entries that could not be retrieved.
+func (it *LogEntryIterator) Err() error {
+	if it.err != nil {
+		return it.err
+	}
+	if it.pages != nil && it.pages.Err() != nil {
+		return it.pages.Err()
+	}
+	if !it.failures.Empty() {
+		return it.failures
+	}
+	return nil
 }
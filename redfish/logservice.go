@@ -5,6 +5,7 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 	"reflect"
 
@@ -142,54 +143,63 @@ func (logservice *LogService) Update() error {
 
 // GetLogService will get a LogService instance from the service.
 func GetLogService(c common.Client, uri string) (*LogService, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetLogServiceContext(context.Background(), c, uri)
+}
+
+// GetLogServiceContext is the same as GetLogService, but uses ctx for the
+// request.
+func GetLogServiceContext(ctx context.Context, c common.Client, uri string) (*LogService, error) {
+	return GetLogServiceWithOptions(ctx, c, uri, nil)
+}
 
+// GetLogServiceWithOptions is the same as GetLogServiceContext, but adds the
+// query options opts to the request.
+func GetLogServiceWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*LogService, error) {
 	var logservice LogService
-	err = json.NewDecoder(resp.Body).Decode(&logservice)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &logservice); err != nil {
 		return nil, err
 	}
-
-	logservice.SetClient(c)
 	return &logservice, nil
 }
 
 // ListReferencedLogServices gets the collection of LogService from a provided reference.
-func ListReferencedLogServices(c common.Client, link string) ([]*LogService, error) { //nolint:dupl
-	var result []*LogService
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedLogServices(c common.Client, link string) ([]*LogService, error) {
+	return ListReferencedLogServicesContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, logserviceLink := range links.ItemLinks {
-		logservice, err := GetLogService(c, logserviceLink)
-		if err != nil {
-			collectionError.Failures[logserviceLink] = err
-		} else {
-			result = append(result, logservice)
-		}
-	}
+// ListReferencedLogServicesContext is the same as ListReferencedLogServices,
+// but uses ctx for the requests.
+func ListReferencedLogServicesContext(ctx context.Context, c common.Client, link string) ([]*LogService, error) {
+	return ListReferencedLogServicesWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedLogServicesWithOptions is the same as
+// ListReferencedLogServicesContext, but adds the query options opts to the
+// requests.
+func ListReferencedLogServicesWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*LogService, error) { //nolint:dupl
+	var result []*LogService
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
 
 // Entries gets the log entries of this service.
 func (logservice *LogService) Entries() ([]*LogEntry, error) {
-	return ListReferencedLogEntrys(logservice.Client, logservice.entries)
+	var result []*LogEntry
+	entries := logservice.EntriesIterator()
+	for entries.Next() {
+		result = append(result, entries.Entry())
+	}
+	return result, entries.Err()
+}
+
+// EntriesIterator returns an iterator over the log entries, retrieving them
+// one page at a time.
+func (logservice *LogService) EntriesIterator() *LogEntryIterator {
+	return NewLogEntryIterator(context.Background(), logservice.Client, logservice.entries)
 }
 
 // ClearLog shall delete all entries found in the Entries collection for this
@@ -202,9 +212,5 @@ func (logservice *LogService) ClearLog() error {
 		Action: "LogService.ClearLog",
 	}
 
-	resp, err := logservice.Client.Post(logservice.clearLogTarget, t)
-	if err == nil {
-		defer resp.Body.Close()
-	}
-	return err
+	return common.PostAction(context.Background(), &logservice.Entity, logservice.clearLogTarget, t)
 }
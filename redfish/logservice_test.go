@@ -6,6 +6,8 @@ package redfish
 
 import (
 	"encoding/json"
+	"io"
+	"net/http"
 	"strings"
 	"testing"
 
@@ -105,3 +107,56 @@ func TestLogServiceUpdate(t *testing.T) { //nolint:dupl
 		t.Errorf("Unexpected ServiceEnabled update payload: %s", calls[0].Payload)
 	}
 }
+
+// TestLogServiceEntries tests retrieving log entries that are returned in
+// multiple pages.
+func TestLogServiceEntries(t *testing.T) {
+	var result LogService
+	err := json.NewDecoder(strings.NewReader(logServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				&http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(`{
+					"Members@odata.count": 3,
+					"Members": [
+						{"@odata.id": "/redfish/v1/LogEntryCollection/1", "Id": "1", "Message": "first"},
+						{"@odata.id": "/redfish/v1/LogEntryCollection/2", "Id": "2", "Message": "second"}
+					],
+					"Members@odata.nextLink": "/redfish/v1/LogEntryCollection?$skip=2"
+				}`))},
+				&http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(`{
+					"Members@odata.count": 3,
+					"Members": [{"@odata.id": "/redfish/v1/LogEntryCollection/3"}]
+				}`))},
+				&http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(
+					`{"@odata.id": "/redfish/v1/LogEntryCollection/3", "Id": "3", "Message": "third"}`))},
+			},
+		},
+	}
+	result.SetClient(testClient)
+
+	entries, err := result.Entries()
+	if err != nil {
+		t.Fatalf("Error getting entries: %s", err)
+	}
+
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(entries))
+	}
+
+	for i, message := range []string{"first", "second", "third"} {
+		if entries[i].Message != message {
+			t.Errorf("Expected entry %d to be %s, got %s", i, message, entries[i].Message)
+		}
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 3 || calls[1].URL != "/redfish/v1/LogEntryCollection?$skip=2" {
+		t.Errorf("Unexpected calls: %v", calls)
+	}
+}
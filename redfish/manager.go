@@ -5,6 +5,7 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"reflect"
@@ -375,8 +376,11 @@ func (manager *Manager) Update() error {
 
 	readWriteFields := []string{
 		"AutoDSTEnabled",
+		"CommandShell.ServiceEnabled",
 		"DateTime",
 		"DateTimeLocalOffset",
+		"GraphicalConsole.ServiceEnabled",
+		"SerialConsole.ServiceEnabled",
 	}
 
 	originalElement := reflect.ValueOf(original).Elem()
@@ -387,49 +391,51 @@ func (manager *Manager) Update() error {
 
 // GetManager will get a Manager instance from the Swordfish service.
 func GetManager(c common.Client, uri string) (*Manager, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetManagerContext(context.Background(), c, uri)
+}
+
+// GetManagerContext is the same as GetManager, but uses ctx for the request.
+func GetManagerContext(ctx context.Context, c common.Client, uri string) (*Manager, error) {
+	return GetManagerWithOptions(ctx, c, uri, nil)
+}
 
+// GetManagerWithOptions is the same as GetManagerContext, but adds the query
+// options opts to the request.
+func GetManagerWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*Manager, error) {
 	var manager Manager
-	err = json.NewDecoder(resp.Body).Decode(&manager)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &manager); err != nil {
 		return nil, err
 	}
-
-	manager.SetClient(c)
 	return &manager, nil
 }
 
 // ListReferencedManagers gets the collection of Managers
 func ListReferencedManagers(c common.Client, link string) ([]*Manager, error) {
-	var result []*Manager
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, managerLink := range links.ItemLinks {
-		manager, err := GetManager(c, managerLink)
-		if err != nil {
-			collectionError.Failures[managerLink] = err
-		} else {
-			result = append(result, manager)
-		}
-	}
+	return ListReferencedManagersContext(context.Background(), c, link)
+}
 
-	if collectionError.Empty() {
-		return result, nil
-	}
+// ListReferencedManagersContext is the same as ListReferencedManagers, but uses
+// ctx for the requests.
+func ListReferencedManagersContext(ctx context.Context, c common.Client, link string) ([]*Manager, error) {
+	return ListReferencedManagersWithOptions(ctx, c, link, nil)
+}
 
-	return result, collectionError
+// ListReferencedManagersWithOptions is the same as
+// ListReferencedManagersContext, but adds the query options opts to the
+// requests.
+func ListReferencedManagersWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*Manager, error) {
+	var result []*Manager
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
 
 // Reset shall perform a reset of the manager.
 func (manager *Manager) Reset(resetType ResetType) error {
+	return manager.ResetContext(context.Background(), resetType)
+}
+
+// ResetContext is the same as Reset, but uses ctx for the request.
+func (manager *Manager) ResetContext(ctx context.Context, resetType ResetType) error {
 	if len(manager.SupportedResetTypes) == 0 {
 		// reset directly without reset type. HPE server has the behavior
 		type temp struct {
@@ -439,11 +445,7 @@ func (manager *Manager) Reset(resetType ResetType) error {
 			Action: "Manager.Reset",
 		}
 
-		resp, err := manager.Client.Post(manager.resetTarget, t)
-		if err == nil {
-			defer resp.Body.Close()
-		}
-		return err
+		return common.PostAction(ctx, &manager.Entity, manager.resetTarget, t)
 	}
 	// Make sure the requested reset type is supported by the manager.
 	valid := false
@@ -466,11 +468,7 @@ func (manager *Manager) Reset(resetType ResetType) error {
 		ResetType: resetType,
 	}
 
-	resp, err := manager.Client.Post(manager.resetTarget, t)
-	if err == nil {
-		defer resp.Body.Close()
-	}
-	return err
+	return common.PostAction(ctx, &manager.Entity, manager.resetTarget, t)
 }
 
 // EthernetInterfaces get this system's ethernet interfaces.
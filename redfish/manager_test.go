@@ -363,6 +363,7 @@ func TestManagerUpdate(t *testing.T) {
 
 	result.AutoDSTEnabled = false
 	result.DateTimeLocalOffset = "+05:00"
+	result.CommandShell.ServiceEnabled = !result.CommandShell.ServiceEnabled
 	err = result.Update()
 
 	if err != nil {
@@ -378,4 +379,8 @@ func TestManagerUpdate(t *testing.T) {
 	if !strings.Contains(calls[0].Payload, "DateTimeLocalOffset:+05:00") {
 		t.Errorf("Unexpected DateTimeLocalOffset update payload: %s", calls[0].Payload)
 	}
+
+	if !strings.Contains(calls[0].Payload, "CommandShell:map[ServiceEnabled:") {
+		t.Errorf("Unexpected CommandShell update payload: %s", calls[0].Payload)
+	}
 }
@@ -5,6 +5,7 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 	"reflect"
 
@@ -147,50 +148,48 @@ func (manageraccount *ManagerAccount) Update() error {
 
 // GetManagerAccount will get a ManagerAccount instance from the service.
 func GetManagerAccount(c common.Client, uri string) (*ManagerAccount, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetManagerAccountContext(context.Background(), c, uri)
+}
+
+// GetManagerAccountContext is the same as GetManagerAccount, but uses ctx for
+// the request.
+func GetManagerAccountContext(ctx context.Context, c common.Client, uri string) (*ManagerAccount, error) {
+	return GetManagerAccountWithOptions(ctx, c, uri, nil)
+}
 
+// GetManagerAccountWithOptions is the same as GetManagerAccountContext, but
+// adds the query options opts to the request.
+func GetManagerAccountWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*ManagerAccount, error) {
 	var manageraccount ManagerAccount
-	err = json.NewDecoder(resp.Body).Decode(&manageraccount)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &manageraccount); err != nil {
 		return nil, err
 	}
-
-	manageraccount.SetClient(c)
 	return &manageraccount, nil
 }
 
 // ListReferencedManagerAccounts gets the collection of ManagerAccount from
 // a provided reference.
-func ListReferencedManagerAccounts(c common.Client, link string) ([]*ManagerAccount, error) { //nolint:dupl
-	var result []*ManagerAccount
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedManagerAccounts(c common.Client, link string) ([]*ManagerAccount, error) {
+	return ListReferencedManagerAccountsContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, manageraccountLink := range links.ItemLinks {
-		manageraccount, err := GetManagerAccount(c, manageraccountLink)
-		if err != nil {
-			collectionError.Failures[manageraccountLink] = err
-		} else {
-			result = append(result, manageraccount)
-		}
-	}
+// ListReferencedManagerAccountsContext is the same as
+// ListReferencedManagerAccounts, but uses ctx for the requests.
+func ListReferencedManagerAccountsContext(ctx context.Context, c common.Client, link string) ([]*ManagerAccount, error) {
+	return ListReferencedManagerAccountsWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedManagerAccountsWithOptions is the same as
+// ListReferencedManagerAccountsContext, but adds the query options opts to the
+// requests.
+func ListReferencedManagerAccountsWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*ManagerAccount, error) { //nolint:dupl
+	var result []*ManagerAccount
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
 
 // SNMPUserInfo is shall contain the SNMP settings for an account.
@@ -5,9 +5,9 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 	"reflect"
-	"sync"
 
 	"github.com/stmcginnis/gofish/common"
 )
@@ -387,72 +387,46 @@ func (memory *Memory) Update() error {
 
 // GetMemory will get a Memory instance from the service.
 func GetMemory(c common.Client, uri string) (*Memory, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetMemoryContext(context.Background(), c, uri)
+}
+
+// GetMemoryContext is the same as GetMemory, but uses ctx for the request.
+func GetMemoryContext(ctx context.Context, c common.Client, uri string) (*Memory, error) {
+	return GetMemoryWithOptions(ctx, c, uri, nil)
+}
 
+// GetMemoryWithOptions is the same as GetMemoryContext, but adds the query
+// options opts to the request.
+func GetMemoryWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*Memory, error) {
 	var memory Memory
-	err = json.NewDecoder(resp.Body).Decode(&memory)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &memory); err != nil {
 		return nil, err
 	}
-
-	memory.SetClient(c)
 	return &memory, nil
 }
 
 // ListReferencedMemorys gets the collection of Memory from
 // a provided reference.
 func ListReferencedMemorys(c common.Client, collectionLink string) ([]*Memory, error) {
-	var result []*Memory
-	if collectionLink == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, collectionLink)
-	if err != nil {
-		return result, err
-	}
-
-	type GetMemoryResult struct {
-		Item  *Memory
-		Link  string
-		Error error
-	}
-
-	ch := make(chan GetMemoryResult)
-	var wg sync.WaitGroup
-
-	collectionError := common.NewCollectionError()
-	for _, memoryLink := range links.ItemLinks {
-		wg.Add(1)
-		go func(link string) {
-			defer wg.Done()
-			memory, err := GetMemory(c, link)
-			ch <- GetMemoryResult{Item: memory, Link: link, Error: err}
-		}(memoryLink)
-	}
+	return ListReferencedMemorysContext(context.Background(), c, collectionLink)
+}
 
-	go func() {
-		wg.Wait()
-		close(ch)
-	}()
-
-	for r := range ch {
-		if r.Error != nil {
-			collectionError.Failures[r.Link] = r.Error
-		} else {
-			result = append(result, r.Item)
-		}
-	}
+// ListReferencedMemorysContext is the same as ListReferencedMemorys, but uses
+// ctx for the requests.
+func ListReferencedMemorysContext(ctx context.Context, c common.Client, collectionLink string) ([]*Memory, error) {
+	return ListReferencedMemorysWithOptions(ctx, c, collectionLink, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedMemorysWithOptions is the same as ListReferencedMemorysContext,
+// but adds the query options opts to the requests.
+func ListReferencedMemorysWithOptions(ctx context.Context, c common.Client, collectionLink string, opts *common.QueryOptions) ([]*Memory, error) {
+	var result []*Memory
+	if collectionLink == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, collectionLink, opts, &result)
+	return result, err
 }
 
 // Assembly gets this memory's assembly.
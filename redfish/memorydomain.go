@@ -5,6 +5,7 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/stmcginnis/gofish/common"
@@ -61,50 +62,48 @@ func (memorydomain *MemoryDomain) UnmarshalJSON(b []byte) error {
 
 // GetMemoryDomain will get a MemoryDomain instance from the service.
 func GetMemoryDomain(c common.Client, uri string) (*MemoryDomain, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetMemoryDomainContext(context.Background(), c, uri)
+}
+
+// GetMemoryDomainContext is the same as GetMemoryDomain, but uses ctx for the
+// request.
+func GetMemoryDomainContext(ctx context.Context, c common.Client, uri string) (*MemoryDomain, error) {
+	return GetMemoryDomainWithOptions(ctx, c, uri, nil)
+}
 
+// GetMemoryDomainWithOptions is the same as GetMemoryDomainContext, but adds
+// the query options opts to the request.
+func GetMemoryDomainWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*MemoryDomain, error) {
 	var memorydomain MemoryDomain
-	err = json.NewDecoder(resp.Body).Decode(&memorydomain)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &memorydomain); err != nil {
 		return nil, err
 	}
-
-	memorydomain.SetClient(c)
 	return &memorydomain, nil
 }
 
 // ListReferencedMemoryDomains gets the collection of MemoryDomain from
 // a provided reference.
-func ListReferencedMemoryDomains(c common.Client, link string) ([]*MemoryDomain, error) { //nolint:dupl
-	var result []*MemoryDomain
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedMemoryDomains(c common.Client, link string) ([]*MemoryDomain, error) {
+	return ListReferencedMemoryDomainsContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, memorydomainLink := range links.ItemLinks {
-		memorydomain, err := GetMemoryDomain(c, memorydomainLink)
-		if err != nil {
-			collectionError.Failures[memorydomainLink] = err
-		} else {
-			result = append(result, memorydomain)
-		}
-	}
+// ListReferencedMemoryDomainsContext is the same as
+// ListReferencedMemoryDomains, but uses ctx for the requests.
+func ListReferencedMemoryDomainsContext(ctx context.Context, c common.Client, link string) ([]*MemoryDomain, error) {
+	return ListReferencedMemoryDomainsWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedMemoryDomainsWithOptions is the same as
+// ListReferencedMemoryDomainsContext, but adds the query options opts to the
+// requests.
+func ListReferencedMemoryDomainsWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*MemoryDomain, error) { //nolint:dupl
+	var result []*MemoryDomain
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
 
 // MemorySet shall represent the interleave sets for a memory chunk.
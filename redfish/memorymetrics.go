@@ -5,7 +5,7 @@
 package redfish
 
 import (
-	"encoding/json"
+	"context"
 
 	"github.com/stmcginnis/gofish/common"
 )
@@ -102,48 +102,46 @@ type MemoryMetrics struct {
 
 // GetMemoryMetrics will get a MemoryMetrics instance from the service.
 func GetMemoryMetrics(c common.Client, uri string) (*MemoryMetrics, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetMemoryMetricsContext(context.Background(), c, uri)
+}
+
+// GetMemoryMetricsContext is the same as GetMemoryMetrics, but uses ctx for the
+// request.
+func GetMemoryMetricsContext(ctx context.Context, c common.Client, uri string) (*MemoryMetrics, error) {
+	return GetMemoryMetricsWithOptions(ctx, c, uri, nil)
+}
 
+// GetMemoryMetricsWithOptions is the same as GetMemoryMetricsContext, but adds
+// the query options opts to the request.
+func GetMemoryMetricsWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*MemoryMetrics, error) {
 	var memorymetrics MemoryMetrics
-	err = json.NewDecoder(resp.Body).Decode(&memorymetrics)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &memorymetrics); err != nil {
 		return nil, err
 	}
-
-	memorymetrics.SetClient(c)
 	return &memorymetrics, nil
 }
 
 // ListReferencedMemoryMetricss gets the collection of MemoryMetrics from
 // a provided reference.
-func ListReferencedMemoryMetricss(c common.Client, link string) ([]*MemoryMetrics, error) { //nolint:dupl
-	var result []*MemoryMetrics
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedMemoryMetricss(c common.Client, link string) ([]*MemoryMetrics, error) {
+	return ListReferencedMemoryMetricssContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, memorymetricsLink := range links.ItemLinks {
-		memorymetrics, err := GetMemoryMetrics(c, memorymetricsLink)
-		if err != nil {
-			collectionError.Failures[memorymetricsLink] = err
-		} else {
-			result = append(result, memorymetrics)
-		}
-	}
+// ListReferencedMemoryMetricssContext is the same as
+// ListReferencedMemoryMetricss, but uses ctx for the requests.
+func ListReferencedMemoryMetricssContext(ctx context.Context, c common.Client, link string) ([]*MemoryMetrics, error) {
+	return ListReferencedMemoryMetricssWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedMemoryMetricssWithOptions is the same as
+// ListReferencedMemoryMetricssContext, but adds the query options opts to the
+// requests.
+func ListReferencedMemoryMetricssWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*MemoryMetrics, error) { //nolint:dupl
+	var result []*MemoryMetrics
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
@@ -5,8 +5,9 @@
 package redfish
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/stmcginnis/gofish/common"
@@ -72,18 +73,20 @@ func GetMessageRegistry(
 	c common.Client,
 	uri string,
 ) (*MessageRegistry, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetMessageRegistryContext(context.Background(), c, uri)
+}
 
+// GetMessageRegistryContext is the same as GetMessageRegistry, but uses ctx for
+// the request.
+func GetMessageRegistryContext(
+	ctx context.Context,
+	c common.Client,
+	uri string,
+) (*MessageRegistry, error) {
 	var t MessageRegistry
-	err = json.NewDecoder(resp.Body).Decode(&t)
-	if err != nil {
+	if err := common.GetObject(ctx, c, uri, &t); err != nil {
 		return nil, err
 	}
-
 	return &t, nil
 }
 
@@ -91,21 +94,31 @@ func GetMessageRegistry(
 func ListReferencedMessageRegistries(
 	c common.Client,
 	link string,
+) ([]*MessageRegistry, error) {
+	return ListReferencedMessageRegistriesContext(context.Background(), c, link)
+}
+
+// ListReferencedMessageRegistriesContext is the same as
+// ListReferencedMessageRegistries, but uses ctx for the requests.
+func ListReferencedMessageRegistriesContext(
+	ctx context.Context,
+	c common.Client,
+	link string,
 ) ([]*MessageRegistry, error) {
 	var result []*MessageRegistry
-	links, err := common.GetCollection(c, link)
+	links, err := common.GetCollectionContext(ctx, c, link)
 	if err != nil {
 		return nil, err
 	}
 
 	for _, sLink := range links.ItemLinks {
-		mrf, err := GetMessageRegistryFile(c, sLink)
+		mrf, err := GetMessageRegistryFileContext(ctx, c, sLink)
 		if err != nil {
 			return nil, err
 		}
 		// get message registry from all location
 		for _, location := range mrf.Location {
-			mr, err := GetMessageRegistry(c, location.URI)
+			mr, err := GetMessageRegistryContext(ctx, c, location.URI)
 			if err != nil {
 				return nil, err
 			}
@@ -122,6 +135,17 @@ func ListReferencedMessageRegistriesByLanguage(
 	c common.Client,
 	link string,
 	language string,
+) ([]*MessageRegistry, error) {
+	return ListReferencedMessageRegistriesByLanguageContext(context.Background(), c, link, language)
+}
+
+// ListReferencedMessageRegistriesByLanguageContext is the same as
+// ListReferencedMessageRegistriesByLanguage, but uses ctx for the requests.
+func ListReferencedMessageRegistriesByLanguageContext(
+	ctx context.Context,
+	c common.Client,
+	link string,
+	language string,
 ) ([]*MessageRegistry, error) {
 	language = strings.TrimSpace(language)
 	if language == "" {
@@ -129,20 +153,20 @@ func ListReferencedMessageRegistriesByLanguage(
 	}
 
 	var result []*MessageRegistry
-	links, err := common.GetCollection(c, link)
+	links, err := common.GetCollectionContext(ctx, c, link)
 	if err != nil {
 		return nil, err
 	}
 
 	for _, sLink := range links.ItemLinks {
-		mrf, err := GetMessageRegistryFile(c, sLink)
+		mrf, err := GetMessageRegistryFileContext(ctx, c, sLink)
 		if err != nil {
 			return nil, err
 		}
 		// get message registry by language
 		for _, location := range mrf.Location {
 			if location.Language == language {
-				mr, err := GetMessageRegistry(c, location.URI)
+				mr, err := GetMessageRegistryContext(ctx, c, location.URI)
 				if err != nil {
 					return nil, err
 				}
@@ -165,6 +189,18 @@ func GetMessageRegistryByLanguage(
 	link string,
 	registry string,
 	language string,
+) (*MessageRegistry, error) {
+	return GetMessageRegistryByLanguageContext(context.Background(), c, link, registry, language)
+}
+
+// GetMessageRegistryByLanguageContext is the same as
+// GetMessageRegistryByLanguage, but uses ctx for the requests.
+func GetMessageRegistryByLanguageContext(
+	ctx context.Context,
+	c common.Client,
+	link string,
+	registry string,
+	language string,
 ) (*MessageRegistry, error) {
 	registry = strings.TrimSpace(registry)
 	if registry == "" {
@@ -176,13 +212,13 @@ func GetMessageRegistryByLanguage(
 		return nil, fmt.Errorf("received empty language")
 	}
 
-	links, err := common.GetCollection(c, link)
+	links, err := common.GetCollectionContext(ctx, c, link)
 	if err != nil {
 		return nil, err
 	}
 
 	for _, sLink := range links.ItemLinks {
-		s, err := GetMessageRegistryFile(c, sLink)
+		s, err := GetMessageRegistryFileContext(ctx, c, sLink)
 		if err != nil {
 			return nil, err
 		}
@@ -191,7 +227,7 @@ func GetMessageRegistryByLanguage(
 			// search for the correct location
 			for _, location := range s.Location {
 				if location.Language == language {
-					return GetMessageRegistry(c, location.URI)
+					return GetMessageRegistryContext(ctx, c, location.URI)
 				}
 			}
 		}
@@ -217,6 +253,18 @@ func GetMessageFromMessageRegistryByLanguage(
 	link string,
 	messageID string,
 	language string,
+) (*MessageRegistryMessage, error) {
+	return GetMessageFromMessageRegistryByLanguageContext(context.Background(), c, link, messageID, language)
+}
+
+// GetMessageFromMessageRegistryByLanguageContext is the same as
+// GetMessageFromMessageRegistryByLanguage, but uses ctx for the requests.
+func GetMessageFromMessageRegistryByLanguageContext(
+	ctx context.Context,
+	c common.Client,
+	link string,
+	messageID string,
+	language string,
 ) (*MessageRegistryMessage, error) {
 	messageID = strings.TrimSpace(messageID)
 	if messageID == "" {
@@ -228,31 +276,81 @@ func GetMessageFromMessageRegistryByLanguage(
 		return nil, fmt.Errorf("received empty language")
 	}
 
-	// split messageID
-	messageIDSplitted := strings.Split(messageID, ".")
+	if _, err := parseMessageID(messageID); err != nil {
+		return nil, err
+	}
+
+	allMessageRegistryByLanguage, err := ListReferencedMessageRegistriesByLanguageContext(ctx, c, link, language)
+	if err == nil {
+		if m, err := messageFromRegistries(allMessageRegistryByLanguage, messageID); err == nil {
+			return m, nil
+		}
+	}
+
+	// Many services do not provide the standard registries, so fall back to
+	// the ones embedded in gofish, which are only available in English.
+	if standard, stdErr := standardRegistriesByLanguage(language); stdErr == nil {
+		if m, stdErr := messageFromRegistries(standard, messageID); stdErr == nil {
+			return m, nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, fmt.Errorf("message not found")
+}
+
+// messageIDParts holds the segments of a MessageId.
+type messageIDParts struct {
+	prefix string
+	major  int
+	minor  int
+	key    string
+}
 
-	// validate messageID
-	if len(messageIDSplitted) != MessageIDSectionLength {
+// parseMessageID splits a MessageId such as "Base.1.8.PropertyValueNotInList"
+// into its segments.
+func parseMessageID(messageID string) (*messageIDParts, error) {
+	parts := strings.Split(strings.TrimSpace(messageID), ".")
+	if len(parts) != MessageIDSectionLength {
 		return nil, fmt.Errorf("received invalid messageID %s", messageID)
 	}
 
-	// get information from the messageID
-	registryPrefix := messageIDSplitted[0]
-	registryMajorVersion := messageIDSplitted[1]
-	registryMinorVersion := messageIDSplitted[2]
-	registryMajorMinorVersion := registryMajorVersion + "." + registryMinorVersion
-	registryMessageKey := messageIDSplitted[3]
+	major, majorErr := strconv.Atoi(parts[1])
+	minor, minorErr := strconv.Atoi(parts[2])
+	if majorErr != nil || minorErr != nil {
+		return nil, fmt.Errorf("received invalid messageID %s", messageID)
+	}
+
+	return &messageIDParts{prefix: parts[0], major: major, minor: minor, key: parts[3]}, nil
+}
 
-	allMessageRegistryByLanguage, err := ListReferencedMessageRegistriesByLanguage(c, link, language)
+// messageFromRegistries looks up messageID in the registries with the same
+// major and minor version as messageID.
+func messageFromRegistries(registries []*MessageRegistry, messageID string) (*MessageRegistryMessage, error) {
+	id, err := parseMessageID(messageID)
 	if err != nil {
 		return nil, err
 	}
-	for _, mr := range allMessageRegistryByLanguage {
-		if mr.RegistryPrefix == registryPrefix &&
-			strings.HasPrefix(mr.RegistryVersion, registryMajorMinorVersion) {
-			if m, ok := mr.Messages[registryMessageKey]; ok {
-				return &m, nil
-			}
+
+	for _, mr := range registries {
+		if mr.RegistryPrefix != id.prefix {
+			continue
+		}
+
+		version := strings.Split(mr.RegistryVersion, ".")
+		if len(version) < 2 { //nolint:gomnd // major and minor version
+			continue
+		}
+		major, majorErr := strconv.Atoi(version[0])
+		minor, minorErr := strconv.Atoi(version[1])
+		if majorErr != nil || minorErr != nil || major != id.major || minor != id.minor {
+			continue
+		}
+
+		if m, ok := mr.Messages[id.key]; ok {
+			return &m, nil
 		}
 	}
 
@@ -5,7 +5,7 @@
 package redfish
 
 import (
-	"encoding/json"
+	"context"
 
 	"github.com/stmcginnis/gofish/common"
 )
@@ -43,18 +43,20 @@ func GetMessageRegistryFile(
 	c common.Client,
 	uri string,
 ) (*MessageRegistryFile, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetMessageRegistryFileContext(context.Background(), c, uri)
+}
 
+// GetMessageRegistryFileContext is the same as GetMessageRegistryFile, but uses
+// ctx for the request.
+func GetMessageRegistryFileContext(
+	ctx context.Context,
+	c common.Client,
+	uri string,
+) (*MessageRegistryFile, error) {
 	var t MessageRegistryFile
-	err = json.NewDecoder(resp.Body).Decode(&t)
-	if err != nil {
+	if err := common.GetObject(ctx, c, uri, &t); err != nil {
 		return nil, err
 	}
-
 	return &t, nil
 }
 
@@ -63,25 +65,17 @@ func ListReferencedMessageRegistryFiles(
 	c common.Client,
 	link string,
 ) ([]*MessageRegistryFile, error) {
-	var result []*MessageRegistryFile
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, sLink := range links.ItemLinks {
-		s, err := GetMessageRegistryFile(c, sLink)
-		if err != nil {
-			collectionError.Failures[sLink] = err
-		} else {
-			result = append(result, s)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
+	return ListReferencedMessageRegistryFilesContext(context.Background(), c, link)
+}
 
-	return result, collectionError
+// ListReferencedMessageRegistryFilesContext is the same as
+// ListReferencedMessageRegistryFiles, but uses ctx for the requests.
+func ListReferencedMessageRegistryFilesContext(
+	ctx context.Context,
+	c common.Client,
+	link string,
+) ([]*MessageRegistryFile, error) {
+	var result []*MessageRegistryFile
+	err := common.GetCollectionObjects(ctx, c, link, &result)
+	return result, err
 }
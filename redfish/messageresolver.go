@@ -0,0 +1,158 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/stmcginnis/gofish/common"
+)
+
+// messageArgPattern matches the %1, %2, ... substitution markers of a message.
+var messageArgPattern = regexp.MustCompile(`%(\d+)`)
+
+// Format returns the message with the %1, %2, ... markers replaced by the
+// corresponding args. Markers without a matching argument are left as is.
+func (m *MessageRegistryMessage) Format(args []string) string {
+	return messageArgPattern.ReplaceAllStringFunc(m.Message, func(marker string) string {
+		i, err := strconv.Atoi(marker[1:])
+		if err != nil || i < 1 || i > len(args) {
+			return marker
+		}
+		return args[i-1]
+	})
+}
+
+// MessageResolver looks up MessageIds in the message registries of a service,
+// and the standard registries embedded in gofish, to format the messages
+// reported in error responses. The registries of each language are only
+// retrieved once.
+type MessageResolver struct {
+	client common.Client
+	link   string
+
+	lock       sync.Mutex
+	registries map[string][]*MessageRegistry
+}
+
+// NewMessageResolver creates a MessageResolver for the message registries in
+// the registries collection at link.
+func NewMessageResolver(c common.Client, link string) *MessageResolver {
+	return &MessageResolver{
+		client:     c,
+		link:       link,
+		registries: make(map[string][]*MessageRegistry),
+	}
+}
+
+// registriesByLanguage returns the registries in language, retrieving them if
+// they are not cached yet. English is used if language is empty. The standard
+// registries embedded in gofish, which are in English, are included after
+// those of the service for English, so they are used for the messages the
+// service does not provide. If the registries of the service cannot be
+// retrieved, only the standard ones are used, and the service ones are
+// retrieved again next time.
+func (r *MessageResolver) registriesByLanguage(ctx context.Context, language string) ([]*MessageRegistry, error) {
+	if language = strings.TrimSpace(language); language == "" {
+		language = "en"
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if registries, ok := r.registries[language]; ok {
+		return registries, nil
+	}
+
+	var registries []*MessageRegistry
+	cache := true
+	if r.link != "" {
+		var err error
+		registries, err = ListReferencedMessageRegistriesByLanguageContext(ctx, r.client, r.link, language)
+		if err != nil {
+			// A service without registries will not get any later.
+			cache = common.IsNotFound(err)
+		}
+	}
+
+	standard, err := standardRegistriesByLanguage(language)
+	if err != nil {
+		return nil, err
+	}
+	registries = append(registries, standard...)
+
+	if cache {
+		r.registries[language] = registries
+	}
+	return registries, nil
+}
+
+// Message gets the registry message for messageID in language, for example
+// "Base.1.8.PropertyValueNotInList" and "en". The version matching rules of
+// GetMessageFromMessageRegistryByLanguage apply.
+func (r *MessageResolver) Message(ctx context.Context, messageID, language string) (*MessageRegistryMessage, error) {
+	registries, err := r.registriesByLanguage(ctx, language)
+	if err != nil {
+		return nil, err
+	}
+	return messageFromRegistries(registries, messageID)
+}
+
+// Resolve returns the messages of err, which should be a *common.Error, with
+// their text, severity and resolution taken from the message registries in
+// language. Messages that cannot be found in the registries are returned as
+// reported by the service. If the error has no extended information, its
+// code and message are returned instead. Nil is returned if err is not a
+// *common.Error.
+func (r *MessageResolver) Resolve(err error, language string) ([]common.ErrExtendedInfo, error) {
+	return r.ResolveContext(context.Background(), err, language)
+}
+
+// ResolveContext is the same as Resolve, but uses ctx for the requests made
+// to retrieve the message registries.
+func (r *MessageResolver) ResolveContext(ctx context.Context, err error, language string) ([]common.ErrExtendedInfo, error) {
+	var redfishErr *common.Error
+	if !errors.As(err, &redfishErr) {
+		return nil, nil
+	}
+
+	infos := redfishErr.ExtendedInfos
+	if len(infos) == 0 && redfishErr.Code != "" {
+		infos = []common.ErrExtendedInfo{{MessageID: redfishErr.Code, Message: redfishErr.Message}}
+	}
+
+	registries, err := r.registriesByLanguage(ctx, language)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]common.ErrExtendedInfo, len(infos))
+	for i := range infos {
+		info := infos[i]
+		m, err := messageFromRegistries(registries, info.MessageID)
+		if err != nil {
+			result[i] = info
+			continue
+		}
+
+		info.Message = m.Format(info.MessageArgs)
+		if m.MessageSeverity != "" {
+			info.Severity = m.MessageSeverity
+		} else if m.Severity != "" {
+			info.Severity = m.Severity
+		}
+		if m.Resolution != "" {
+			info.Resolution = m.Resolution
+		}
+		result[i] = info
+	}
+
+	return result, nil
+}
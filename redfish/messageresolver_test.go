@@ -0,0 +1,137 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stmcginnis/gofish/common"
+)
+
+var registriesBody = `{
+		"@odata.id": "/redfish/v1/Registries",
+		"Members": [{"@odata.id": "/redfish/v1/Registries/MyRegistry"}],
+		"Members@odata.count": 1
+	}`
+
+func registryResponse(body string) *http.Response {
+	return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(body))}
+}
+
+// TestMessageRegistryMessageFormat tests substituting the message arguments.
+func TestMessageRegistryMessageFormat(t *testing.T) {
+	m := MessageRegistryMessage{Message: "%1 of %2 failed, %10 and %3 are kept"}
+	args := []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"}
+
+	if result := m.Format(args); result != "a of b failed, j and c are kept" {
+		t.Errorf("Unexpected message: %s", result)
+	}
+	if result := m.Format(args[:1]); result != "a of %2 failed, %10 and %3 are kept" {
+		t.Errorf("Unexpected message: %s", result)
+	}
+}
+
+// TestMessageResolver tests resolving the messages of an error against the
+// message registries, which should only be retrieved once.
+func TestMessageResolver(t *testing.T) {
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				registryResponse(registriesBody),
+				registryResponse(messageRegistryFileBody),
+				registryResponse(messageRegistryBody),
+			},
+		},
+	}
+	resolver := NewMessageResolver(testClient, "/redfish/v1/Registries")
+
+	body := `{"error": {"code": "MyRegistry.2.2.SecondMessage", "message": "", "@Message.ExtendedInfo": [
+		{"MessageId": "MyRegistry.2.2.ThirdMessage", "MessageArgs": ["Red", "IndicatorLED"], "RelatedProperties": ["#/IndicatorLED"]},
+		{"MessageId": "Other.1.0.Unknown", "Message": "Kept as is", "Severity": "Warning"}
+	]}}`
+	err := common.ConstructError(http.StatusBadRequest, []byte(body))
+
+	infos, resolveErr := resolver.Resolve(err, "en")
+	if resolveErr != nil {
+		t.Fatalf("Unexpected error: %v", resolveErr)
+	}
+	if len(infos) != 2 {
+		t.Fatalf("Expected two messages, got: %#v", infos)
+	}
+
+	if infos[0].Message != "This message has two args: Red and IndicatorLED" ||
+		infos[0].Severity != "Warning" ||
+		infos[0].Resolution != "The resolution for the third message." ||
+		infos[0].RelatedProperties[0] != "#/IndicatorLED" {
+		t.Errorf("Unexpected resolved message: %#v", infos[0])
+	}
+	if infos[1].Message != "Kept as is" || infos[1].Severity != "Warning" {
+		t.Errorf("Unknown messages should be kept as is: %#v", infos[1])
+	}
+
+	// The registries are cached, and the code is resolved if there is no
+	// extended information.
+	body = `{"error": {"code": "MyRegistry.2.2.SecondMessage", "message": "Failed"}}`
+	infos, resolveErr = resolver.Resolve(common.ConstructError(http.StatusBadRequest, []byte(body)), "")
+	if resolveErr != nil {
+		t.Fatalf("Unexpected error: %v", resolveErr)
+	}
+	if len(infos) != 1 || infos[0].Message != "This message has no args." || infos[0].Severity != "Critical" {
+		t.Errorf("Unexpected resolved message: %#v", infos)
+	}
+
+	if calls := testClient.CapturedCalls(); len(calls) != 3 {
+		t.Errorf("Expected the registries to be retrieved once, got: %v", calls)
+	}
+
+	if infos, _ := resolver.Resolve(io.EOF, "en"); infos != nil {
+		t.Errorf("Expected no messages for other errors, got: %v", infos)
+	}
+}
+
+// TestMessageResolverFallback tests using the standard registries when the
+// service registries cannot be retrieved, and only for English.
+func TestMessageResolverFallback(t *testing.T) {
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				&http.Response{StatusCode: http.StatusInternalServerError, Body: io.NopCloser(strings.NewReader("{}"))},
+				&http.Response{StatusCode: http.StatusInternalServerError, Body: io.NopCloser(strings.NewReader("{}"))},
+				&http.Response{StatusCode: http.StatusInternalServerError, Body: io.NopCloser(strings.NewReader("{}"))},
+			},
+		},
+	}
+	resolver := NewMessageResolver(testClient, "/redfish/v1/Registries")
+
+	body := `{"error": {"code": "Base.1.15.PropertyNotWritable", "message": "Not writable", "@Message.ExtendedInfo": [
+		{"MessageId": "Base.1.15.PropertyNotWritable", "Message": "Not writable", "MessageArgs": ["SKU"]}
+	]}}`
+	err := common.ConstructError(http.StatusBadRequest, []byte(body))
+
+	for i := 0; i < 2; i++ {
+		infos, resolveErr := resolver.Resolve(err, "en-US")
+		if resolveErr != nil {
+			t.Fatalf("Unexpected error: %v", resolveErr)
+		}
+		if len(infos) != 1 || infos[0].Message != "The property SKU is a read-only property and cannot be assigned a value." {
+			t.Errorf("Expected the standard message, got: %#v", infos)
+		}
+	}
+
+	infos, resolveErr := resolver.Resolve(err, "fr")
+	if resolveErr != nil {
+		t.Fatalf("Unexpected error: %v", resolveErr)
+	}
+	if len(infos) != 1 || infos[0].Message != "Not writable" {
+		t.Errorf("Expected the English registries not to be used, got: %#v", infos)
+	}
+
+	if calls := testClient.CapturedCalls(); len(calls) != 3 {
+		t.Errorf("Expected the failed registries not to be cached, got: %v", calls)
+	}
+}
@@ -5,6 +5,7 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/stmcginnis/gofish/common"
@@ -238,45 +239,43 @@ func (networkadapter *NetworkAdapter) UnmarshalJSON(b []byte) error {
 
 // GetNetworkAdapter will get a NetworkAdapter instance from the Redfish service.
 func GetNetworkAdapter(c common.Client, uri string) (*NetworkAdapter, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetNetworkAdapterContext(context.Background(), c, uri)
+}
+
+// GetNetworkAdapterContext is the same as GetNetworkAdapter, but uses ctx for
+// the request.
+func GetNetworkAdapterContext(ctx context.Context, c common.Client, uri string) (*NetworkAdapter, error) {
+	return GetNetworkAdapterWithOptions(ctx, c, uri, nil)
+}
 
+// GetNetworkAdapterWithOptions is the same as GetNetworkAdapterContext, but
+// adds the query options opts to the request.
+func GetNetworkAdapterWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*NetworkAdapter, error) {
 	var networkAdapter NetworkAdapter
-	err = json.NewDecoder(resp.Body).Decode(&networkAdapter)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &networkAdapter); err != nil {
 		return nil, err
 	}
-
-	networkAdapter.SetClient(c)
 	return &networkAdapter, nil
 }
 
 // ListReferencedNetworkAdapter gets the collection of Chassis from a provided reference.
 func ListReferencedNetworkAdapter(c common.Client, link string) ([]*NetworkAdapter, error) {
-	var result []*NetworkAdapter
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, networkAdapterLink := range links.ItemLinks {
-		networkAdapter, err := GetNetworkAdapter(c, networkAdapterLink)
-		if err != nil {
-			collectionError.Failures[networkAdapterLink] = err
-		} else {
-			result = append(result, networkAdapter)
-		}
-	}
+	return ListReferencedNetworkAdapterContext(context.Background(), c, link)
+}
 
-	if collectionError.Empty() {
-		return result, nil
-	}
+// ListReferencedNetworkAdapterContext is the same as
+// ListReferencedNetworkAdapter, but uses ctx for the requests.
+func ListReferencedNetworkAdapterContext(ctx context.Context, c common.Client, link string) ([]*NetworkAdapter, error) {
+	return ListReferencedNetworkAdapterWithOptions(ctx, c, link, nil)
+}
 
-	return result, collectionError
+// ListReferencedNetworkAdapterWithOptions is the same as
+// ListReferencedNetworkAdapterContext, but adds the query options opts to the
+// requests.
+func ListReferencedNetworkAdapterWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*NetworkAdapter, error) {
+	var result []*NetworkAdapter
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
 
 // Assembly gets this adapter's assembly.
@@ -300,9 +299,5 @@ func (networkadapter *NetworkAdapter) NetworkPorts() ([]*NetworkPort, error) {
 // ResetSettingsToDefault shall perform a reset of all active and pending
 // settings back to factory default settings upon reset of the network adapter.
 func (networkadapter *NetworkAdapter) ResetSettingsToDefault() error {
-	resp, err := networkadapter.Client.Post(networkadapter.resetSettingsToDefaultTarget, nil)
-	if err == nil {
-		defer resp.Body.Close()
-	}
-	return err
+	return common.PostAction(context.Background(), &networkadapter.Entity, networkadapter.resetSettingsToDefaultTarget, nil)
 }
@@ -5,6 +5,7 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 	"reflect"
 
@@ -350,50 +351,49 @@ func (networkdevicefunction *NetworkDeviceFunction) Update() error {
 
 // GetNetworkDeviceFunction will get a NetworkDeviceFunction instance from the service.
 func GetNetworkDeviceFunction(c common.Client, uri string) (*NetworkDeviceFunction, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetNetworkDeviceFunctionContext(context.Background(), c, uri)
+}
+
+// GetNetworkDeviceFunctionContext is the same as GetNetworkDeviceFunction, but
+// uses ctx for the request.
+func GetNetworkDeviceFunctionContext(ctx context.Context, c common.Client, uri string) (*NetworkDeviceFunction, error) {
+	return GetNetworkDeviceFunctionWithOptions(ctx, c, uri, nil)
+}
 
+// GetNetworkDeviceFunctionWithOptions is the same as
+// GetNetworkDeviceFunctionContext, but adds the query options opts to the
+// request.
+func GetNetworkDeviceFunctionWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*NetworkDeviceFunction, error) {
 	var networkdevicefunction NetworkDeviceFunction
-	err = json.NewDecoder(resp.Body).Decode(&networkdevicefunction)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &networkdevicefunction); err != nil {
 		return nil, err
 	}
-
-	networkdevicefunction.SetClient(c)
 	return &networkdevicefunction, nil
 }
 
 // ListReferencedNetworkDeviceFunctions gets the collection of NetworkDeviceFunction from
 // a provided reference.
-func ListReferencedNetworkDeviceFunctions(c common.Client, link string) ([]*NetworkDeviceFunction, error) { //nolint:dupl
-	var result []*NetworkDeviceFunction
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedNetworkDeviceFunctions(c common.Client, link string) ([]*NetworkDeviceFunction, error) {
+	return ListReferencedNetworkDeviceFunctionsContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, networkdevicefunctionLink := range links.ItemLinks {
-		networkdevicefunction, err := GetNetworkDeviceFunction(c, networkdevicefunctionLink)
-		if err != nil {
-			collectionError.Failures[networkdevicefunctionLink] = err
-		} else {
-			result = append(result, networkdevicefunction)
-		}
-	}
+// ListReferencedNetworkDeviceFunctionsContext is the same as
+// ListReferencedNetworkDeviceFunctions, but uses ctx for the requests.
+func ListReferencedNetworkDeviceFunctionsContext(ctx context.Context, c common.Client, link string) ([]*NetworkDeviceFunction, error) {
+	return ListReferencedNetworkDeviceFunctionsWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedNetworkDeviceFunctionsWithOptions is the same as
+// ListReferencedNetworkDeviceFunctionsContext, but adds the query options opts
+// to the requests.
+func ListReferencedNetworkDeviceFunctionsWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*NetworkDeviceFunction, error) { //nolint:dupl
+	var result []*NetworkDeviceFunction
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
 
 // ISCSIBoot shall describe the iSCSI boot capabilities, status, and
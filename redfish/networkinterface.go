@@ -5,6 +5,7 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/stmcginnis/gofish/common"
@@ -69,50 +70,48 @@ func (networkinterface *NetworkInterface) UnmarshalJSON(b []byte) error {
 
 // GetNetworkInterface will get a NetworkInterface instance from the service.
 func GetNetworkInterface(c common.Client, uri string) (*NetworkInterface, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetNetworkInterfaceContext(context.Background(), c, uri)
+}
+
+// GetNetworkInterfaceContext is the same as GetNetworkInterface, but uses ctx
+// for the request.
+func GetNetworkInterfaceContext(ctx context.Context, c common.Client, uri string) (*NetworkInterface, error) {
+	return GetNetworkInterfaceWithOptions(ctx, c, uri, nil)
+}
 
+// GetNetworkInterfaceWithOptions is the same as GetNetworkInterfaceContext, but
+// adds the query options opts to the request.
+func GetNetworkInterfaceWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*NetworkInterface, error) {
 	var networkinterface NetworkInterface
-	err = json.NewDecoder(resp.Body).Decode(&networkinterface)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &networkinterface); err != nil {
 		return nil, err
 	}
-
-	networkinterface.SetClient(c)
 	return &networkinterface, nil
 }
 
 // ListReferencedNetworkInterfaces gets the collection of NetworkInterface from
 // a provided reference.
-func ListReferencedNetworkInterfaces(c common.Client, link string) ([]*NetworkInterface, error) { //nolint:dupl
-	var result []*NetworkInterface
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedNetworkInterfaces(c common.Client, link string) ([]*NetworkInterface, error) {
+	return ListReferencedNetworkInterfacesContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, networkinterfaceLink := range links.ItemLinks {
-		networkinterface, err := GetNetworkInterface(c, networkinterfaceLink)
-		if err != nil {
-			collectionError.Failures[networkinterfaceLink] = err
-		} else {
-			result = append(result, networkinterface)
-		}
-	}
+// ListReferencedNetworkInterfacesContext is the same as
+// ListReferencedNetworkInterfaces, but uses ctx for the requests.
+func ListReferencedNetworkInterfacesContext(ctx context.Context, c common.Client, link string) ([]*NetworkInterface, error) {
+	return ListReferencedNetworkInterfacesWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedNetworkInterfacesWithOptions is the same as
+// ListReferencedNetworkInterfacesContext, but adds the query options opts to
+// the requests.
+func ListReferencedNetworkInterfacesWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*NetworkInterface, error) { //nolint:dupl
+	var result []*NetworkInterface
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
 
 // NetworkAdapter gets the NetworkAdapter for this interface.
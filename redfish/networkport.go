@@ -5,6 +5,7 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 	"reflect"
 
@@ -249,50 +250,48 @@ func (networkport *NetworkPort) Update() error {
 
 // GetNetworkPort will get a NetworkPort instance from the service.
 func GetNetworkPort(c common.Client, uri string) (*NetworkPort, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetNetworkPortContext(context.Background(), c, uri)
+}
+
+// GetNetworkPortContext is the same as GetNetworkPort, but uses ctx for the
+// request.
+func GetNetworkPortContext(ctx context.Context, c common.Client, uri string) (*NetworkPort, error) {
+	return GetNetworkPortWithOptions(ctx, c, uri, nil)
+}
 
+// GetNetworkPortWithOptions is the same as GetNetworkPortContext, but adds the
+// query options opts to the request.
+func GetNetworkPortWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*NetworkPort, error) {
 	var networkport NetworkPort
-	err = json.NewDecoder(resp.Body).Decode(&networkport)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &networkport); err != nil {
 		return nil, err
 	}
-
-	networkport.SetClient(c)
 	return &networkport, nil
 }
 
 // ListReferencedNetworkPorts gets the collection of NetworkPort from
 // a provided reference.
-func ListReferencedNetworkPorts(c common.Client, link string) ([]*NetworkPort, error) { //nolint:dupl
-	var result []*NetworkPort
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedNetworkPorts(c common.Client, link string) ([]*NetworkPort, error) {
+	return ListReferencedNetworkPortsContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, networkportLink := range links.ItemLinks {
-		networkport, err := GetNetworkPort(c, networkportLink)
-		if err != nil {
-			collectionError.Failures[networkportLink] = err
-		} else {
-			result = append(result, networkport)
-		}
-	}
+// ListReferencedNetworkPortsContext is the same as ListReferencedNetworkPorts,
+// but uses ctx for the requests.
+func ListReferencedNetworkPortsContext(ctx context.Context, c common.Client, link string) ([]*NetworkPort, error) {
+	return ListReferencedNetworkPortsWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedNetworkPortsWithOptions is the same as
+// ListReferencedNetworkPortsContext, but adds the query options opts to the
+// requests.
+func ListReferencedNetworkPortsWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*NetworkPort, error) { //nolint:dupl
+	var result []*NetworkPort
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
 
 // SupportedLinkCapabilities shall describe the static capabilities of an
@@ -5,6 +5,7 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 	"reflect"
 
@@ -149,50 +150,48 @@ func (pciedevice *PCIeDevice) Update() error {
 
 // GetPCIeDevice will get a PCIeDevice instance from the service.
 func GetPCIeDevice(c common.Client, uri string) (*PCIeDevice, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetPCIeDeviceContext(context.Background(), c, uri)
+}
 
+// GetPCIeDeviceContext is the same as GetPCIeDevice, but uses ctx for the
+// request.
+func GetPCIeDeviceContext(ctx context.Context, c common.Client, uri string) (*PCIeDevice, error) {
+	return GetPCIeDeviceWithOptions(ctx, c, uri, nil)
+}
+
+// GetPCIeDeviceWithOptions is the same as GetPCIeDeviceContext, but adds the
+// query options opts to the request.
+func GetPCIeDeviceWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*PCIeDevice, error) {
 	var pciedevice PCIeDevice
-	err = json.NewDecoder(resp.Body).Decode(&pciedevice)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &pciedevice); err != nil {
 		return nil, err
 	}
-
-	pciedevice.SetClient(c)
 	return &pciedevice, nil
 }
 
 // ListReferencedPCIeDevices gets the collection of PCIeDevice from
 // a provided reference.
-func ListReferencedPCIeDevices(c common.Client, link string) ([]*PCIeDevice, error) { //nolint:dupl
-	var result []*PCIeDevice
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedPCIeDevices(c common.Client, link string) ([]*PCIeDevice, error) {
+	return ListReferencedPCIeDevicesContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, pciedeviceLink := range links.ItemLinks {
-		pciedevice, err := GetPCIeDevice(c, pciedeviceLink)
-		if err != nil {
-			collectionError.Failures[pciedeviceLink] = err
-		} else {
-			result = append(result, pciedevice)
-		}
-	}
+// ListReferencedPCIeDevicesContext is the same as ListReferencedPCIeDevices,
+// but uses ctx for the requests.
+func ListReferencedPCIeDevicesContext(ctx context.Context, c common.Client, link string) ([]*PCIeDevice, error) {
+	return ListReferencedPCIeDevicesWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedPCIeDevicesWithOptions is the same as
+// ListReferencedPCIeDevicesContext, but adds the query options opts to the
+// requests.
+func ListReferencedPCIeDevicesWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*PCIeDevice, error) { //nolint:dupl
+	var result []*PCIeDevice
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
 
 // PCIeInterface properties shall be the definition for a PCIe Interface for a
@@ -220,41 +219,13 @@ func (pciedevice *PCIeDevice) Assembly() (*Assembly, error) {
 // Chassis gets the chassis in which the PCIe device is contained.
 func (pciedevice *PCIeDevice) Chassis() ([]*Chassis, error) {
 	var result []*Chassis
-
-	collectionError := common.NewCollectionError()
-	for _, chassisLink := range pciedevice.chassis {
-		chassis, err := GetChassis(pciedevice.Client, chassisLink)
-		if err != nil {
-			collectionError.Failures[chassisLink] = err
-		} else {
-			result = append(result, chassis)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	err := common.GetObjects(context.Background(), pciedevice.Client, pciedevice.chassis, &result)
+	return result, err
 }
 
 // PCIeFunctions get the PCIe functions that this device exposes.
 func (pciedevice *PCIeDevice) PCIeFunctions() ([]*PCIeDevice, error) {
 	var result []*PCIeDevice
-
-	collectionError := common.NewCollectionError()
-	for _, funcLink := range pciedevice.pcieFunctions {
-		pciFunction, err := GetPCIeDevice(pciedevice.Client, funcLink)
-		if err != nil {
-			collectionError.Failures[funcLink] = err
-		} else {
-			result = append(result, pciFunction)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	err := common.GetObjects(context.Background(), pciedevice.Client, pciedevice.pcieFunctions, &result)
+	return result, err
 }
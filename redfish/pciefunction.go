@@ -5,6 +5,7 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/stmcginnis/gofish/common"
@@ -179,113 +180,69 @@ func (pciefunction *PCIeFunction) UnmarshalJSON(b []byte) error {
 
 // GetPCIeFunction will get a PCIeFunction instance from the service.
 func GetPCIeFunction(c common.Client, uri string) (*PCIeFunction, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetPCIeFunctionContext(context.Background(), c, uri)
+}
+
+// GetPCIeFunctionContext is the same as GetPCIeFunction, but uses ctx for the
+// request.
+func GetPCIeFunctionContext(ctx context.Context, c common.Client, uri string) (*PCIeFunction, error) {
+	return GetPCIeFunctionWithOptions(ctx, c, uri, nil)
+}
 
+// GetPCIeFunctionWithOptions is the same as GetPCIeFunctionContext, but adds
+// the query options opts to the request.
+func GetPCIeFunctionWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*PCIeFunction, error) {
 	var pciefunction PCIeFunction
-	err = json.NewDecoder(resp.Body).Decode(&pciefunction)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &pciefunction); err != nil {
 		return nil, err
 	}
-
-	pciefunction.SetClient(c)
 	return &pciefunction, nil
 }
 
 // ListReferencedPCIeFunctions gets the collection of PCIeFunction from
 // a provided reference.
-func ListReferencedPCIeFunctions(c common.Client, link string) ([]*PCIeFunction, error) { //nolint:dupl
-	var result []*PCIeFunction
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedPCIeFunctions(c common.Client, link string) ([]*PCIeFunction, error) {
+	return ListReferencedPCIeFunctionsContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, pciefunctionLink := range links.ItemLinks {
-		pciefunction, err := GetPCIeFunction(c, pciefunctionLink)
-		if err != nil {
-			collectionError.Failures[pciefunctionLink] = err
-		} else {
-			result = append(result, pciefunction)
-		}
-	}
+// ListReferencedPCIeFunctionsContext is the same as
+// ListReferencedPCIeFunctions, but uses ctx for the requests.
+func ListReferencedPCIeFunctionsContext(ctx context.Context, c common.Client, link string) ([]*PCIeFunction, error) {
+	return ListReferencedPCIeFunctionsWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedPCIeFunctionsWithOptions is the same as
+// ListReferencedPCIeFunctionsContext, but adds the query options opts to the
+// requests.
+func ListReferencedPCIeFunctionsWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*PCIeFunction, error) { //nolint:dupl
+	var result []*PCIeFunction
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
 
 // Drives gets the PCIe function's drives.
 func (pciefunction *PCIeFunction) Drives() ([]*Drive, error) {
 	var result []*Drive
-
-	collectionError := common.NewCollectionError()
-	for _, driveLink := range pciefunction.drives {
-		drive, err := GetDrive(pciefunction.Client, driveLink)
-		if err != nil {
-			collectionError.Failures[driveLink] = err
-		} else {
-			result = append(result, drive)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	err := common.GetObjects(context.Background(), pciefunction.Client, pciefunction.drives, &result)
+	return result, err
 }
 
 // EthernetInterfaces gets the PCIe function's ethernet interfaces.
 func (pciefunction *PCIeFunction) EthernetInterfaces() ([]*EthernetInterface, error) {
 	var result []*EthernetInterface
-
-	collectionError := common.NewCollectionError()
-	for _, ethLink := range pciefunction.ethernetInterfaces {
-		eth, err := GetEthernetInterface(pciefunction.Client, ethLink)
-		if err != nil {
-			collectionError.Failures[ethLink] = err
-		} else {
-			result = append(result, eth)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	err := common.GetObjects(context.Background(), pciefunction.Client, pciefunction.ethernetInterfaces, &result)
+	return result, err
 }
 
 // NetworkDeviceFunctions gets the PCIe function's ethernet interfaces.
 func (pciefunction *PCIeFunction) NetworkDeviceFunctions() ([]*NetworkDeviceFunction, error) {
 	var result []*NetworkDeviceFunction
-
-	collectionError := common.NewCollectionError()
-	for _, netLink := range pciefunction.networkDeviceFunctions {
-		net, err := GetNetworkDeviceFunction(pciefunction.Client, netLink)
-		if err != nil {
-			collectionError.Failures[netLink] = err
-		} else {
-			result = append(result, net)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	err := common.GetObjects(context.Background(), pciefunction.Client, pciefunction.networkDeviceFunctions, &result)
+	return result, err
 }
 
 // PCIeDevice gets the associated PCIe device for this function.
@@ -299,20 +256,6 @@ func (pciefunction *PCIeFunction) PCIeDevice() (*PCIeDevice, error) {
 // StorageControllers gets the associated storage controllers.
 func (pciefunction *PCIeFunction) StorageControllers() ([]*StorageController, error) {
 	var result []*StorageController
-
-	collectionError := common.NewCollectionError()
-	for _, scLink := range pciefunction.storageControllers {
-		sc, err := GetStorageController(pciefunction.Client, scLink)
-		if err != nil {
-			collectionError.Failures[scLink] = err
-		} else {
-			result = append(result, sc)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	err := common.GetObjects(context.Background(), pciefunction.Client, pciefunction.storageControllers, &result)
+	return result, err
 }
@@ -5,6 +5,7 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 	"reflect"
 	"strconv"
@@ -147,50 +148,46 @@ type Power struct {
 
 // GetPower will get a Power instance from the service.
 func GetPower(c common.Client, uri string) (*Power, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetPowerContext(context.Background(), c, uri)
+}
+
+// GetPowerContext is the same as GetPower, but uses ctx for the request.
+func GetPowerContext(ctx context.Context, c common.Client, uri string) (*Power, error) {
+	return GetPowerWithOptions(ctx, c, uri, nil)
+}
 
+// GetPowerWithOptions is the same as GetPowerContext, but adds the query
+// options opts to the request.
+func GetPowerWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*Power, error) {
 	var power Power
-	err = json.NewDecoder(resp.Body).Decode(&power)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &power); err != nil {
 		return nil, err
 	}
-
-	power.SetClient(c)
 	return &power, nil
 }
 
 // ListReferencedPowers gets the collection of Power from
 // a provided reference.
-func ListReferencedPowers(c common.Client, link string) ([]*Power, error) { //nolint:dupl
-	var result []*Power
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedPowers(c common.Client, link string) ([]*Power, error) {
+	return ListReferencedPowersContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, powerLink := range links.ItemLinks {
-		power, err := GetPower(c, powerLink)
-		if err != nil {
-			collectionError.Failures[powerLink] = err
-		} else {
-			result = append(result, power)
-		}
-	}
+// ListReferencedPowersContext is the same as ListReferencedPowers, but uses ctx
+// for the requests.
+func ListReferencedPowersContext(ctx context.Context, c common.Client, link string) ([]*Power, error) {
+	return ListReferencedPowersWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedPowersWithOptions is the same as ListReferencedPowersContext,
+// but adds the query options opts to the requests.
+func ListReferencedPowersWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*Power, error) { //nolint:dupl
+	var result []*Power
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
 
 // PowerControl is
@@ -5,6 +5,7 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 	"strconv"
 
@@ -437,45 +438,43 @@ func (processor *Processor) UnmarshalJSON(b []byte) error {
 
 // GetProcessor will get a Processor instance from the system
 func GetProcessor(c common.Client, uri string) (*Processor, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetProcessorContext(context.Background(), c, uri)
+}
 
+// GetProcessorContext is the same as GetProcessor, but uses ctx for the
+// request.
+func GetProcessorContext(ctx context.Context, c common.Client, uri string) (*Processor, error) {
+	return GetProcessorWithOptions(ctx, c, uri, nil)
+}
+
+// GetProcessorWithOptions is the same as GetProcessorContext, but adds the
+// query options opts to the request.
+func GetProcessorWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*Processor, error) {
 	var processor Processor
-	err = json.NewDecoder(resp.Body).Decode(&processor)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &processor); err != nil {
 		return nil, err
 	}
-
-	processor.SetClient(c)
 	return &processor, nil
 }
 
 // ListReferencedProcessors gets the collection of Processor from a provided reference.
 func ListReferencedProcessors(c common.Client, link string) ([]*Processor, error) {
-	var result []*Processor
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, processorLink := range links.ItemLinks {
-		processor, err := GetProcessor(c, processorLink)
-		if err != nil {
-			collectionError.Failures[processorLink] = err
-		} else {
-			result = append(result, processor)
-		}
-	}
+	return ListReferencedProcessorsContext(context.Background(), c, link)
+}
 
-	if collectionError.Empty() {
-		return result, nil
-	}
+// ListReferencedProcessorsContext is the same as ListReferencedProcessors, but
+// uses ctx for the requests.
+func ListReferencedProcessorsContext(ctx context.Context, c common.Client, link string) ([]*Processor, error) {
+	return ListReferencedProcessorsWithOptions(ctx, c, link, nil)
+}
 
-	return result, collectionError
+// ListReferencedProcessorsWithOptions is the same as
+// ListReferencedProcessorsContext, but adds the query options opts to the
+// requests.
+func ListReferencedProcessorsWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*Processor, error) {
+	var result []*Processor
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
 
 // ProcessorID shall contain identification information for a processor.
@@ -5,6 +5,7 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 	"reflect"
 
@@ -115,48 +116,46 @@ func (redundancy *Redundancy) Update() error {
 
 // GetRedundancy will get a Redundancy instance from the service.
 func GetRedundancy(c common.Client, uri string) (*Redundancy, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetRedundancyContext(context.Background(), c, uri)
+}
+
+// GetRedundancyContext is the same as GetRedundancy, but uses ctx for the
+// request.
+func GetRedundancyContext(ctx context.Context, c common.Client, uri string) (*Redundancy, error) {
+	return GetRedundancyWithOptions(ctx, c, uri, nil)
+}
 
+// GetRedundancyWithOptions is the same as GetRedundancyContext, but adds the
+// query options opts to the request.
+func GetRedundancyWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*Redundancy, error) {
 	var redundancy Redundancy
-	err = json.NewDecoder(resp.Body).Decode(&redundancy)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &redundancy); err != nil {
 		return nil, err
 	}
-
-	redundancy.SetClient(c)
 	return &redundancy, nil
 }
 
 // ListReferencedRedundancies gets the collection of Redundancy from
 // a provided reference.
-func ListReferencedRedundancies(c common.Client, link string) ([]*Redundancy, error) { //nolint:dupl
-	var result []*Redundancy
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedRedundancies(c common.Client, link string) ([]*Redundancy, error) {
+	return ListReferencedRedundanciesContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, redundancyLink := range links.ItemLinks {
-		redundancy, err := GetRedundancy(c, redundancyLink)
-		if err != nil {
-			collectionError.Failures[redundancyLink] = err
-		} else {
-			result = append(result, redundancy)
-		}
-	}
+// ListReferencedRedundanciesContext is the same as ListReferencedRedundancies,
+// but uses ctx for the requests.
+func ListReferencedRedundanciesContext(ctx context.Context, c common.Client, link string) ([]*Redundancy, error) {
+	return ListReferencedRedundanciesWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedRedundanciesWithOptions is the same as
+// ListReferencedRedundanciesContext, but adds the query options opts to the
+// requests.
+func ListReferencedRedundanciesWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*Redundancy, error) { //nolint:dupl
+	var result []*Redundancy
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
@@ -5,6 +5,7 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 	"reflect"
 
@@ -107,48 +108,44 @@ func (role *Role) Update() error {
 
 // GetRole will get a Role instance from the service.
 func GetRole(c common.Client, uri string) (*Role, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetRoleContext(context.Background(), c, uri)
+}
+
+// GetRoleContext is the same as GetRole, but uses ctx for the request.
+func GetRoleContext(ctx context.Context, c common.Client, uri string) (*Role, error) {
+	return GetRoleWithOptions(ctx, c, uri, nil)
+}
 
+// GetRoleWithOptions is the same as GetRoleContext, but adds the query options
+// opts to the request.
+func GetRoleWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*Role, error) {
 	var role Role
-	err = json.NewDecoder(resp.Body).Decode(&role)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &role); err != nil {
 		return nil, err
 	}
-
-	role.SetClient(c)
 	return &role, nil
 }
 
 // ListReferencedRoles gets the collection of Role from
 // a provided reference.
-func ListReferencedRoles(c common.Client, link string) ([]*Role, error) { //nolint:dupl
-	var result []*Role
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedRoles(c common.Client, link string) ([]*Role, error) {
+	return ListReferencedRolesContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, roleLink := range links.ItemLinks {
-		role, err := GetRole(c, roleLink)
-		if err != nil {
-			collectionError.Failures[roleLink] = err
-		} else {
-			result = append(result, role)
-		}
-	}
+// ListReferencedRolesContext is the same as ListReferencedRoles, but uses ctx
+// for the requests.
+func ListReferencedRolesContext(ctx context.Context, c common.Client, link string) ([]*Role, error) {
+	return ListReferencedRolesWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedRolesWithOptions is the same as ListReferencedRolesContext, but
+// adds the query options opts to the requests.
+func ListReferencedRolesWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*Role, error) { //nolint:dupl
+	var result []*Role
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
@@ -5,6 +5,7 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 	"reflect"
 
@@ -128,50 +129,48 @@ func (secureboot *SecureBoot) Update() error {
 
 // GetSecureBoot will get a SecureBoot instance from the service.
 func GetSecureBoot(c common.Client, uri string) (*SecureBoot, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetSecureBootContext(context.Background(), c, uri)
+}
+
+// GetSecureBootContext is the same as GetSecureBoot, but uses ctx for the
+// request.
+func GetSecureBootContext(ctx context.Context, c common.Client, uri string) (*SecureBoot, error) {
+	return GetSecureBootWithOptions(ctx, c, uri, nil)
+}
 
+// GetSecureBootWithOptions is the same as GetSecureBootContext, but adds the
+// query options opts to the request.
+func GetSecureBootWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*SecureBoot, error) {
 	var secureboot SecureBoot
-	err = json.NewDecoder(resp.Body).Decode(&secureboot)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &secureboot); err != nil {
 		return nil, err
 	}
-
-	secureboot.SetClient(c)
 	return &secureboot, nil
 }
 
 // ListReferencedSecureBoots gets the collection of SecureBoot from
 // a provided reference.
-func ListReferencedSecureBoots(c common.Client, link string) ([]*SecureBoot, error) { //nolint:dupl
-	var result []*SecureBoot
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedSecureBoots(c common.Client, link string) ([]*SecureBoot, error) {
+	return ListReferencedSecureBootsContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, securebootLink := range links.ItemLinks {
-		secureboot, err := GetSecureBoot(c, securebootLink)
-		if err != nil {
-			collectionError.Failures[securebootLink] = err
-		} else {
-			result = append(result, secureboot)
-		}
-	}
+// ListReferencedSecureBootsContext is the same as ListReferencedSecureBoots,
+// but uses ctx for the requests.
+func ListReferencedSecureBootsContext(ctx context.Context, c common.Client, link string) ([]*SecureBoot, error) {
+	return ListReferencedSecureBootsWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedSecureBootsWithOptions is the same as
+// ListReferencedSecureBootsContext, but adds the query options opts to the
+// requests.
+func ListReferencedSecureBootsWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*SecureBoot, error) { //nolint:dupl
+	var result []*SecureBoot
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
 
 // ResetKeys shall perform a reset of the Secure Boot key databases. The
@@ -185,9 +184,5 @@ func (secureboot *SecureBoot) ResetKeys(resetType ResetKeysType) error {
 	}
 	t := temp{ResetKeysType: resetType}
 
-	resp, err := secureboot.Client.Post(secureboot.resetKeysTarget, t)
-	if err == nil {
-		defer resp.Body.Close()
-	}
-	return err
+	return common.PostAction(context.Background(), &secureboot.Entity, secureboot.resetKeysTarget, t)
 }
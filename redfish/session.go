@@ -5,7 +5,7 @@
 package redfish
 
 import (
-	"encoding/json"
+	"context"
 	"net/url"
 
 	"github.com/stmcginnis/gofish/common"
@@ -64,13 +64,16 @@ type Session struct {
 	UserName string
 }
 
-// AuthToken contains the authentication and session information.
+// AuthToken contains the authentication and session information. Token is
+// sent as an OAuth 2.0 bearer token if BearerAuth is set, or else as the
+// X-Auth-Token of the session.
 type AuthToken struct {
-	Token     string
-	Session   string
-	Username  string
-	Password  string
-	BasicAuth bool
+	Token      string
+	Session    string
+	Username   string
+	Password   string
+	BasicAuth  bool
+	BearerAuth bool
 }
 
 type authPayload struct {
@@ -114,42 +117,40 @@ func DeleteSession(c common.Client, sessionURL string) (err error) {
 
 // GetSession will get a Session instance from the Redfish service.
 func GetSession(c common.Client, uri string) (*Session, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetSessionContext(context.Background(), c, uri)
+}
 
+// GetSessionContext is the same as GetSession, but uses ctx for the request.
+func GetSessionContext(ctx context.Context, c common.Client, uri string) (*Session, error) {
+	return GetSessionWithOptions(ctx, c, uri, nil)
+}
+
+// GetSessionWithOptions is the same as GetSessionContext, but adds the query
+// options opts to the request.
+func GetSessionWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*Session, error) {
 	var t Session
-	err = json.NewDecoder(resp.Body).Decode(&t)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &t); err != nil {
 		return nil, err
 	}
-
 	return &t, nil
 }
 
 // ListReferencedSessions gets the collection of Sessions
 func ListReferencedSessions(c common.Client, link string) ([]*Session, error) {
-	var result []*Session
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, sLink := range links.ItemLinks {
-		s, err := GetSession(c, sLink)
-		if err != nil {
-			collectionError.Failures[sLink] = err
-		} else {
-			result = append(result, s)
-		}
-	}
+	return ListReferencedSessionsContext(context.Background(), c, link)
+}
 
-	if collectionError.Empty() {
-		return result, nil
-	}
+// ListReferencedSessionsContext is the same as ListReferencedSessions, but uses
+// ctx for the requests.
+func ListReferencedSessionsContext(ctx context.Context, c common.Client, link string) ([]*Session, error) {
+	return ListReferencedSessionsWithOptions(ctx, c, link, nil)
+}
 
-	return result, collectionError
+// ListReferencedSessionsWithOptions is the same as
+// ListReferencedSessionsContext, but adds the query options opts to the
+// requests.
+func ListReferencedSessionsWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*Session, error) {
+	var result []*Session
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
@@ -0,0 +1,124 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+
+	"github.com/stmcginnis/gofish/common"
+)
+
+// ErrNoSessionsCollection is returned when the SessionService does not link
+// to a sessions collection.
+var ErrNoSessionsCollection = errors.New("the session service has no sessions collection")
+
+// SessionService is used to represent the Session Service Properties for a
+// Redfish implementation.
+type SessionService struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// ServiceEnabled shall be a boolean indicating whether this service is
+	// enabled. If this is set to false, the Session Service is disabled and
+	// any attempt to access it will fail. This means new sessions cannot be
+	// created, old sessions cannot be deleted though established sessions
+	// may continue operating.
+	ServiceEnabled bool
+	// SessionTimeout shall be the threshold of time in seconds between
+	// requests on a specific session at which point the session service
+	// shall close the session due to inactivity.
+	SessionTimeout int
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// rawData holds the original serialized JSON so we can compare updates.
+	rawData []byte
+
+	sessions string
+}
+
+// UnmarshalJSON unmarshals a SessionService object from the raw JSON.
+func (sessionservice *SessionService) UnmarshalJSON(b []byte) error {
+	type temp SessionService
+	var t struct {
+		temp
+		Sessions common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*sessionservice = SessionService(t.temp)
+	sessionservice.sessions = string(t.Sessions)
+
+	// This is a read/write object, so we need to save the raw object data for later
+	sessionservice.rawData = b
+
+	return nil
+}
+
+// Update commits updates to this object's properties to the running system.
+func (sessionservice *SessionService) Update() error {
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(SessionService)
+	err := original.UnmarshalJSON(sessionservice.rawData)
+	if err != nil {
+		return err
+	}
+
+	readWriteFields := []string{
+		"ServiceEnabled",
+		"SessionTimeout",
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(sessionservice).Elem()
+
+	return sessionservice.Entity.Update(originalElement, currentElement, readWriteFields)
+}
+
+// Sessions gets the active sessions of the service.
+func (sessionservice *SessionService) Sessions() ([]*Session, error) {
+	return sessionservice.SessionsContext(context.Background())
+}
+
+// SessionsContext is the same as Sessions, but uses ctx for the requests.
+func (sessionservice *SessionService) SessionsContext(ctx context.Context) ([]*Session, error) {
+	if sessionservice.sessions == "" {
+		return nil, ErrNoSessionsCollection
+	}
+	return ListReferencedSessionsContext(ctx, sessionservice.Client, sessionservice.sessions)
+}
+
+// GetSessionService will get a SessionService instance from the service.
+func GetSessionService(c common.Client, uri string) (*SessionService, error) {
+	return GetSessionServiceContext(context.Background(), c, uri)
+}
+
+// GetSessionServiceContext is the same as GetSessionService, but uses ctx for
+// the request.
+func GetSessionServiceContext(ctx context.Context, c common.Client, uri string) (*SessionService, error) {
+	return GetSessionServiceWithOptions(ctx, c, uri, nil)
+}
+
+// GetSessionServiceWithOptions is the same as GetSessionServiceContext, but
+// adds the query options opts to the request.
+func GetSessionServiceWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*SessionService, error) {
+	var sessionService SessionService
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &sessionService); err != nil {
+		return nil, err
+	}
+	return &sessionService, nil
+}
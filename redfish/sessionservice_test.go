@@ -0,0 +1,91 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stmcginnis/gofish/common"
+)
+
+var sessionServiceBody = `{
+		"@odata.type": "#SessionService.v1_1_8.SessionService",
+		"Id": "SessionService",
+		"Name": "Session Service",
+		"Description": "Session Service",
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		},
+		"ServiceEnabled": true,
+		"SessionTimeout": 1800,
+		"Sessions": {
+			"@odata.id": "/redfish/v1/SessionService/Sessions"
+		},
+		"@odata.id": "/redfish/v1/SessionService"
+	}`
+
+// TestSessionService tests the parsing of SessionService objects.
+func TestSessionService(t *testing.T) {
+	var result SessionService
+	err := json.NewDecoder(strings.NewReader(sessionServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "SessionService" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if !result.ServiceEnabled {
+		t.Error("ServiceEnabled should be true")
+	}
+
+	if result.SessionTimeout != 1800 {
+		t.Errorf("Invalid session timeout: %d", result.SessionTimeout)
+	}
+
+	if result.sessions != "/redfish/v1/SessionService/Sessions" {
+		t.Errorf("Invalid Sessions link: %s", result.sessions)
+	}
+}
+
+// TestSessionServiceUpdate tests the Update call.
+func TestSessionServiceUpdate(t *testing.T) {
+	var result SessionService
+	err := json.NewDecoder(strings.NewReader(sessionServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.ServiceEnabled = false
+	result.SessionTimeout = 600
+	err = result.Update()
+
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 1 {
+		t.Errorf("Expected one call to be made, captured: %v", calls)
+	}
+
+	if !strings.Contains(calls[0].Payload, "ServiceEnabled:false") {
+		t.Errorf("Unexpected ServiceEnabled update payload: %s", calls[0].Payload)
+	}
+
+	if !strings.Contains(calls[0].Payload, "SessionTimeout:600") {
+		t.Errorf("Unexpected SessionTimeout update payload: %s", calls[0].Payload)
+	}
+}
@@ -5,6 +5,7 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/stmcginnis/gofish/common"
@@ -80,50 +81,48 @@ func (simplestorage *SimpleStorage) UnmarshalJSON(b []byte) error {
 
 // GetSimpleStorage will get a SimpleStorage instance from the service.
 func GetSimpleStorage(c common.Client, uri string) (*SimpleStorage, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetSimpleStorageContext(context.Background(), c, uri)
+}
+
+// GetSimpleStorageContext is the same as GetSimpleStorage, but uses ctx for the
+// request.
+func GetSimpleStorageContext(ctx context.Context, c common.Client, uri string) (*SimpleStorage, error) {
+	return GetSimpleStorageWithOptions(ctx, c, uri, nil)
+}
 
+// GetSimpleStorageWithOptions is the same as GetSimpleStorageContext, but adds
+// the query options opts to the request.
+func GetSimpleStorageWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*SimpleStorage, error) {
 	var simplestorage SimpleStorage
-	err = json.NewDecoder(resp.Body).Decode(&simplestorage)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &simplestorage); err != nil {
 		return nil, err
 	}
-
-	simplestorage.SetClient(c)
 	return &simplestorage, nil
 }
 
 // ListReferencedSimpleStorages gets the collection of SimpleStorage from
 // a provided reference.
-func ListReferencedSimpleStorages(c common.Client, link string) ([]*SimpleStorage, error) { //nolint:dupl
-	var result []*SimpleStorage
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedSimpleStorages(c common.Client, link string) ([]*SimpleStorage, error) {
+	return ListReferencedSimpleStoragesContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, simplestorageLink := range links.ItemLinks {
-		simplestorage, err := GetSimpleStorage(c, simplestorageLink)
-		if err != nil {
-			collectionError.Failures[simplestorageLink] = err
-		} else {
-			result = append(result, simplestorage)
-		}
-	}
+// ListReferencedSimpleStoragesContext is the same as
+// ListReferencedSimpleStorages, but uses ctx for the requests.
+func ListReferencedSimpleStoragesContext(ctx context.Context, c common.Client, link string) ([]*SimpleStorage, error) {
+	return ListReferencedSimpleStoragesWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedSimpleStoragesWithOptions is the same as
+// ListReferencedSimpleStoragesContext, but adds the query options opts to the
+// requests.
+func ListReferencedSimpleStoragesWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*SimpleStorage, error) { //nolint:dupl
+	var result []*SimpleStorage
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
 
 // Chassis gets the chassis containing this storage service.
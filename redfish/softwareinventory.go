@@ -5,7 +5,7 @@
 package redfish
 
 import (
-	"encoding/json"
+	"context"
 
 	"github.com/stmcginnis/gofish/common"
 )
@@ -63,48 +63,46 @@ type SoftwareInventory struct {
 
 // GetSoftwareInventory will get a SoftwareInventory instance from the service.
 func GetSoftwareInventory(c common.Client, uri string) (*SoftwareInventory, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetSoftwareInventoryContext(context.Background(), c, uri)
+}
+
+// GetSoftwareInventoryContext is the same as GetSoftwareInventory, but uses ctx
+// for the request.
+func GetSoftwareInventoryContext(ctx context.Context, c common.Client, uri string) (*SoftwareInventory, error) {
+	return GetSoftwareInventoryWithOptions(ctx, c, uri, nil)
+}
 
+// GetSoftwareInventoryWithOptions is the same as GetSoftwareInventoryContext,
+// but adds the query options opts to the request.
+func GetSoftwareInventoryWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*SoftwareInventory, error) {
 	var softwareinventory SoftwareInventory
-	err = json.NewDecoder(resp.Body).Decode(&softwareinventory)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &softwareinventory); err != nil {
 		return nil, err
 	}
-
-	softwareinventory.SetClient(c)
 	return &softwareinventory, nil
 }
 
 // ListReferencedSoftwareInventories gets the collection of SoftwareInventory from
 // a provided reference.
-func ListReferencedSoftwareInventories(c common.Client, link string) ([]*SoftwareInventory, error) { //nolint:dupl
-	var result []*SoftwareInventory
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedSoftwareInventories(c common.Client, link string) ([]*SoftwareInventory, error) {
+	return ListReferencedSoftwareInventoriesContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, softwareinventoryLink := range links.ItemLinks {
-		softwareinventory, err := GetSoftwareInventory(c, softwareinventoryLink)
-		if err != nil {
-			collectionError.Failures[softwareinventoryLink] = err
-		} else {
-			result = append(result, softwareinventory)
-		}
-	}
+// ListReferencedSoftwareInventoriesContext is the same as
+// ListReferencedSoftwareInventories, but uses ctx for the requests.
+func ListReferencedSoftwareInventoriesContext(ctx context.Context, c common.Client, link string) ([]*SoftwareInventory, error) {
+	return ListReferencedSoftwareInventoriesWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedSoftwareInventoriesWithOptions is the same as
+// ListReferencedSoftwareInventoriesContext, but adds the query options opts to
+// the requests.
+func ListReferencedSoftwareInventoriesWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*SoftwareInventory, error) { //nolint:dupl
+	var result []*SoftwareInventory
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
@@ -0,0 +1,84 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"embed"
+	"encoding/json"
+	"strings"
+	"sync"
+)
+
+// standardRegistryFiles holds the DMTF standard message registries (DSP8011)
+// used when a service does not provide them. The files are updated with
+// tools/get_registries.sh.
+//
+//go:embed registries/*.json
+var standardRegistryFiles embed.FS
+
+var (
+	standardRegistries     []*MessageRegistry
+	standardRegistriesErr  error
+	standardRegistriesOnce sync.Once
+)
+
+// StandardMessageRegistries returns the DMTF standard message registries,
+// Base, TaskEvent, ResourceEvent, Update and License, embedded in gofish.
+// They are only available in English.
+func StandardMessageRegistries() ([]*MessageRegistry, error) {
+	standardRegistriesOnce.Do(func() {
+		entries, err := standardRegistryFiles.ReadDir("registries")
+		if err != nil {
+			standardRegistriesErr = err
+			return
+		}
+
+		for _, entry := range entries {
+			data, err := standardRegistryFiles.ReadFile("registries/" + entry.Name())
+			if err != nil {
+				standardRegistriesErr = err
+				return
+			}
+
+			var mr MessageRegistry
+			if err := json.Unmarshal(data, &mr); err != nil {
+				standardRegistriesErr = err
+				return
+			}
+			standardRegistries = append(standardRegistries, &mr)
+		}
+	})
+
+	return standardRegistries, standardRegistriesErr
+}
+
+// standardRegistriesByLanguage returns the standard message registries if
+// language is English, such as "en" or "en-US", or none otherwise.
+func standardRegistriesByLanguage(language string) ([]*MessageRegistry, error) {
+	registries, err := StandardMessageRegistries()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*MessageRegistry
+	primary := strings.SplitN(language, "-", 2)[0]
+	for _, mr := range registries {
+		if strings.EqualFold(mr.Language, language) || strings.EqualFold(mr.Language, primary) {
+			result = append(result, mr)
+		}
+	}
+	return result, nil
+}
+
+// StandardMessage gets the message for messageID from the standard message
+// registries. The registry major and minor versions in messageID must match,
+// as with GetMessageFromMessageRegistryByLanguage.
+func StandardMessage(messageID string) (*MessageRegistryMessage, error) {
+	registries, err := StandardMessageRegistries()
+	if err != nil {
+		return nil, err
+	}
+	return messageFromRegistries(registries, messageID)
+}
@@ -0,0 +1,177 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stmcginnis/gofish/common"
+)
+
+// TestStandardMessageRegistries tests loading the embedded registries.
+func TestStandardMessageRegistries(t *testing.T) {
+	registries, err := StandardMessageRegistries()
+	if err != nil {
+		t.Fatalf("Error loading the standard registries: %s", err)
+	}
+
+	prefixes := make(map[string]bool)
+	for _, mr := range registries {
+		if mr.Language != "en" || len(mr.Messages) == 0 {
+			t.Errorf("Invalid registry %s: %s, %d messages", mr.ID, mr.Language, len(mr.Messages))
+		}
+		prefixes[mr.RegistryPrefix] = true
+	}
+	for _, prefix := range []string{"Base", "TaskEvent", "ResourceEvent", "Update", "License"} {
+		if !prefixes[prefix] {
+			t.Errorf("Registry %s is missing", prefix)
+		}
+	}
+}
+
+// requireStandardRegistry skips the test if no version of the registry with
+// the prefix and major and minor version, such as Base.1.8, is embedded.
+// tools/get_registries.sh embeds all the published versions.
+func requireStandardRegistry(t *testing.T, version string) {
+	t.Helper()
+	entries, err := standardRegistryFiles.ReadDir("registries")
+	if err != nil {
+		t.Fatalf("Error reading the standard registries: %s", err)
+	}
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), version+".") {
+			return
+		}
+	}
+	t.Skipf("%s is not embedded, run tools/get_registries.sh", version)
+}
+
+// TestStandardMessage tests the version matching of the standard registries.
+func TestStandardMessage(t *testing.T) {
+	for _, version := range []string{"Base.1.0", "Base.1.8", "Base.1.14", "Base.1.15"} {
+		version := version
+		t.Run(version, func(t *testing.T) {
+			requireStandardRegistry(t, version)
+			m, err := StandardMessage(version + ".PropertyValueNotInList")
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if !strings.Contains(m.Format([]string{"Red", "IndicatorLED"}), "Red") {
+				t.Errorf("Unexpected message: %s", m.Message)
+			}
+		})
+	}
+
+	requireStandardRegistry(t, "Base.1.8")
+	m, err := StandardMessage("Base.1.8.PropertyValueNotInList")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if m.Format([]string{"Red", "IndicatorLED"}) !=
+		"The value 'Red' for the property IndicatorLED is not in the list of acceptable values." {
+		t.Errorf("Unexpected message: %s", m.Message)
+	}
+
+	for _, messageID := range []string{"Base.2.0.PropertyValueNotInList", "Base.1.99.PropertyValueNotInList", "Base.1.8.Unknown", "Base.1.Success"} {
+		if _, err := StandardMessage(messageID); err == nil {
+			t.Errorf("Expected %s not to be found", messageID)
+		}
+	}
+}
+
+// TestMessageFromRegistriesVersion tests that only registries with the same
+// major and minor version are used.
+func TestMessageFromRegistriesVersion(t *testing.T) {
+	registry := func(version, message string) *MessageRegistry {
+		return &MessageRegistry{
+			RegistryPrefix:  "Test",
+			RegistryVersion: version,
+			Messages:        map[string]MessageRegistryMessage{"Message": {Message: message}},
+		}
+	}
+	registries := []*MessageRegistry{
+		registry("1.10.0", "1.10"),
+		registry("1.2.1", "1.2"),
+		registry("1.1.0", "1.1"),
+	}
+
+	tests := map[string]string{
+		"Test.1.1.Message":  "1.1",
+		"Test.1.2.Message":  "1.2",
+		"Test.1.10.Message": "1.10",
+	}
+	for messageID, expected := range tests {
+		m, err := messageFromRegistries(registries, messageID)
+		if err != nil || m.Message != expected {
+			t.Errorf("Expected %s to match version %s, got: %v %v", messageID, expected, m, err)
+		}
+	}
+
+	for _, messageID := range []string{"Test.1.0.Message", "Test.1.3.Message", "Test.2.1.Message"} {
+		if m, err := messageFromRegistries(registries, messageID); err == nil {
+			t.Errorf("Expected %s not to be found, got: %v", messageID, m)
+		}
+	}
+}
+
+// TestGetMessageStandardFallback tests that messages missing from the
+// service registries are found in the standard registries.
+func TestGetMessageStandardFallback(t *testing.T) {
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				registryResponse(registriesBody),
+				registryResponse(messageRegistryFileBody),
+				registryResponse(messageRegistryBody),
+			},
+		},
+	}
+
+	m, err := GetMessageFromMessageRegistryByLanguage(testClient, "/redfish/v1/Registries", "TaskEvent.1.0.TaskStarted", "en")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if m.Message != "The task with Id '%1' has started." {
+		t.Errorf("Unexpected message: %s", m.Message)
+	}
+
+	testClient = &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {registryResponse(`{"Members": []}`)},
+		},
+	}
+	if _, err := GetMessageFromMessageRegistryByLanguage(testClient, "/redfish/v1/Registries", "Unknown.1.0.Message", "en"); err == nil {
+		t.Error("Expected an unknown message not to be found")
+	}
+
+	testClient = &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {registryResponse(`{"Members": []}`)},
+		},
+	}
+	if _, err := GetMessageFromMessageRegistryByLanguage(testClient, "/redfish/v1/Registries", "TaskEvent.1.0.TaskStarted", "fr"); err == nil {
+		t.Error("Expected the English standard registries not to be used for other languages")
+	}
+}
+
+// TestMessageResolverStandard tests resolving messages when the service has
+// no registries.
+func TestMessageResolverStandard(t *testing.T) {
+	requireStandardRegistry(t, "Base.1.8")
+	resolver := NewMessageResolver(&common.TestClient{}, "")
+
+	body := `{"error": {"code": "Base.1.8.GeneralError", "@Message.ExtendedInfo": [
+		{"MessageId": "Base.1.8.PropertyNotWritable", "MessageArgs": ["SKU"]}
+	]}}`
+	infos, err := resolver.Resolve(common.ConstructError(http.StatusBadRequest, []byte(body)), "en")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(infos) != 1 || infos[0].Message != "The property SKU is a read-only property and cannot be assigned a value." {
+		t.Errorf("Unexpected resolved message: %#v", infos)
+	}
+}
@@ -5,6 +5,7 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 	"reflect"
 
@@ -104,93 +105,62 @@ func (storage *Storage) UnmarshalJSON(b []byte) error {
 
 // GetStorage will get a Storage instance from the service.
 func GetStorage(c common.Client, uri string) (*Storage, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetStorageContext(context.Background(), c, uri)
+}
 
+// GetStorageContext is the same as GetStorage, but uses ctx for the request.
+func GetStorageContext(ctx context.Context, c common.Client, uri string) (*Storage, error) {
+	return GetStorageWithOptions(ctx, c, uri, nil)
+}
+
+// GetStorageWithOptions is the same as GetStorageContext, but adds the query
+// options opts to the request.
+func GetStorageWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*Storage, error) {
 	var storage Storage
-	err = json.NewDecoder(resp.Body).Decode(&storage)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &storage); err != nil {
 		return nil, err
 	}
-
-	storage.SetClient(c)
 	return &storage, nil
 }
 
 // ListReferencedStorages gets the collection of Storage from a provided
 // reference.
-func ListReferencedStorages(c common.Client, link string) ([]*Storage, error) { //nolint:dupl
-	var result []*Storage
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedStorages(c common.Client, link string) ([]*Storage, error) {
+	return ListReferencedStoragesContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, storageLink := range links.ItemLinks {
-		storage, err := GetStorage(c, storageLink)
-		if err != nil {
-			collectionError.Failures[storageLink] = err
-		} else {
-			result = append(result, storage)
-		}
-	}
+// ListReferencedStoragesContext is the same as ListReferencedStorages, but uses
+// ctx for the requests.
+func ListReferencedStoragesContext(ctx context.Context, c common.Client, link string) ([]*Storage, error) {
+	return ListReferencedStoragesWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedStoragesWithOptions is the same as
+// ListReferencedStoragesContext, but adds the query options opts to the
+// requests.
+func ListReferencedStoragesWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*Storage, error) { //nolint:dupl
+	var result []*Storage
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
 
 // Enclosures gets the physical containers attached to this resource.
 func (storage *Storage) Enclosures() ([]*Chassis, error) {
 	var result []*Chassis
-
-	collectionError := common.NewCollectionError()
-	for _, chassisLink := range storage.enclosures {
-		chassis, err := GetChassis(storage.Client, chassisLink)
-		if err != nil {
-			collectionError.Failures[chassisLink] = err
-		} else {
-			result = append(result, chassis)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	err := common.GetObjects(context.Background(), storage.Client, storage.enclosures, &result)
+	return result, err
 }
 
 // Drives gets the drives attached to the storage controllers that this
 // resource represents.
 func (storage *Storage) Drives() ([]*Drive, error) {
 	var result []*Drive
-
-	collectionError := common.NewCollectionError()
-	for _, driveLink := range storage.drives {
-		drive, err := GetDrive(storage.Client, driveLink)
-		if err != nil {
-			collectionError.Failures[driveLink] = err
-		} else {
-			result = append(result, drive)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	err := common.GetObjects(context.Background(), storage.Client, storage.drives, &result)
+	return result, err
 }
 
 // Volumes gets the volumes associated with this storage subsystem.
@@ -205,11 +175,7 @@ func (storage *Storage) SetEncryptionKey(key string) error {
 	}
 	t := temp{EncryptionKey: key}
 
-	resp, err := storage.Client.Post(storage.setEncryptionKeyTarget, t)
-	if err == nil {
-		defer resp.Body.Close()
-	}
-	return err
+	return common.PostAction(context.Background(), &storage.Entity, storage.setEncryptionKeyTarget, t)
 }
 
 // GetOperationApplyTimeValues returns the OperationApplyTime values applicable for this storage
@@ -347,50 +313,48 @@ func (storagecontroller *StorageController) Update() error {
 
 // GetStorageController will get a Storage controller instance from the service.
 func GetStorageController(c common.Client, uri string) (*StorageController, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetStorageControllerContext(context.Background(), c, uri)
+}
 
+// GetStorageControllerContext is the same as GetStorageController, but uses ctx
+// for the request.
+func GetStorageControllerContext(ctx context.Context, c common.Client, uri string) (*StorageController, error) {
+	return GetStorageControllerWithOptions(ctx, c, uri, nil)
+}
+
+// GetStorageControllerWithOptions is the same as GetStorageControllerContext,
+// but adds the query options opts to the request.
+func GetStorageControllerWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*StorageController, error) {
 	var storage StorageController
-	err = json.NewDecoder(resp.Body).Decode(&storage)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &storage); err != nil {
 		return nil, err
 	}
-
-	storage.SetClient(c)
 	return &storage, nil
 }
 
 // ListReferencedStorageControllers gets the collection of StorageControllers
 // from a provided reference.
-func ListReferencedStorageControllers(c common.Client, link string) ([]*StorageController, error) { //nolint:dupl
-	var result []*StorageController
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedStorageControllers(c common.Client, link string) ([]*StorageController, error) {
+	return ListReferencedStorageControllersContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, storageLink := range links.ItemLinks {
-		storage, err := GetStorageController(c, storageLink)
-		if err != nil {
-			collectionError.Failures[storageLink] = err
-		} else {
-			result = append(result, storage)
-		}
-	}
+// ListReferencedStorageControllersContext is the same as
+// ListReferencedStorageControllers, but uses ctx for the requests.
+func ListReferencedStorageControllersContext(ctx context.Context, c common.Client, link string) ([]*StorageController, error) {
+	return ListReferencedStorageControllersWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedStorageControllersWithOptions is the same as
+// ListReferencedStorageControllersContext, but adds the query options opts to
+// the requests.
+func ListReferencedStorageControllersWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*StorageController, error) { //nolint:dupl
+	var result []*StorageController
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
 
 // Assembly gets the storage controller's assembly.
@@ -404,20 +368,6 @@ func (storagecontroller *StorageController) Assembly() (*Assembly, error) {
 // Endpoints gets the storage controller's endpoints.
 func (storagecontroller *StorageController) Endpoints() ([]*Endpoint, error) {
 	var result []*Endpoint
-
-	collectionError := common.NewCollectionError()
-	for _, endpointLink := range storagecontroller.endpoints {
-		endpoint, err := GetEndpoint(storagecontroller.Client, endpointLink)
-		if err != nil {
-			collectionError.Failures[endpointLink] = err
-		} else {
-			result = append(result, endpoint)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	err := common.GetObjects(context.Background(), storagecontroller.Client, storagecontroller.endpoints, &result)
+	return result, err
 }
@@ -5,6 +5,7 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/stmcginnis/gofish/common"
@@ -153,48 +154,44 @@ func (task *Task) UnmarshalJSON(b []byte) error {
 
 // GetTask will get a Task instance from the service.
 func GetTask(c common.Client, uri string) (*Task, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetTaskContext(context.Background(), c, uri)
+}
+
+// GetTaskContext is the same as GetTask, but uses ctx for the request.
+func GetTaskContext(ctx context.Context, c common.Client, uri string) (*Task, error) {
+	return GetTaskWithOptions(ctx, c, uri, nil)
+}
 
+// GetTaskWithOptions is the same as GetTaskContext, but adds the query options
+// opts to the request.
+func GetTaskWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*Task, error) {
 	var task Task
-	err = json.NewDecoder(resp.Body).Decode(&task)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &task); err != nil {
 		return nil, err
 	}
-
-	task.SetClient(c)
 	return &task, nil
 }
 
 // ListReferencedTasks gets the collection of Task from
 // a provided reference.
-func ListReferencedTasks(c common.Client, link string) ([]*Task, error) { //nolint:dupl
-	var result []*Task
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedTasks(c common.Client, link string) ([]*Task, error) {
+	return ListReferencedTasksContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, taskLink := range links.ItemLinks {
-		task, err := GetTask(c, taskLink)
-		if err != nil {
-			collectionError.Failures[taskLink] = err
-		} else {
-			result = append(result, task)
-		}
-	}
+// ListReferencedTasksContext is the same as ListReferencedTasks, but uses ctx
+// for the requests.
+func ListReferencedTasksContext(ctx context.Context, c common.Client, link string) ([]*Task, error) {
+	return ListReferencedTasksWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedTasksWithOptions is the same as ListReferencedTasksContext, but
+// adds the query options opts to the requests.
+func ListReferencedTasksWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*Task, error) { //nolint:dupl
+	var result []*Task
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
@@ -5,6 +5,7 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/stmcginnis/gofish/common"
@@ -334,47 +335,44 @@ func (thermal *Thermal) UnmarshalJSON(b []byte) error {
 
 // GetThermal will get a Thermal instance from the service.
 func GetThermal(c common.Client, uri string) (*Thermal, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetThermalContext(context.Background(), c, uri)
+}
+
+// GetThermalContext is the same as GetThermal, but uses ctx for the request.
+func GetThermalContext(ctx context.Context, c common.Client, uri string) (*Thermal, error) {
+	return GetThermalWithOptions(ctx, c, uri, nil)
+}
 
+// GetThermalWithOptions is the same as GetThermalContext, but adds the query
+// options opts to the request.
+func GetThermalWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*Thermal, error) {
 	var thermal Thermal
-	err = json.NewDecoder(resp.Body).Decode(&thermal)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &thermal); err != nil {
 		return nil, err
 	}
-
-	thermal.SetClient(c)
 	return &thermal, nil
 }
 
 // ListReferencedThermals gets the collection of Thermal from a provided reference.
-func ListReferencedThermals(c common.Client, link string) ([]*Thermal, error) { //nolint:dupl
-	var result []*Thermal
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedThermals(c common.Client, link string) ([]*Thermal, error) {
+	return ListReferencedThermalsContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, thermalLink := range links.ItemLinks {
-		thermal, err := GetThermal(c, thermalLink)
-		if err != nil {
-			collectionError.Failures[thermalLink] = err
-		} else {
-			result = append(result, thermal)
-		}
-	}
+// ListReferencedThermalsContext is the same as ListReferencedThermals, but uses
+// ctx for the requests.
+func ListReferencedThermalsContext(ctx context.Context, c common.Client, link string) ([]*Thermal, error) {
+	return ListReferencedThermalsWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedThermalsWithOptions is the same as
+// ListReferencedThermalsContext, but adds the query options opts to the
+// requests.
+func ListReferencedThermalsWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*Thermal, error) { //nolint:dupl
+	var result []*Thermal
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
@@ -5,6 +5,7 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/stmcginnis/gofish/common"
@@ -83,17 +84,22 @@ func (updateService *UpdateService) UnmarshalJSON(b []byte) error {
 
 // GetUpdateService will get a UpdateService instance from the service.
 func GetUpdateService(c common.Client, uri string) (*UpdateService, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetUpdateServiceContext(context.Background(), c, uri)
+}
+
+// GetUpdateServiceContext is the same as GetUpdateService, but uses ctx for the
+// request.
+func GetUpdateServiceContext(ctx context.Context, c common.Client, uri string) (*UpdateService, error) {
+	return GetUpdateServiceWithOptions(ctx, c, uri, nil)
+}
+
+// GetUpdateServiceWithOptions is the same as GetUpdateServiceContext, but adds
+// the query options opts to the request.
+func GetUpdateServiceWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*UpdateService, error) {
 	var updateService UpdateService
-	err = json.NewDecoder(resp.Body).Decode(&updateService)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &updateService); err != nil {
 		return nil, err
 	}
-	updateService.SetClient(c)
 	return &updateService, nil
 }
 
@@ -5,6 +5,7 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"reflect"
@@ -202,11 +203,7 @@ func (virtualmedia *VirtualMedia) EjectMedia() error {
 		return errors.New("redfish service does not support VirtualMedia.EjectMedia calls")
 	}
 
-	resp, err := virtualmedia.Client.Post(virtualmedia.ejectMediaTarget, struct{}{})
-	if err == nil {
-		defer resp.Body.Close()
-	}
-	return err
+	return common.PostAction(context.Background(), &virtualmedia.Entity, virtualmedia.ejectMediaTarget, struct{}{})
 }
 
 // InsertMedia sends a request to insert virtual media.
@@ -226,11 +223,7 @@ func (virtualmedia *VirtualMedia) InsertMedia(image string, inserted, writeProte
 		WriteProtected: writeProtected,
 	}
 
-	resp, err := virtualmedia.Client.Post(virtualmedia.insertMediaTarget, t)
-	if err == nil {
-		defer resp.Body.Close()
-	}
-	return err
+	return common.PostAction(context.Background(), &virtualmedia.Entity, virtualmedia.insertMediaTarget, t)
 }
 
 // VirtualMediaConfig is an struct used to pass config data to build the HTTP body when inserting media
@@ -249,56 +242,51 @@ func (virtualmedia *VirtualMedia) InsertMediaConfig(config VirtualMediaConfig) e
 	if !virtualmedia.SupportsMediaInsert {
 		return errors.New("redfish service does not support VirtualMedia.InsertMedia calls")
 	}
-	resp, err := virtualmedia.Client.Post(virtualmedia.insertMediaTarget, config)
-	if err == nil {
-		defer resp.Body.Close()
-	}
-	return err
+	return common.PostAction(context.Background(), &virtualmedia.Entity, virtualmedia.insertMediaTarget, config)
 }
 
 // GetVirtualMedia will get a VirtualMedia instance from the service.
 func GetVirtualMedia(c common.Client, uri string) (*VirtualMedia, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetVirtualMediaContext(context.Background(), c, uri)
+}
+
+// GetVirtualMediaContext is the same as GetVirtualMedia, but uses ctx for the
+// request.
+func GetVirtualMediaContext(ctx context.Context, c common.Client, uri string) (*VirtualMedia, error) {
+	return GetVirtualMediaWithOptions(ctx, c, uri, nil)
+}
 
+// GetVirtualMediaWithOptions is the same as GetVirtualMediaContext, but adds
+// the query options opts to the request.
+func GetVirtualMediaWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*VirtualMedia, error) {
 	var virtualmedia VirtualMedia
-	err = json.NewDecoder(resp.Body).Decode(&virtualmedia)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &virtualmedia); err != nil {
 		return nil, err
 	}
-
-	virtualmedia.SetClient(c)
 	return &virtualmedia, nil
 }
 
 // ListReferencedVirtualMedias gets the collection of VirtualMedia from
 // a provided reference.
-func ListReferencedVirtualMedias(c common.Client, link string) ([]*VirtualMedia, error) { //nolint:dupl
-	var result []*VirtualMedia
-	if link == "" {
-		return result, nil
-	}
+func ListReferencedVirtualMedias(c common.Client, link string) ([]*VirtualMedia, error) {
+	return ListReferencedVirtualMediasContext(context.Background(), c, link)
+}
 
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+// ListReferencedVirtualMediasContext is the same as
+// ListReferencedVirtualMedias, but uses ctx for the requests.
+func ListReferencedVirtualMediasContext(ctx context.Context, c common.Client, link string) ([]*VirtualMedia, error) {
+	return ListReferencedVirtualMediasWithOptions(ctx, c, link, nil)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, virtualmediaLink := range links.ItemLinks {
-		virtualmedia, err := GetVirtualMedia(c, virtualmediaLink)
-		if err != nil {
-			collectionError.Failures[virtualmediaLink] = err
-		} else {
-			result = append(result, virtualmedia)
-		}
-	}
-	if collectionError.Empty() {
+// ListReferencedVirtualMediasWithOptions is the same as
+// ListReferencedVirtualMediasContext, but adds the query options opts to the
+// requests.
+func ListReferencedVirtualMediasWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*VirtualMedia, error) { //nolint:dupl
+	var result []*VirtualMedia
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
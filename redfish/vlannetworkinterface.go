@@ -5,6 +5,7 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 	"reflect"
 
@@ -82,48 +83,47 @@ func (vlannetworkinterface *VLanNetworkInterface) Update() error {
 
 // GetVLanNetworkInterface will get a VLanNetworkInterface instance from the service.
 func GetVLanNetworkInterface(c common.Client, uri string) (*VLanNetworkInterface, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetVLanNetworkInterfaceContext(context.Background(), c, uri)
+}
+
+// GetVLanNetworkInterfaceContext is the same as GetVLanNetworkInterface, but
+// uses ctx for the request.
+func GetVLanNetworkInterfaceContext(ctx context.Context, c common.Client, uri string) (*VLanNetworkInterface, error) {
+	return GetVLanNetworkInterfaceWithOptions(ctx, c, uri, nil)
+}
 
+// GetVLanNetworkInterfaceWithOptions is the same as
+// GetVLanNetworkInterfaceContext, but adds the query options opts to the
+// request.
+func GetVLanNetworkInterfaceWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*VLanNetworkInterface, error) {
 	var vlannetworkinterface VLanNetworkInterface
-	err = json.NewDecoder(resp.Body).Decode(&vlannetworkinterface)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &vlannetworkinterface); err != nil {
 		return nil, err
 	}
-
-	vlannetworkinterface.SetClient(c)
 	return &vlannetworkinterface, nil
 }
 
 // ListReferencedVLanNetworkInterfaces gets the collection of VLanNetworkInterface from
 // a provided reference.
-func ListReferencedVLanNetworkInterfaces(c common.Client, link string) ([]*VLanNetworkInterface, error) { //nolint:dupl
-	var result []*VLanNetworkInterface
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedVLanNetworkInterfaces(c common.Client, link string) ([]*VLanNetworkInterface, error) {
+	return ListReferencedVLanNetworkInterfacesContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, vlannetworkinterfaceLink := range links.ItemLinks {
-		vlannetworkinterface, err := GetVLanNetworkInterface(c, vlannetworkinterfaceLink)
-		if err != nil {
-			collectionError.Failures[vlannetworkinterfaceLink] = err
-		} else {
-			result = append(result, vlannetworkinterface)
-		}
-	}
+// ListReferencedVLanNetworkInterfacesContext is the same as
+// ListReferencedVLanNetworkInterfaces, but uses ctx for the requests.
+func ListReferencedVLanNetworkInterfacesContext(ctx context.Context, c common.Client, link string) ([]*VLanNetworkInterface, error) {
+	return ListReferencedVLanNetworkInterfacesWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedVLanNetworkInterfacesWithOptions is the same as
+// ListReferencedVLanNetworkInterfacesContext, but adds the query options opts
+// to the requests.
+func ListReferencedVLanNetworkInterfacesWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*VLanNetworkInterface, error) { //nolint:dupl
+	var result []*VLanNetworkInterface
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
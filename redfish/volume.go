@@ -5,6 +5,7 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/stmcginnis/gofish/common"
@@ -220,70 +221,52 @@ func (volume *Volume) UnmarshalJSON(b []byte) error {
 
 // GetVolume will get a Volume instance from the service.
 func GetVolume(c common.Client, uri string) (*Volume, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetVolumeContext(context.Background(), c, uri)
+}
+
+// GetVolumeContext is the same as GetVolume, but uses ctx for the request.
+func GetVolumeContext(ctx context.Context, c common.Client, uri string) (*Volume, error) {
+	return GetVolumeWithOptions(ctx, c, uri, nil)
+}
 
+// GetVolumeWithOptions is the same as GetVolumeContext, but adds the query
+// options opts to the request.
+func GetVolumeWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*Volume, error) {
 	var volume Volume
-	err = json.NewDecoder(resp.Body).Decode(&volume)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &volume); err != nil {
 		return nil, err
 	}
-
-	volume.SetClient(c)
 	return &volume, nil
 }
 
 // ListReferencedVolumes gets the collection of Volumes from a provided reference.
-func ListReferencedVolumes(c common.Client, link string) ([]*Volume, error) { //nolint:dupl
-	var result []*Volume
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedVolumes(c common.Client, link string) ([]*Volume, error) {
+	return ListReferencedVolumesContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, volumeLink := range links.ItemLinks {
-		volume, err := GetVolume(c, volumeLink)
-		if err != nil {
-			collectionError.Failures[volumeLink] = err
-		} else {
-			result = append(result, volume)
-		}
-	}
+// ListReferencedVolumesContext is the same as ListReferencedVolumes, but uses
+// ctx for the requests.
+func ListReferencedVolumesContext(ctx context.Context, c common.Client, link string) ([]*Volume, error) {
+	return ListReferencedVolumesWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedVolumesWithOptions is the same as ListReferencedVolumesContext,
+// but adds the query options opts to the requests.
+func ListReferencedVolumesWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*Volume, error) { //nolint:dupl
+	var result []*Volume
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
 
 // Drives references the Drives that this volume is associated with.
 func (volume *Volume) Drives() ([]*Drive, error) {
 	var result []*Drive
-
-	collectionError := common.NewCollectionError()
-	for _, driveLink := range volume.drives {
-		drive, err := GetDrive(volume.Client, driveLink)
-		if err != nil {
-			collectionError.Failures[driveLink] = err
-		} else {
-			result = append(result, drive)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	err := common.GetObjects(context.Background(), volume.Client, volume.drives, &result)
+	return result, err
 }
 
 // AllowedVolumesUpdateApplyTimes returns the set of allowed apply times to request when setting the volumes values
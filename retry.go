@@ -0,0 +1,197 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/stmcginnis/gofish/common"
+)
+
+const (
+	// DefaultRetryAttempts is the number of attempts made by DefaultRetryPolicy.
+	DefaultRetryAttempts = 3
+	// DefaultRetryInitialBackoff is the delay before the first retry if the
+	// RetryPolicy does not set one.
+	DefaultRetryInitialBackoff = 500 * time.Millisecond
+	// DefaultRetryMaxBackoff is the longest delay between two attempts if the
+	// RetryPolicy does not set one.
+	DefaultRetryMaxBackoff = 30 * time.Second
+	// DefaultRetryJitter is the jitter used by DefaultRetryPolicy.
+	DefaultRetryJitter = 0.2
+)
+
+// RetryPolicy controls how requests that failed with a transient error, such
+// as a busy or rebooting BMC, are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times a request is sent, including
+	// the first attempt. Values lower than 2 disable retries.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry. The delay is
+	// doubled for every following attempt. DefaultRetryInitialBackoff is used
+	// if this is not set.
+	InitialBackoff time.Duration
+	// MaxBackoff is the maximum delay between two attempts, including delays
+	// requested by the service through the Retry-After header.
+	// DefaultRetryMaxBackoff is used if this is not set.
+	MaxBackoff time.Duration
+	// Jitter is the fraction (between 0 and 1) of every backoff delay that is
+	// randomized to avoid many clients retrying at the same time. Zero
+	// disables jitter.
+	Jitter float64
+	// RetryNonIdempotent allows POST and PATCH requests to be retried. Only
+	// enable this if repeating those requests is safe for the service, by
+	// default only GET, HEAD, PUT, DELETE and OPTIONS requests are retried.
+	RetryNonIdempotent bool
+	// ShouldRetry is an optional function to classify errors as transient.
+	// The error is either a *common.Error for responses with an unsuccessful
+	// status code, or the error returned by the HTTP client. If not set,
+	// IsTransientError is used.
+	ShouldRetry func(err error) bool
+}
+
+// DefaultRetryPolicy returns a RetryPolicy with sensible default values.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    DefaultRetryAttempts,
+		InitialBackoff: DefaultRetryInitialBackoff,
+		MaxBackoff:     DefaultRetryMaxBackoff,
+		Jitter:         DefaultRetryJitter,
+	}
+}
+
+// IsTransientError checks if err is likely to be caused by a temporary
+// condition of the service. This covers 429 and 502-504 responses, 500
+// responses reporting the service as temporarily unavailable, as well as
+// timeouts and connections that were refused or reset.
+func IsTransientError(err error) bool {
+	var redfishErr *common.Error
+	if errors.As(err, &redfishErr) {
+		switch redfishErr.HTTPReturnedStatusCode {
+		case http.StatusTooManyRequests, http.StatusBadGateway,
+			http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		case http.StatusInternalServerError:
+			return isTemporarilyUnavailable(redfishErr)
+		}
+		return false
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// isTemporarilyUnavailable checks for the Base registry messages a service
+// uses to report it is too busy to handle the request.
+func isTemporarilyUnavailable(err *common.Error) bool {
+	if err.HasMessage("ServiceTemporarilyUnavailable") {
+		return true
+	}
+	return strings.Contains(strings.ToLower(err.Message), "temporarily busy")
+}
+
+// retryable checks if a request may be attempted again after it failed.
+func (p *RetryPolicy) retryable(method string, attempt int, err error) bool {
+	if p == nil || attempt >= p.MaxAttempts {
+		return false
+	}
+
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+	default:
+		if !p.RetryNonIdempotent {
+			return false
+		}
+	}
+
+	if p.ShouldRetry != nil {
+		return p.ShouldRetry(err)
+	}
+	return IsTransientError(err)
+}
+
+// backoff returns how long to wait before the next attempt. If the service
+// asked for a delay through the Retry-After header, that delay is used.
+func (p *RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	maxBackoff := p.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultRetryMaxBackoff
+	}
+
+	if delay, ok := retryAfter(resp); ok {
+		if delay > maxBackoff {
+			return maxBackoff
+		}
+		return delay
+	}
+
+	delay := p.InitialBackoff
+	if delay <= 0 {
+		delay = DefaultRetryInitialBackoff
+	}
+	for i := 1; i < attempt && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxBackoff {
+		delay = maxBackoff
+	}
+
+	if p.Jitter > 0 {
+		jitter := p.Jitter
+		if jitter > 1 {
+			jitter = 1
+		}
+		delay -= time.Duration(rand.Float64() * jitter * float64(delay)) //nolint:gosec
+	}
+
+	return delay
+}
+
+// retryAfter parses the Retry-After header of a response, which can either
+// be a number of seconds or a HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+
+	return 0, false
+}
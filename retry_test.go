@@ -0,0 +1,196 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stmcginnis/gofish/common"
+)
+
+// flakyServer fails the first failures requests to anything but the service
+// root with the given status.
+func flakyServer(t *testing.T, failures, status int) (*httptest.Server, *int) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redfish/v1/" {
+			w.Write([]byte(`{}`)) //nolint
+			return
+		}
+
+		requests++
+		if requests <= failures {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(status)
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		w.Write(body) //nolint
+	}))
+	t.Cleanup(ts.Close)
+	return ts, &requests
+}
+
+func fastRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+	}
+}
+
+// TestRetryTransientError tests that a request is retried until it succeeds.
+func TestRetryTransientError(t *testing.T) {
+	ts, requests := flakyServer(t, 2, http.StatusServiceUnavailable)
+
+	c, err := Connect(ClientConfig{Endpoint: ts.URL, HTTPClient: ts.Client(), RetryPolicy: fastRetryPolicy()})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	resp, err := c.Put("/redfish/v1/Systems/1", map[string]string{"AssetTag": "retried"})
+	if err != nil {
+		t.Fatalf("Request should have been retried: %s", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != `{"AssetTag":"retried"}` {
+		t.Errorf("Payload was not resent: %s", body)
+	}
+
+	if *requests != 3 {
+		t.Errorf("Expected 3 attempts, got %d", *requests)
+	}
+}
+
+// TestRetryMaxAttempts tests that the last error is returned once all
+// attempts were used.
+func TestRetryMaxAttempts(t *testing.T) {
+	ts, requests := flakyServer(t, 5, http.StatusTooManyRequests)
+
+	c, err := Connect(ClientConfig{Endpoint: ts.URL, HTTPClient: ts.Client(), RetryPolicy: fastRetryPolicy()})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	_, err = c.Get("/redfish/v1/Systems/1") //nolint:bodyclose
+	errStruct, ok := err.(*common.Error)
+	if !ok || errStruct.HTTPReturnedStatusCode != http.StatusTooManyRequests {
+		t.Errorf("Expected 429 error, got: %v", err)
+	}
+
+	if *requests != 3 {
+		t.Errorf("Expected 3 attempts, got %d", *requests)
+	}
+}
+
+// TestRetryNonIdempotent tests that POST requests are only retried on opt-in.
+func TestRetryNonIdempotent(t *testing.T) {
+	ts, requests := flakyServer(t, 1, http.StatusServiceUnavailable)
+
+	policy := fastRetryPolicy()
+	c, err := Connect(ClientConfig{Endpoint: ts.URL, HTTPClient: ts.Client(), RetryPolicy: policy})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	_, err = c.Post("/redfish/v1/Systems/1/Actions/ComputerSystem.Reset", nil) //nolint:bodyclose
+	if err == nil || *requests != 1 {
+		t.Errorf("POST should not be retried by default, %d attempts made", *requests)
+	}
+
+	policy.RetryNonIdempotent = true
+	*requests = 0
+	resp, err := c.Post("/redfish/v1/Systems/1/Actions/ComputerSystem.Reset", nil)
+	if err != nil {
+		t.Fatalf("POST should have been retried: %s", err)
+	}
+	resp.Body.Close()
+}
+
+// TestRetryShouldRetry tests a custom error classification.
+func TestRetryShouldRetry(t *testing.T) {
+	ts, requests := flakyServer(t, 1, http.StatusNotFound)
+
+	policy := fastRetryPolicy()
+	policy.ShouldRetry = func(err error) bool {
+		var e *common.Error
+		return errors.As(err, &e) && e.HTTPReturnedStatusCode == http.StatusNotFound
+	}
+
+	c, err := Connect(ClientConfig{Endpoint: ts.URL, HTTPClient: ts.Client(), RetryPolicy: policy})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	resp, err := c.Get("/redfish/v1/Systems/1")
+	if err != nil {
+		t.Fatalf("Request should have been retried: %s", err)
+	}
+	resp.Body.Close()
+
+	if *requests != 2 {
+		t.Errorf("Expected 2 attempts, got %d", *requests)
+	}
+}
+
+// TestIsTransientError tests the default error classification.
+func TestIsTransientError(t *testing.T) {
+	tests := []struct {
+		err       error
+		transient bool
+	}{
+		{common.ConstructError(http.StatusServiceUnavailable, nil), true},
+		{common.ConstructError(http.StatusTooManyRequests, nil), true},
+		{common.ConstructError(http.StatusNotFound, nil), false},
+		{common.ConstructError(http.StatusInternalServerError, []byte(`{"error": {"code": "Base.1.8.GeneralError"}}`)), false},
+		{common.ConstructError(http.StatusInternalServerError, []byte(`{"error": {"code": "Base.1.8.ServiceTemporarilyUnavailable"}}`)), true},
+		{io.ErrUnexpectedEOF, true},
+		{errors.New("some error"), false},
+	}
+
+	for _, test := range tests {
+		if IsTransientError(test.err) != test.transient {
+			t.Errorf("Expected %v to be transient: %t", test.err, test.transient)
+		}
+	}
+}
+
+// TestRetryBackoff tests the backoff delays.
+func TestRetryBackoff(t *testing.T) {
+	policy := &RetryPolicy{
+		InitialBackoff: time.Second,
+		MaxBackoff:     5 * time.Second,
+	}
+
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second}
+	for i, delay := range expected {
+		if result := policy.backoff(i+1, nil); result != delay {
+			t.Errorf("Attempt %d: expected %s, got %s", i+1, delay, result)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"3"}}}
+	if result := policy.backoff(1, resp); result != 3*time.Second {
+		t.Errorf("Retry-After should be honoured, got %s", result)
+	}
+
+	resp.Header.Set("Retry-After", "120")
+	if result := policy.backoff(1, resp); result != 5*time.Second {
+		t.Errorf("Retry-After should be capped, got %s", result)
+	}
+
+	policy.Jitter = 0.5
+	if result := policy.backoff(2, nil); result < time.Second || result > 2*time.Second {
+		t.Errorf("Unexpected delay with jitter: %s", result)
+	}
+}
@@ -6,6 +6,7 @@ package gofish
 
 import (
 	"encoding/json"
+	"errors"
 
 	"github.com/stmcginnis/gofish/common"
 	"github.com/stmcginnis/gofish/redfish"
@@ -57,6 +58,10 @@ type ProtocolFeaturesSupported struct {
 	// SelectQuery shall be a boolean indicating whether this service supports
 	// the use of the $select query parameter as described by the specification.
 	SelectQuery bool
+	// TopSkipQuery shall be a boolean indicating whether this service supports
+	// the use of the $top and $skip query parameters as described by the
+	// specification.
+	TopSkipQuery bool
 }
 
 // Service represents the root Redfish service. All values for resources
@@ -260,8 +265,28 @@ func (serviceroot *Service) CreateSession(username, password string) (*redfish.A
 	return redfish.CreateSession(serviceroot.Client, serviceroot.sessions, username, password)
 }
 
-// Sessions gets the system's active sessions
+// SessionService gets the Redfish SessionService
+func (serviceroot *Service) SessionService() (*redfish.SessionService, error) {
+	return redfish.GetSessionService(serviceroot.Client, serviceroot.sessionService)
+}
+
+// Sessions gets the system's active sessions, from the SessionService if it
+// links to them, or else from the sessions link of the service root. The
+// service root is only used when there is no SessionService, it is not found
+// or it has no sessions collection; other errors are returned.
 func (serviceroot *Service) Sessions() ([]*redfish.Session, error) {
+	if serviceroot.sessionService != "" {
+		sessionService, err := serviceroot.SessionService()
+		switch {
+		case err == nil:
+			sessions, err := sessionService.Sessions()
+			if !errors.Is(err, redfish.ErrNoSessionsCollection) {
+				return sessions, err
+			}
+		case !common.IsNotFound(err):
+			return nil, err
+		}
+	}
 	return redfish.ListReferencedSessions(serviceroot.Client, serviceroot.sessions)
 }
 
@@ -6,8 +6,11 @@ package gofish
 
 import (
 	"encoding/json"
+	"net/http"
 	"strings"
 	"testing"
+
+	"github.com/stmcginnis/gofish/mockserver"
 )
 
 var serviceRootBody = strings.NewReader(
@@ -62,7 +65,8 @@ var serviceRootBody = strings.NewReader(
 			},
 			"FilterQuery": true,
 			"OnlyMemberQuery": true,
-			"SelectQuery": true
+			"SelectQuery": true,
+			"TopSkipQuery": true
 		},
 		"RedfishVersion": "1.2.3",
 		"Registries": {
@@ -157,6 +161,10 @@ func TestServiceRoot(t *testing.T) {
 		t.Error("ExcerptQuery should be true")
 	}
 
+	if !result.ProtocolFeaturesSupported.TopSkipQuery {
+		t.Error("TopSkipQuery should be true")
+	}
+
 	if result.registries != "/redfish/v1/Registries" {
 		t.Errorf("Invalid Registries link: %s", result.registries)
 	}
@@ -237,3 +245,47 @@ func TestServiceRootOEM(t *testing.T) {
 		t.Errorf("Expect\n%s\n,Obtain\n%s", oemExp, oemObt)
 	}
 }
+
+// TestServiceRootSessions tests getting the sessions from the SessionService,
+// and from the service root if the SessionService does not link to them.
+func TestServiceRootSessions(t *testing.T) {
+	server, ts := sessionCacheServer(t)
+	c, err := Connect(ClientConfig{Endpoint: ts.URL, Username: "admin", Password: "password"})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+	defer c.Logout()
+
+	sessionService, err := c.Service.SessionService()
+	if err != nil {
+		t.Fatalf("Error getting the session service: %s", err)
+	}
+	if sessionService.SessionTimeout != 30 {
+		t.Errorf("Invalid session timeout: %d", sessionService.SessionTimeout)
+	}
+
+	sessions, err := c.Service.Sessions()
+	if err != nil || len(sessions) != 1 {
+		t.Errorf("Expected one session, got: %v %v", sessions, err)
+	}
+
+	resource := server.Resource("/redfish/v1/SessionService")
+	delete(resource, "Sessions")
+	server.SetResource("/redfish/v1/SessionService", resource)
+
+	sessions, err = c.Service.Sessions()
+	if err != nil || len(sessions) != 1 {
+		t.Errorf("Expected one session from the service root, got: %v %v", sessions, err)
+	}
+
+	server.Inject(mockserver.Fault{Path: "/redfish/v1/SessionService", Times: 1, StatusCode: http.StatusNotFound})
+	sessions, err = c.Service.Sessions()
+	if err != nil || len(sessions) != 1 {
+		t.Errorf("Expected one session from the service root after a 404, got: %v %v", sessions, err)
+	}
+
+	server.Inject(mockserver.Fault{Path: "/redfish/v1/SessionService", Times: 1, StatusCode: http.StatusInternalServerError})
+	if sessions, err = c.Service.Sessions(); err == nil {
+		t.Errorf("Expected the SessionService error, got: %v", sessions)
+	}
+}
@@ -0,0 +1,272 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+)
+
+// CachedSession is a session stored in a SessionCache.
+type CachedSession struct {
+	// Endpoint is the URL of the service of the session.
+	Endpoint string
+	// Username is the user the session was created for.
+	Username string
+	// Session is the ID and token of the session.
+	Session
+	// LastUsed is when the session was last created or resumed.
+	LastUsed time.Time
+}
+
+// SessionCache stores sessions by endpoint and user, so that later clients,
+// such as the next run of a command, resume the session instead of creating
+// a new one.
+type SessionCache interface {
+	// Load returns the session of username at endpoint, or nil if there is
+	// none.
+	Load(endpoint, username string) (*CachedSession, error)
+	// Store adds or replaces the session of its endpoint and user.
+	Store(session *CachedSession) error
+	// Delete removes the session of username at endpoint, if any.
+	Delete(endpoint, username string) error
+	// List returns all the sessions.
+	List() ([]*CachedSession, error)
+}
+
+// FileSessionCache is a SessionCache stored in a JSON file only readable by
+// its owner, since it holds session tokens.
+type FileSessionCache struct {
+	// Path is the path of the file. Defaults to gofish/sessions.json in the
+	// user cache directory.
+	Path string
+
+	lock sync.Mutex
+}
+
+// Load returns the cached session of username at endpoint.
+func (f *FileSessionCache) Load(endpoint, username string) (*CachedSession, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	sessions, err := f.read()
+	if err != nil {
+		return nil, err
+	}
+	return sessions[sessionCacheKey(endpoint, username)], nil
+}
+
+// Store adds or replaces a cached session.
+func (f *FileSessionCache) Store(session *CachedSession) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	sessions, err := f.read()
+	if err != nil {
+		return err
+	}
+	sessions[sessionCacheKey(session.Endpoint, session.Username)] = session
+	return f.write(sessions)
+}
+
+// Delete removes the cached session of username at endpoint.
+func (f *FileSessionCache) Delete(endpoint, username string) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	sessions, err := f.read()
+	if err != nil {
+		return err
+	}
+	key := sessionCacheKey(endpoint, username)
+	if _, ok := sessions[key]; !ok {
+		return nil
+	}
+	delete(sessions, key)
+	return f.write(sessions)
+}
+
+// List returns the cached sessions, sorted by endpoint and user.
+func (f *FileSessionCache) List() ([]*CachedSession, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	sessions, err := f.read()
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(sessions))
+	for key := range sessions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	result := make([]*CachedSession, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, sessions[key])
+	}
+	return result, nil
+}
+
+func (f *FileSessionCache) path() (string, error) {
+	if f.Path != "" {
+		return f.Path, nil
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gofish", "sessions.json"), nil
+}
+
+// read returns the sessions in the file by key, or none if it does not
+// exist.
+func (f *FileSessionCache) read() (map[string]*CachedSession, error) {
+	sessions := make(map[string]*CachedSession)
+
+	name, err := f.path()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(name)
+	if errors.Is(err, os.ErrNotExist) {
+		return sessions, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &sessions); err != nil {
+		return nil, fmt.Errorf("invalid session cache %s: %w", name, err)
+	}
+	return sessions, nil
+}
+
+// write replaces the file with sessions. The file is renamed into place so
+// other processes never read a partial file.
+func (f *FileSessionCache) write(sessions map[string]*CachedSession) error {
+	name, err := f.path()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0o700); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(sessions, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(name), filepath.Base(name)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	// CreateTemp already creates the file with mode 0600.
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
+}
+
+func sessionCacheKey(endpoint, username string) string {
+	return username + "@" + endpoint
+}
+
+// resumeSession returns the cached session of the client's user if the
+// service still accepts it, or nil. Sessions the service ended are removed
+// from the cache. Errors reading or writing the cache are ignored, so that a
+// new session is created instead.
+func (c *APIClient) resumeSession(ctx context.Context, username string) (*redfish.AuthToken, error) {
+	cached, err := c.sessionCache.Load(c.endpoint, username)
+	if err != nil || cached == nil {
+		return nil, nil
+	}
+
+	// Reading the session itself is a cheap check that its token is valid.
+	auth := &redfish.AuthToken{Session: cached.ID, Token: cached.Token}
+	resp, err := c.doRequest(ctx, http.MethodGet, cached.ID, nil, "", nil, auth)
+	if err != nil {
+		if common.IsUnauthorized(err) || common.IsNotFound(err) {
+			_ = c.sessionCache.Delete(c.endpoint, username)
+			return nil, nil
+		}
+		// The session may still be valid, so it is kept for the next
+		// clients rather than leaked.
+		return nil, err
+	}
+	resp.Body.Close()
+
+	cached.LastUsed = time.Now()
+	_ = c.sessionCache.Store(cached)
+	return auth, nil
+}
+
+// DeleteCachedSessions deletes the sessions of config.SessionCache that were
+// not used for at least maxAge from their services, and then from the cache.
+// Sessions the services already ended are removed from the cache as well.
+// The other settings of config are used to connect to the services.
+func DeleteCachedSessions(ctx context.Context, config ClientConfig, maxAge time.Duration) error { //nolint:gocritic
+	if config.SessionCache == nil {
+		return errors.New("no session cache configured")
+	}
+
+	sessions, err := config.SessionCache.List()
+	if err != nil {
+		return err
+	}
+
+	failures := common.NewCollectionError()
+	for _, cached := range sessions {
+		if time.Since(cached.LastUsed) < maxAge {
+			continue
+		}
+
+		sessionConfig := config
+		sessionConfig.Endpoint = cached.Endpoint
+		sessionConfig.Session = &Session{ID: cached.ID, Token: cached.Token}
+		sessionConfig.SessionCache = nil
+		sessionConfig.ReAuthenticate = false
+		// The client is only used to delete the session, nothing would stop
+		// its keep-alive.
+		sessionConfig.SessionKeepAlive = false
+
+		c, err := ConnectContext(ctx, sessionConfig)
+		if err == nil {
+			err = c.Service.DeleteSession(cached.ID)
+		}
+		if err != nil && !common.IsNotFound(err) && !common.IsUnauthorized(err) {
+			failures.Failures[sessionCacheKey(cached.Endpoint, cached.Username)] = err
+			continue
+		}
+
+		if err := config.SessionCache.Delete(cached.Endpoint, cached.Username); err != nil {
+			return err
+		}
+	}
+
+	if failures.Empty() {
+		return nil
+	}
+	return failures
+}
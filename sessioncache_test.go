@@ -0,0 +1,167 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stmcginnis/gofish/mockserver"
+)
+
+const mockSessionsURI = "/redfish/v1/SessionService/Sessions"
+
+// sessionCacheServer starts a mock server requiring authentication.
+func sessionCacheServer(t *testing.T) (*mockserver.Server, *httptest.Server) {
+	server, err := mockserver.New("mockserver/testdata/simple")
+	if err != nil {
+		t.Fatalf("Error loading mockup: %s", err)
+	}
+	server.Username, server.Password = "admin", "password"
+	ts := server.Start()
+	t.Cleanup(ts.Close)
+	return server, ts
+}
+
+// activeSessions returns the number of sessions of the mock server.
+func activeSessions(server *mockserver.Server) int {
+	members, _ := server.Resource(mockSessionsURI)["Members"].([]interface{})
+	return len(members)
+}
+
+// TestSessionCache tests resuming cached sessions.
+func TestSessionCache(t *testing.T) {
+	server, ts := sessionCacheServer(t)
+	name := filepath.Join(t.TempDir(), "cache", "sessions.json")
+	cache := &FileSessionCache{Path: name}
+	config := ClientConfig{Endpoint: ts.URL, Username: "admin", Password: "password", SessionCache: cache}
+
+	first, err := Connect(config)
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+	firstSession, _ := first.GetSession()
+
+	info, err := os.Stat(name)
+	if err != nil || info.Mode().Perm() != 0o600 {
+		t.Fatalf("Expected a private cache file, got: %v %v", info, err)
+	}
+
+	// A new process resumes the session.
+	second, err := Connect(ClientConfig{Endpoint: ts.URL, Username: "admin", Password: "password", SessionCache: &FileSessionCache{Path: name}})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+	secondSession, _ := second.GetSession()
+	if secondSession.Token != firstSession.Token || activeSessions(server) != 1 {
+		t.Errorf("Expected the session to be resumed, got %d sessions", activeSessions(server))
+	}
+	if _, err := second.Service.Systems(); err != nil {
+		t.Errorf("Error using the resumed session: %s", err)
+	}
+
+	// Other users do not share the session.
+	if cached, err := cache.Load(ts.URL, "operator"); cached != nil || err != nil {
+		t.Errorf("Expected no session for another user, got: %v %v", cached, err)
+	}
+
+	// The service ended the session, so a new one is created and cached.
+	server.Inject(mockserver.Fault{Times: 1, ExpireSession: true})
+	_, _ = first.Get("/redfish/v1/Systems") //nolint:bodyclose
+	third, err := Connect(config)
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+	thirdSession, _ := third.GetSession()
+	cached, err := cache.Load(ts.URL, "admin")
+	if thirdSession.Token == firstSession.Token || err != nil || cached.Token != thirdSession.Token {
+		t.Errorf("Expected a new cached session, got: %v %v", cached, err)
+	}
+
+	third.Logout()
+	if cached, err := cache.Load(ts.URL, "admin"); cached != nil || err != nil {
+		t.Errorf("Expected the session to be removed from the cache, got: %v %v", cached, err)
+	}
+}
+
+// TestSessionCacheErrors tests cache and service errors when resuming
+// sessions.
+func TestSessionCacheErrors(t *testing.T) {
+	server, ts := sessionCacheServer(t)
+	name := filepath.Join(t.TempDir(), "sessions.json")
+	cache := &FileSessionCache{Path: name}
+	config := ClientConfig{Endpoint: ts.URL, Username: "admin", Password: "password", SessionCache: cache}
+
+	// A corrupt cache does not prevent connecting.
+	if err := os.WriteFile(name, []byte("{"), 0o600); err != nil {
+		t.Fatalf("Error writing cache: %s", err)
+	}
+	if _, err := Connect(config); err != nil {
+		t.Fatalf("Error connecting with a corrupt cache: %s", err)
+	}
+	if activeSessions(server) != 1 {
+		t.Errorf("Expected a new session, got %d sessions", activeSessions(server))
+	}
+
+	if err := os.Remove(name); err != nil {
+		t.Fatalf("Error removing cache: %s", err)
+	}
+	first, err := Connect(config)
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+	firstSession, _ := first.GetSession()
+
+	// Other errors checking the session keep it cached.
+	server.Inject(mockserver.Fault{Method: "GET", Path: firstSession.ID, Times: 1, StatusCode: 503})
+	if _, err := Connect(config); err == nil {
+		t.Error("Expected the service error")
+	}
+	cached, err := cache.Load(ts.URL, "admin")
+	if err != nil || cached == nil || cached.Token != firstSession.Token {
+		t.Errorf("Expected the session to be kept, got: %v %v", cached, err)
+	}
+}
+
+// TestDeleteCachedSessions tests deleting sessions left behind.
+func TestDeleteCachedSessions(t *testing.T) {
+	server, ts := sessionCacheServer(t)
+	cache := &FileSessionCache{Path: filepath.Join(t.TempDir(), "sessions.json")}
+
+	if _, err := Connect(ClientConfig{Endpoint: ts.URL, Username: "admin", Password: "password", SessionCache: cache}); err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+	// Sessions the service already ended, one of them recently used.
+	for _, username := range []string{"expired", "recent"} {
+		cached := &CachedSession{Endpoint: ts.URL, Username: username, Session: Session{ID: mockSessionsURI + "/" + username, Token: "expired"}}
+		if username == "recent" {
+			cached.LastUsed = time.Now()
+		}
+		if err := cache.Store(cached); err != nil {
+			t.Fatalf("Error storing session: %s", err)
+		}
+	}
+
+	err := DeleteCachedSessions(context.Background(), ClientConfig{SessionCache: cache}, time.Hour)
+	if err != nil {
+		t.Fatalf("Error deleting sessions: %s", err)
+	}
+	if activeSessions(server) != 1 {
+		t.Errorf("Expected recent sessions to be kept, got %d sessions", activeSessions(server))
+	}
+
+	err = DeleteCachedSessions(context.Background(), ClientConfig{SessionCache: cache}, 0)
+	if err != nil {
+		t.Fatalf("Error deleting sessions: %s", err)
+	}
+	sessions, err := cache.List()
+	if err != nil || len(sessions) != 0 || activeSessions(server) != 0 {
+		t.Errorf("Expected all sessions to be deleted, got: %v %v, %d sessions", sessions, err, activeSessions(server))
+	}
+}
@@ -5,6 +5,7 @@
 package swordfish
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/stmcginnis/gofish/redfish"
@@ -119,44 +120,48 @@ func (capacitysource *CapacitySource) UnmarshalJSON(b []byte) error {
 
 // GetCapacitySource will get a CapacitySource instance from the service.
 func GetCapacitySource(c common.Client, uri string) (*CapacitySource, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetCapacitySourceContext(context.Background(), c, uri)
+}
 
+// GetCapacitySourceContext is the same as GetCapacitySource, but uses ctx for
+// the request.
+func GetCapacitySourceContext(ctx context.Context, c common.Client, uri string) (*CapacitySource, error) {
+	return GetCapacitySourceWithOptions(ctx, c, uri, nil)
+}
+
+// GetCapacitySourceWithOptions is the same as GetCapacitySourceContext, but
+// adds the query options opts to the request.
+func GetCapacitySourceWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*CapacitySource, error) {
 	var capacitysource CapacitySource
-	err = json.NewDecoder(resp.Body).Decode(&capacitysource)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &capacitysource); err != nil {
 		return nil, err
 	}
-
-	capacitysource.SetClient(c)
 	return &capacitysource, nil
 }
 
 // ListReferencedCapacitySources gets the collection of CapacitySources from
 // a provided reference.
 func ListReferencedCapacitySources(c common.Client, link string) ([]*CapacitySource, error) {
+	return ListReferencedCapacitySourcesContext(context.Background(), c, link)
+}
+
+// ListReferencedCapacitySourcesContext is the same as
+// ListReferencedCapacitySources, but uses ctx for the requests.
+func ListReferencedCapacitySourcesContext(ctx context.Context, c common.Client, link string) ([]*CapacitySource, error) {
+	return ListReferencedCapacitySourcesWithOptions(ctx, c, link, nil)
+}
+
+// ListReferencedCapacitySourcesWithOptions is the same as
+// ListReferencedCapacitySourcesContext, but adds the query options opts to the
+// requests.
+func ListReferencedCapacitySourcesWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*CapacitySource, error) {
 	var result []*CapacitySource
 	if link == "" {
 		return result, nil
 	}
 
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	for _, capSourceLink := range links.ItemLinks {
-		capSource, err := GetCapacitySource(c, capSourceLink)
-		if err != nil {
-			return result, err
-		}
-		result = append(result, capSource)
-	}
-
-	return result, nil
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
 
 // ProvidedClassOfService gets the ClassOfService from the ProvidingDrives,
@@ -5,6 +5,7 @@
 package swordfish
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/stmcginnis/gofish/common"
@@ -87,158 +88,86 @@ func (classofservice *ClassOfService) UnmarshalJSON(b []byte) error {
 
 // GetClassOfService will get a ClassOfService instance from the service.
 func GetClassOfService(c common.Client, uri string) (*ClassOfService, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetClassOfServiceContext(context.Background(), c, uri)
+}
 
+// GetClassOfServiceContext is the same as GetClassOfService, but uses ctx for
+// the request.
+func GetClassOfServiceContext(ctx context.Context, c common.Client, uri string) (*ClassOfService, error) {
+	return GetClassOfServiceWithOptions(ctx, c, uri, nil)
+}
+
+// GetClassOfServiceWithOptions is the same as GetClassOfServiceContext, but
+// adds the query options opts to the request.
+func GetClassOfServiceWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*ClassOfService, error) {
 	var classofservice ClassOfService
-	err = json.NewDecoder(resp.Body).Decode(&classofservice)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &classofservice); err != nil {
 		return nil, err
 	}
-
-	classofservice.SetClient(c)
 	return &classofservice, nil
 }
 
 // ListReferencedClassOfServices gets the collection of ClassOfService from
 // a provided reference.
-func ListReferencedClassOfServices(c common.Client, link string) ([]*ClassOfService, error) { //nolint:dupl
-	var result []*ClassOfService
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedClassOfServices(c common.Client, link string) ([]*ClassOfService, error) {
+	return ListReferencedClassOfServicesContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, classofserviceLink := range links.ItemLinks {
-		classofservice, err := GetClassOfService(c, classofserviceLink)
-		if err != nil {
-			collectionError.Failures[classofserviceLink] = err
-		} else {
-			result = append(result, classofservice)
-		}
-	}
+// ListReferencedClassOfServicesContext is the same as
+// ListReferencedClassOfServices, but uses ctx for the requests.
+func ListReferencedClassOfServicesContext(ctx context.Context, c common.Client, link string) ([]*ClassOfService, error) {
+	return ListReferencedClassOfServicesWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedClassOfServicesWithOptions is the same as
+// ListReferencedClassOfServicesContext, but adds the query options opts to the
+// requests.
+func ListReferencedClassOfServicesWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*ClassOfService, error) { //nolint:dupl
+	var result []*ClassOfService
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
 
 // DataProtectionLinesOfServices gets the DataProtectionLinesOfService that are
 // part of this ClassOfService.
 func (classofservice *ClassOfService) DataProtectionLinesOfServices() ([]*DataProtectionLineOfService, error) {
 	var result []*DataProtectionLineOfService
-
-	collectionError := common.NewCollectionError()
-	for _, dpLosLink := range classofservice.dataProtectionLinesOfService {
-		dpLos, err := GetDataProtectionLineOfService(classofservice.Client, dpLosLink)
-		if err != nil {
-			collectionError.Failures[dpLosLink] = err
-		} else {
-			result = append(result, dpLos)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	err := common.GetObjects(context.Background(), classofservice.Client, classofservice.dataProtectionLinesOfService, &result)
+	return result, err
 }
 
 // DataSecurityLinesOfServices gets the DataSecurityLinesOfService that are
 // part of this ClassOfService.
 func (classofservice *ClassOfService) DataSecurityLinesOfServices() ([]*DataSecurityLineOfService, error) {
 	var result []*DataSecurityLineOfService
-
-	collectionError := common.NewCollectionError()
-	for _, dsLosLink := range classofservice.dataSecurityLinesOfService {
-		dsLos, err := GetDataSecurityLineOfService(classofservice.Client, dsLosLink)
-		if err != nil {
-			collectionError.Failures[dsLosLink] = err
-		} else {
-			result = append(result, dsLos)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	err := common.GetObjects(context.Background(), classofservice.Client, classofservice.dataSecurityLinesOfService, &result)
+	return result, err
 }
 
 // DataStorageLinesOfServices gets the DataStorageLinesOfService that are
 // part of this ClassOfService.
 func (classofservice *ClassOfService) DataStorageLinesOfServices() ([]*DataStorageLineOfService, error) {
 	var result []*DataStorageLineOfService
-
-	collectionError := common.NewCollectionError()
-	for _, dsLosLink := range classofservice.dataStorageLinesOfService {
-		dsLos, err := GetDataStorageLineOfService(classofservice.Client, dsLosLink)
-		if err != nil {
-			collectionError.Failures[dsLosLink] = err
-		} else {
-			result = append(result, dsLos)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	err := common.GetObjects(context.Background(), classofservice.Client, classofservice.dataStorageLinesOfService, &result)
+	return result, err
 }
 
 // IOConnectivityLinesOfServices gets the IOConnectivityLinesOfService that are
 // part of this ClassOfService.
 func (classofservice *ClassOfService) IOConnectivityLinesOfServices() ([]*IOConnectivityLineOfService, error) {
 	var result []*IOConnectivityLineOfService
-
-	collectionError := common.NewCollectionError()
-	for _, ioLosLink := range classofservice.dataSecurityLinesOfService {
-		ioLos, err := GetIOConnectivityLineOfService(classofservice.Client, ioLosLink)
-		if err != nil {
-			collectionError.Failures[ioLosLink] = err
-		} else {
-			result = append(result, ioLos)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	err := common.GetObjects(context.Background(), classofservice.Client, classofservice.dataSecurityLinesOfService, &result)
+	return result, err
 }
 
 // IOPerformanceLinesOfServices gets the IOPerformanceLinesOfService that are
 // part of this ClassOfService.
 func (classofservice *ClassOfService) IOPerformanceLinesOfServices() ([]*IOPerformanceLineOfService, error) {
 	var result []*IOPerformanceLineOfService
-
-	collectionError := common.NewCollectionError()
-	for _, ioLosLink := range classofservice.dataSecurityLinesOfService {
-		ioLos, err := GetIOPerformanceLineOfService(classofservice.Client, ioLosLink)
-		if err != nil {
-			collectionError.Failures[ioLosLink] = err
-		} else {
-			result = append(result, ioLos)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	err := common.GetObjects(context.Background(), classofservice.Client, classofservice.dataSecurityLinesOfService, &result)
+	return result, err
 }
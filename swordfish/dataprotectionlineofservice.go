@@ -5,7 +5,7 @@
 package swordfish
 
 import (
-	"encoding/json"
+	"context"
 
 	"github.com/stmcginnis/gofish/common"
 )
@@ -61,50 +61,49 @@ type DataProtectionLineOfService struct {
 
 // GetDataProtectionLineOfService will get a DataProtectionLineOfService instance from the service.
 func GetDataProtectionLineOfService(c common.Client, uri string) (*DataProtectionLineOfService, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetDataProtectionLineOfServiceContext(context.Background(), c, uri)
+}
+
+// GetDataProtectionLineOfServiceContext is the same as
+// GetDataProtectionLineOfService, but uses ctx for the request.
+func GetDataProtectionLineOfServiceContext(ctx context.Context, c common.Client, uri string) (*DataProtectionLineOfService, error) {
+	return GetDataProtectionLineOfServiceWithOptions(ctx, c, uri, nil)
+}
 
+// GetDataProtectionLineOfServiceWithOptions is the same as
+// GetDataProtectionLineOfServiceContext, but adds the query options opts to the
+// request.
+func GetDataProtectionLineOfServiceWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*DataProtectionLineOfService, error) {
 	var dataprotectionlineofservice DataProtectionLineOfService
-	err = json.NewDecoder(resp.Body).Decode(&dataprotectionlineofservice)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &dataprotectionlineofservice); err != nil {
 		return nil, err
 	}
-
-	dataprotectionlineofservice.SetClient(c)
 	return &dataprotectionlineofservice, nil
 }
 
 // ListReferencedDataProtectionLineOfServices gets the collection of DataProtectionLineOfService from
 // a provided reference.
-func ListReferencedDataProtectionLineOfServices(c common.Client, link string) ([]*DataProtectionLineOfService, error) { //nolint:dupl
-	var result []*DataProtectionLineOfService
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedDataProtectionLineOfServices(c common.Client, link string) ([]*DataProtectionLineOfService, error) {
+	return ListReferencedDataProtectionLineOfServicesContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, dataprotectionlineofserviceLink := range links.ItemLinks {
-		dataprotectionlineofservice, err := GetDataProtectionLineOfService(c, dataprotectionlineofserviceLink)
-		if err != nil {
-			collectionError.Failures[dataprotectionlineofserviceLink] = err
-		} else {
-			result = append(result, dataprotectionlineofservice)
-		}
-	}
+// ListReferencedDataProtectionLineOfServicesContext is the same as
+// ListReferencedDataProtectionLineOfServices, but uses ctx for the requests.
+func ListReferencedDataProtectionLineOfServicesContext(ctx context.Context, c common.Client, link string) ([]*DataProtectionLineOfService, error) {
+	return ListReferencedDataProtectionLineOfServicesWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedDataProtectionLineOfServicesWithOptions is the same as
+// ListReferencedDataProtectionLineOfServicesContext, but adds the query options
+// opts to the requests.
+func ListReferencedDataProtectionLineOfServicesWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*DataProtectionLineOfService, error) { //nolint:dupl
+	var result []*DataProtectionLineOfService
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
 
 // ReplicaRequest is a request for a replica.
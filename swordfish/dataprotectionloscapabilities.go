@@ -5,6 +5,7 @@
 package swordfish
 
 import (
+	"context"
 	"encoding/json"
 	"reflect"
 
@@ -177,90 +178,61 @@ func (dataprotectionloscapabilities *DataProtectionLoSCapabilities) Update() err
 
 // GetDataProtectionLoSCapabilities will get a DataProtectionLoSCapabilities instance from the service.
 func GetDataProtectionLoSCapabilities(c common.Client, uri string) (*DataProtectionLoSCapabilities, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetDataProtectionLoSCapabilitiesContext(context.Background(), c, uri)
+}
 
+// GetDataProtectionLoSCapabilitiesContext is the same as
+// GetDataProtectionLoSCapabilities, but uses ctx for the request.
+func GetDataProtectionLoSCapabilitiesContext(ctx context.Context, c common.Client, uri string) (*DataProtectionLoSCapabilities, error) {
+	return GetDataProtectionLoSCapabilitiesWithOptions(ctx, c, uri, nil)
+}
+
+// GetDataProtectionLoSCapabilitiesWithOptions is the same as
+// GetDataProtectionLoSCapabilitiesContext, but adds the query options opts to
+// the request.
+func GetDataProtectionLoSCapabilitiesWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*DataProtectionLoSCapabilities, error) {
 	var dataprotectionloscapabilities DataProtectionLoSCapabilities
-	err = json.NewDecoder(resp.Body).Decode(&dataprotectionloscapabilities)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &dataprotectionloscapabilities); err != nil {
 		return nil, err
 	}
-
-	dataprotectionloscapabilities.SetClient(c)
 	return &dataprotectionloscapabilities, nil
 }
 
 // ListReferencedDataProtectionLoSCapabilities gets the collection of DataProtectionLoSCapabilities from
 // a provided reference.
-func ListReferencedDataProtectionLoSCapabilities(c common.Client, link string) ([]*DataProtectionLoSCapabilities, error) { //nolint:dupl
-	var result []*DataProtectionLoSCapabilities
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedDataProtectionLoSCapabilities(c common.Client, link string) ([]*DataProtectionLoSCapabilities, error) {
+	return ListReferencedDataProtectionLoSCapabilitiesContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, dataprotectionloscapabilitiesLink := range links.ItemLinks {
-		dataprotectionloscapabilities, err := GetDataProtectionLoSCapabilities(c, dataprotectionloscapabilitiesLink)
-		if err != nil {
-			collectionError.Failures[dataprotectionloscapabilitiesLink] = err
-		} else {
-			result = append(result, dataprotectionloscapabilities)
-		}
-	}
+// ListReferencedDataProtectionLoSCapabilitiesContext is the same as
+// ListReferencedDataProtectionLoSCapabilities, but uses ctx for the requests.
+func ListReferencedDataProtectionLoSCapabilitiesContext(ctx context.Context, c common.Client, link string) ([]*DataProtectionLoSCapabilities, error) {
+	return ListReferencedDataProtectionLoSCapabilitiesWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedDataProtectionLoSCapabilitiesWithOptions is the same as
+// ListReferencedDataProtectionLoSCapabilitiesContext, but adds the query
+// options opts to the requests.
+func ListReferencedDataProtectionLoSCapabilitiesWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*DataProtectionLoSCapabilities, error) { //nolint:dupl
+	var result []*DataProtectionLoSCapabilities
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
 
 // SupportedReplicaOptions gets the support replica ClassesOfService.
 func (dataprotectionloscapabilities *DataProtectionLoSCapabilities) SupportedReplicaOptions() ([]*ClassOfService, error) {
 	var result []*ClassOfService
-
-	collectionError := common.NewCollectionError()
-	for _, link := range dataprotectionloscapabilities.supportedReplicaOptions {
-		classOfService, err := GetClassOfService(dataprotectionloscapabilities.Client, link)
-		if err != nil {
-			collectionError.Failures[link] = err
-		} else {
-			result = append(result, classOfService)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	err := common.GetObjects(context.Background(), dataprotectionloscapabilities.Client, dataprotectionloscapabilities.supportedReplicaOptions, &result)
+	return result, err
 }
 
 // SupportedLinesOfService gets the supported lines of service.
 func (dataprotectionloscapabilities *DataProtectionLoSCapabilities) SupportedLinesOfService() ([]*DataProtectionLineOfService, error) {
 	var result []*DataProtectionLineOfService
-
-	collectionError := common.NewCollectionError()
-	for _, link := range dataprotectionloscapabilities.supportedLinesOfService {
-		lineOfService, err := GetDataProtectionLineOfService(dataprotectionloscapabilities.Client, link)
-		if err != nil {
-			collectionError.Failures[link] = err
-		} else {
-			result = append(result, lineOfService)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	err := common.GetObjects(context.Background(), dataprotectionloscapabilities.Client, dataprotectionloscapabilities.supportedLinesOfService, &result)
+	return result, err
 }
@@ -5,7 +5,7 @@
 package swordfish
 
 import (
-	"encoding/json"
+	"context"
 
 	"github.com/stmcginnis/gofish/common"
 )
@@ -47,42 +47,47 @@ type DataSecurityLineOfService struct {
 
 // GetDataSecurityLineOfService will get a DataSecurityLineOfService instance from the service.
 func GetDataSecurityLineOfService(c common.Client, uri string) (*DataSecurityLineOfService, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetDataSecurityLineOfServiceContext(context.Background(), c, uri)
+}
 
+// GetDataSecurityLineOfServiceContext is the same as
+// GetDataSecurityLineOfService, but uses ctx for the request.
+func GetDataSecurityLineOfServiceContext(ctx context.Context, c common.Client, uri string) (*DataSecurityLineOfService, error) {
+	return GetDataSecurityLineOfServiceWithOptions(ctx, c, uri, nil)
+}
+
+// GetDataSecurityLineOfServiceWithOptions is the same as
+// GetDataSecurityLineOfServiceContext, but adds the query options opts to the
+// request.
+func GetDataSecurityLineOfServiceWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*DataSecurityLineOfService, error) {
 	var datasecuritylineofservice DataSecurityLineOfService
-	err = json.NewDecoder(resp.Body).Decode(&datasecuritylineofservice)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &datasecuritylineofservice); err != nil {
 		return nil, err
 	}
-
-	datasecuritylineofservice.SetClient(c)
 	return &datasecuritylineofservice, nil
 }
 
 // ListReferencedDataSecurityLineOfServices gets the collection of DataSecurityLineOfService from
 // a provided reference.
 func ListReferencedDataSecurityLineOfServices(c common.Client, link string) ([]*DataSecurityLineOfService, error) {
+	return ListReferencedDataSecurityLineOfServicesContext(context.Background(), c, link)
+}
+
+// ListReferencedDataSecurityLineOfServicesContext is the same as
+// ListReferencedDataSecurityLineOfServices, but uses ctx for the requests.
+func ListReferencedDataSecurityLineOfServicesContext(ctx context.Context, c common.Client, link string) ([]*DataSecurityLineOfService, error) {
+	return ListReferencedDataSecurityLineOfServicesWithOptions(ctx, c, link, nil)
+}
+
+// ListReferencedDataSecurityLineOfServicesWithOptions is the same as
+// ListReferencedDataSecurityLineOfServicesContext, but adds the query options
+// opts to the requests.
+func ListReferencedDataSecurityLineOfServicesWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*DataSecurityLineOfService, error) {
 	var result []*DataSecurityLineOfService
 	if link == "" {
 		return result, nil
 	}
 
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	for _, datasecuritylineofserviceLink := range links.ItemLinks {
-		datasecuritylineofservice, err := GetDataSecurityLineOfService(c, datasecuritylineofserviceLink)
-		if err != nil {
-			return result, err
-		}
-		result = append(result, datasecuritylineofservice)
-	}
-
-	return result, nil
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
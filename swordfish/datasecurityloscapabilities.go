@@ -5,7 +5,7 @@
 package swordfish
 
 import (
-	"encoding/json"
+	"context"
 
 	"github.com/stmcginnis/gofish/common"
 )
@@ -201,48 +201,47 @@ type DataSecurityLoSCapabilities struct {
 
 // GetDataSecurityLoSCapabilities will get a DataSecurityLoSCapabilities instance from the service.
 func GetDataSecurityLoSCapabilities(c common.Client, uri string) (*DataSecurityLoSCapabilities, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetDataSecurityLoSCapabilitiesContext(context.Background(), c, uri)
+}
+
+// GetDataSecurityLoSCapabilitiesContext is the same as
+// GetDataSecurityLoSCapabilities, but uses ctx for the request.
+func GetDataSecurityLoSCapabilitiesContext(ctx context.Context, c common.Client, uri string) (*DataSecurityLoSCapabilities, error) {
+	return GetDataSecurityLoSCapabilitiesWithOptions(ctx, c, uri, nil)
+}
 
+// GetDataSecurityLoSCapabilitiesWithOptions is the same as
+// GetDataSecurityLoSCapabilitiesContext, but adds the query options opts to the
+// request.
+func GetDataSecurityLoSCapabilitiesWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*DataSecurityLoSCapabilities, error) {
 	var datasecurityloscapabilities DataSecurityLoSCapabilities
-	err = json.NewDecoder(resp.Body).Decode(&datasecurityloscapabilities)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &datasecurityloscapabilities); err != nil {
 		return nil, err
 	}
-
-	datasecurityloscapabilities.SetClient(c)
 	return &datasecurityloscapabilities, nil
 }
 
 // ListReferencedDataSecurityLoSCapabilities gets the collection of DataSecurityLoSCapabilities from
 // a provided reference.
-func ListReferencedDataSecurityLoSCapabilities(c common.Client, link string) ([]*DataSecurityLoSCapabilities, error) { //nolint:dupl
-	var result []*DataSecurityLoSCapabilities
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedDataSecurityLoSCapabilities(c common.Client, link string) ([]*DataSecurityLoSCapabilities, error) {
+	return ListReferencedDataSecurityLoSCapabilitiesContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, datasecurityloscapabilitiesLink := range links.ItemLinks {
-		datasecurityloscapabilities, err := GetDataSecurityLoSCapabilities(c, datasecurityloscapabilitiesLink)
-		if err != nil {
-			collectionError.Failures[datasecurityloscapabilitiesLink] = err
-		} else {
-			result = append(result, datasecurityloscapabilities)
-		}
-	}
+// ListReferencedDataSecurityLoSCapabilitiesContext is the same as
+// ListReferencedDataSecurityLoSCapabilities, but uses ctx for the requests.
+func ListReferencedDataSecurityLoSCapabilitiesContext(ctx context.Context, c common.Client, link string) ([]*DataSecurityLoSCapabilities, error) {
+	return ListReferencedDataSecurityLoSCapabilitiesWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedDataSecurityLoSCapabilitiesWithOptions is the same as
+// ListReferencedDataSecurityLoSCapabilitiesContext, but adds the query options
+// opts to the requests.
+func ListReferencedDataSecurityLoSCapabilitiesWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*DataSecurityLoSCapabilities, error) { //nolint:dupl
+	var result []*DataSecurityLoSCapabilities
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
@@ -5,6 +5,7 @@
 package swordfish
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/stmcginnis/gofish/common"
@@ -70,48 +71,47 @@ func (datastoragelineofservice *DataStorageLineOfService) UnmarshalJSON(b []byte
 
 // GetDataStorageLineOfService will get a DataStorageLineOfService instance from the service.
 func GetDataStorageLineOfService(c common.Client, uri string) (*DataStorageLineOfService, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetDataStorageLineOfServiceContext(context.Background(), c, uri)
+}
+
+// GetDataStorageLineOfServiceContext is the same as
+// GetDataStorageLineOfService, but uses ctx for the request.
+func GetDataStorageLineOfServiceContext(ctx context.Context, c common.Client, uri string) (*DataStorageLineOfService, error) {
+	return GetDataStorageLineOfServiceWithOptions(ctx, c, uri, nil)
+}
 
+// GetDataStorageLineOfServiceWithOptions is the same as
+// GetDataStorageLineOfServiceContext, but adds the query options opts to the
+// request.
+func GetDataStorageLineOfServiceWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*DataStorageLineOfService, error) {
 	var datastoragelineofservice DataStorageLineOfService
-	err = json.NewDecoder(resp.Body).Decode(&datastoragelineofservice)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &datastoragelineofservice); err != nil {
 		return nil, err
 	}
-
-	datastoragelineofservice.SetClient(c)
 	return &datastoragelineofservice, nil
 }
 
 // ListReferencedDataStorageLineOfServices gets the collection of DataStorageLineOfService from
 // a provided reference.
-func ListReferencedDataStorageLineOfServices(c common.Client, link string) ([]*DataStorageLineOfService, error) { //nolint:dupl
-	var result []*DataStorageLineOfService
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedDataStorageLineOfServices(c common.Client, link string) ([]*DataStorageLineOfService, error) {
+	return ListReferencedDataStorageLineOfServicesContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, datastoragelineofserviceLink := range links.ItemLinks {
-		datastoragelineofservice, err := GetDataStorageLineOfService(c, datastoragelineofserviceLink)
-		if err != nil {
-			collectionError.Failures[datastoragelineofserviceLink] = err
-		} else {
-			result = append(result, datastoragelineofservice)
-		}
-	}
+// ListReferencedDataStorageLineOfServicesContext is the same as
+// ListReferencedDataStorageLineOfServices, but uses ctx for the requests.
+func ListReferencedDataStorageLineOfServicesContext(ctx context.Context, c common.Client, link string) ([]*DataStorageLineOfService, error) {
+	return ListReferencedDataStorageLineOfServicesWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedDataStorageLineOfServicesWithOptions is the same as
+// ListReferencedDataStorageLineOfServicesContext, but adds the query options
+// opts to the requests.
+func ListReferencedDataStorageLineOfServicesWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*DataStorageLineOfService, error) { //nolint:dupl
+	var result []*DataStorageLineOfService
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
@@ -5,6 +5,7 @@
 package swordfish
 
 import (
+	"context"
 	"encoding/json"
 	"reflect"
 
@@ -130,48 +131,47 @@ func (datastorageloscapabilities *DataStorageLoSCapabilities) Update() error {
 
 // GetDataStorageLoSCapabilities will get a DataStorageLoSCapabilities instance from the service.
 func GetDataStorageLoSCapabilities(c common.Client, uri string) (*DataStorageLoSCapabilities, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetDataStorageLoSCapabilitiesContext(context.Background(), c, uri)
+}
+
+// GetDataStorageLoSCapabilitiesContext is the same as
+// GetDataStorageLoSCapabilities, but uses ctx for the request.
+func GetDataStorageLoSCapabilitiesContext(ctx context.Context, c common.Client, uri string) (*DataStorageLoSCapabilities, error) {
+	return GetDataStorageLoSCapabilitiesWithOptions(ctx, c, uri, nil)
+}
 
+// GetDataStorageLoSCapabilitiesWithOptions is the same as
+// GetDataStorageLoSCapabilitiesContext, but adds the query options opts to the
+// request.
+func GetDataStorageLoSCapabilitiesWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*DataStorageLoSCapabilities, error) {
 	var datastorageloscapabilities DataStorageLoSCapabilities
-	err = json.NewDecoder(resp.Body).Decode(&datastorageloscapabilities)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &datastorageloscapabilities); err != nil {
 		return nil, err
 	}
-
-	datastorageloscapabilities.SetClient(c)
 	return &datastorageloscapabilities, nil
 }
 
 // ListReferencedDataStorageLoSCapabilities gets the collection of DataStorageLoSCapabilities from
 // a provided reference.
-func ListReferencedDataStorageLoSCapabilities(c common.Client, link string) ([]*DataStorageLoSCapabilities, error) { //nolint:dupl
-	var result []*DataStorageLoSCapabilities
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedDataStorageLoSCapabilities(c common.Client, link string) ([]*DataStorageLoSCapabilities, error) {
+	return ListReferencedDataStorageLoSCapabilitiesContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, datastorageloscapabilitiesLink := range links.ItemLinks {
-		datastorageloscapabilities, err := GetDataStorageLoSCapabilities(c, datastorageloscapabilitiesLink)
-		if err != nil {
-			collectionError.Failures[datastorageloscapabilitiesLink] = err
-		} else {
-			result = append(result, datastorageloscapabilities)
-		}
-	}
+// ListReferencedDataStorageLoSCapabilitiesContext is the same as
+// ListReferencedDataStorageLoSCapabilities, but uses ctx for the requests.
+func ListReferencedDataStorageLoSCapabilitiesContext(ctx context.Context, c common.Client, link string) ([]*DataStorageLoSCapabilities, error) {
+	return ListReferencedDataStorageLoSCapabilitiesWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedDataStorageLoSCapabilitiesWithOptions is the same as
+// ListReferencedDataStorageLoSCapabilitiesContext, but adds the query options
+// opts to the requests.
+func ListReferencedDataStorageLoSCapabilitiesWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*DataStorageLoSCapabilities, error) { //nolint:dupl
+	var result []*DataStorageLoSCapabilities
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
@@ -5,6 +5,7 @@
 package swordfish
 
 import (
+	"context"
 	"encoding/json"
 	"reflect"
 
@@ -130,50 +131,48 @@ func (endpointgroup *EndpointGroup) Update() error {
 
 // GetEndpointGroup will get a EndpointGroup instance from the service.
 func GetEndpointGroup(c common.Client, uri string) (*EndpointGroup, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetEndpointGroupContext(context.Background(), c, uri)
+}
+
+// GetEndpointGroupContext is the same as GetEndpointGroup, but uses ctx for the
+// request.
+func GetEndpointGroupContext(ctx context.Context, c common.Client, uri string) (*EndpointGroup, error) {
+	return GetEndpointGroupWithOptions(ctx, c, uri, nil)
+}
 
+// GetEndpointGroupWithOptions is the same as GetEndpointGroupContext, but adds
+// the query options opts to the request.
+func GetEndpointGroupWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*EndpointGroup, error) {
 	var endpointgroup EndpointGroup
-	err = json.NewDecoder(resp.Body).Decode(&endpointgroup)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &endpointgroup); err != nil {
 		return nil, err
 	}
-
-	endpointgroup.SetClient(c)
 	return &endpointgroup, nil
 }
 
 // ListReferencedEndpointGroups gets the collection of EndpointGroup from
 // a provided reference.
-func ListReferencedEndpointGroups(c common.Client, link string) ([]*EndpointGroup, error) { //nolint:dupl
-	var result []*EndpointGroup
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedEndpointGroups(c common.Client, link string) ([]*EndpointGroup, error) {
+	return ListReferencedEndpointGroupsContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, endpointgroupLink := range links.ItemLinks {
-		endpointgroup, err := GetEndpointGroup(c, endpointgroupLink)
-		if err != nil {
-			collectionError.Failures[endpointgroupLink] = err
-		} else {
-			result = append(result, endpointgroup)
-		}
-	}
+// ListReferencedEndpointGroupsContext is the same as
+// ListReferencedEndpointGroups, but uses ctx for the requests.
+func ListReferencedEndpointGroupsContext(ctx context.Context, c common.Client, link string) ([]*EndpointGroup, error) {
+	return ListReferencedEndpointGroupsWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedEndpointGroupsWithOptions is the same as
+// ListReferencedEndpointGroupsContext, but adds the query options opts to the
+// requests.
+func ListReferencedEndpointGroupsWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*EndpointGroup, error) { //nolint:dupl
+	var result []*EndpointGroup
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
 
 // Endpoints gets the group's endpoints.
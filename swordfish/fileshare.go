@@ -5,6 +5,7 @@
 package swordfish
 
 import (
+	"context"
 	"encoding/json"
 	"reflect"
 
@@ -158,44 +159,48 @@ func (fileshare *FileShare) Update() error {
 
 // GetFileShare will get a FileShare instance from the service.
 func GetFileShare(c common.Client, uri string) (*FileShare, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetFileShareContext(context.Background(), c, uri)
+}
 
+// GetFileShareContext is the same as GetFileShare, but uses ctx for the
+// request.
+func GetFileShareContext(ctx context.Context, c common.Client, uri string) (*FileShare, error) {
+	return GetFileShareWithOptions(ctx, c, uri, nil)
+}
+
+// GetFileShareWithOptions is the same as GetFileShareContext, but adds the
+// query options opts to the request.
+func GetFileShareWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*FileShare, error) {
 	var fileshare FileShare
-	err = json.NewDecoder(resp.Body).Decode(&fileshare)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &fileshare); err != nil {
 		return nil, err
 	}
-
-	fileshare.SetClient(c)
 	return &fileshare, nil
 }
 
 // ListReferencedFileShares gets the collection of FileShare from a provided
 // reference.
 func ListReferencedFileShares(c common.Client, link string) ([]*FileShare, error) {
+	return ListReferencedFileSharesContext(context.Background(), c, link)
+}
+
+// ListReferencedFileSharesContext is the same as ListReferencedFileShares, but
+// uses ctx for the requests.
+func ListReferencedFileSharesContext(ctx context.Context, c common.Client, link string) ([]*FileShare, error) {
+	return ListReferencedFileSharesWithOptions(ctx, c, link, nil)
+}
+
+// ListReferencedFileSharesWithOptions is the same as
+// ListReferencedFileSharesContext, but adds the query options opts to the
+// requests.
+func ListReferencedFileSharesWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*FileShare, error) {
 	var result []*FileShare
 	if link == "" {
 		return result, nil
 	}
 
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	for _, fileshareLink := range links.ItemLinks {
-		fileshare, err := GetFileShare(c, fileshareLink)
-		if err != nil {
-			return result, err
-		}
-		result = append(result, fileshare)
-	}
-
-	return result, nil
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
 
 // ClassOfService gets the file share's class of service.
@@ -5,6 +5,7 @@
 package swordfish
 
 import (
+	"context"
 	"encoding/json"
 	"reflect"
 
@@ -259,50 +260,48 @@ func (filesystem *FileSystem) Update() error {
 
 // GetFileSystem will get a FileSystem instance from the service.
 func GetFileSystem(c common.Client, uri string) (*FileSystem, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetFileSystemContext(context.Background(), c, uri)
+}
 
+// GetFileSystemContext is the same as GetFileSystem, but uses ctx for the
+// request.
+func GetFileSystemContext(ctx context.Context, c common.Client, uri string) (*FileSystem, error) {
+	return GetFileSystemWithOptions(ctx, c, uri, nil)
+}
+
+// GetFileSystemWithOptions is the same as GetFileSystemContext, but adds the
+// query options opts to the request.
+func GetFileSystemWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*FileSystem, error) {
 	var filesystem FileSystem
-	err = json.NewDecoder(resp.Body).Decode(&filesystem)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &filesystem); err != nil {
 		return nil, err
 	}
-
-	filesystem.SetClient(c)
 	return &filesystem, nil
 }
 
 // ListReferencedFileSystems gets the collection of FileSystem from
 // a provided reference.
-func ListReferencedFileSystems(c common.Client, link string) ([]*FileSystem, error) { //nolint:dupl
-	var result []*FileSystem
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedFileSystems(c common.Client, link string) ([]*FileSystem, error) {
+	return ListReferencedFileSystemsContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, filesystemLink := range links.ItemLinks {
-		filesystem, err := GetFileSystem(c, filesystemLink)
-		if err != nil {
-			collectionError.Failures[filesystemLink] = err
-		} else {
-			result = append(result, filesystem)
-		}
-	}
+// ListReferencedFileSystemsContext is the same as ListReferencedFileSystems,
+// but uses ctx for the requests.
+func ListReferencedFileSystemsContext(ctx context.Context, c common.Client, link string) ([]*FileSystem, error) {
+	return ListReferencedFileSystemsWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedFileSystemsWithOptions is the same as
+// ListReferencedFileSystemsContext, but adds the query options opts to the
+// requests.
+func ListReferencedFileSystemsWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*FileSystem, error) { //nolint:dupl
+	var result []*FileSystem
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
 
 // ExportedShares gets the exported file shares for this file system.
@@ -322,20 +321,6 @@ func (filesystem *FileSystem) ClassOfService() (*ClassOfService, error) {
 // SpareResourceSets gets the spare resource sets used for this filesystem.
 func (filesystem *FileSystem) SpareResourceSets() ([]*SpareResourceSet, error) {
 	var result []*SpareResourceSet
-
-	collectionError := common.NewCollectionError()
-	for _, rsLink := range filesystem.spareResourceSets {
-		rs, err := GetSpareResourceSet(filesystem.Client, rsLink)
-		if err != nil {
-			collectionError.Failures[rsLink] = err
-		} else {
-			result = append(result, rs)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	err := common.GetObjects(context.Background(), filesystem.Client, filesystem.spareResourceSets, &result)
+	return result, err
 }
@@ -5,7 +5,7 @@
 package swordfish
 
 import (
-	"encoding/json"
+	"context"
 
 	"github.com/stmcginnis/gofish/common"
 )
@@ -37,48 +37,47 @@ type IOConnectivityLineOfService struct {
 
 // GetIOConnectivityLineOfService will get a IOConnectivityLineOfService instance from the service.
 func GetIOConnectivityLineOfService(c common.Client, uri string) (*IOConnectivityLineOfService, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetIOConnectivityLineOfServiceContext(context.Background(), c, uri)
+}
+
+// GetIOConnectivityLineOfServiceContext is the same as
+// GetIOConnectivityLineOfService, but uses ctx for the request.
+func GetIOConnectivityLineOfServiceContext(ctx context.Context, c common.Client, uri string) (*IOConnectivityLineOfService, error) {
+	return GetIOConnectivityLineOfServiceWithOptions(ctx, c, uri, nil)
+}
 
+// GetIOConnectivityLineOfServiceWithOptions is the same as
+// GetIOConnectivityLineOfServiceContext, but adds the query options opts to the
+// request.
+func GetIOConnectivityLineOfServiceWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*IOConnectivityLineOfService, error) {
 	var ioconnectivitylineofservice IOConnectivityLineOfService
-	err = json.NewDecoder(resp.Body).Decode(&ioconnectivitylineofservice)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &ioconnectivitylineofservice); err != nil {
 		return nil, err
 	}
-
-	ioconnectivitylineofservice.SetClient(c)
 	return &ioconnectivitylineofservice, nil
 }
 
 // ListReferencedIOConnectivityLineOfServices gets the collection of IOConnectivityLineOfService from
 // a provided reference.
-func ListReferencedIOConnectivityLineOfServices(c common.Client, link string) ([]*IOConnectivityLineOfService, error) { //nolint:dupl
-	var result []*IOConnectivityLineOfService
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedIOConnectivityLineOfServices(c common.Client, link string) ([]*IOConnectivityLineOfService, error) {
+	return ListReferencedIOConnectivityLineOfServicesContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, ioconnectivitylineofserviceLink := range links.ItemLinks {
-		ioconnectivitylineofservice, err := GetIOConnectivityLineOfService(c, ioconnectivitylineofserviceLink)
-		if err != nil {
-			collectionError.Failures[ioconnectivitylineofserviceLink] = err
-		} else {
-			result = append(result, ioconnectivitylineofservice)
-		}
-	}
+// ListReferencedIOConnectivityLineOfServicesContext is the same as
+// ListReferencedIOConnectivityLineOfServices, but uses ctx for the requests.
+func ListReferencedIOConnectivityLineOfServicesContext(ctx context.Context, c common.Client, link string) ([]*IOConnectivityLineOfService, error) {
+	return ListReferencedIOConnectivityLineOfServicesWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedIOConnectivityLineOfServicesWithOptions is the same as
+// ListReferencedIOConnectivityLineOfServicesContext, but adds the query options
+// opts to the requests.
+func ListReferencedIOConnectivityLineOfServicesWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*IOConnectivityLineOfService, error) { //nolint:dupl
+	var result []*IOConnectivityLineOfService
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
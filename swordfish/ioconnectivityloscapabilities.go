@@ -5,6 +5,7 @@
 package swordfish
 
 import (
+	"context"
 	"encoding/json"
 	"reflect"
 
@@ -90,48 +91,47 @@ func (ioconnectivityloscapabilities *IOConnectivityLoSCapabilities) Update() err
 // GetIOConnectivityLoSCapabilities will get a IOConnectivityLoSCapabilities
 // instance from the service.
 func GetIOConnectivityLoSCapabilities(c common.Client, uri string) (*IOConnectivityLoSCapabilities, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetIOConnectivityLoSCapabilitiesContext(context.Background(), c, uri)
+}
+
+// GetIOConnectivityLoSCapabilitiesContext is the same as
+// GetIOConnectivityLoSCapabilities, but uses ctx for the request.
+func GetIOConnectivityLoSCapabilitiesContext(ctx context.Context, c common.Client, uri string) (*IOConnectivityLoSCapabilities, error) {
+	return GetIOConnectivityLoSCapabilitiesWithOptions(ctx, c, uri, nil)
+}
 
+// GetIOConnectivityLoSCapabilitiesWithOptions is the same as
+// GetIOConnectivityLoSCapabilitiesContext, but adds the query options opts to
+// the request.
+func GetIOConnectivityLoSCapabilitiesWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*IOConnectivityLoSCapabilities, error) {
 	var ioconnectivityloscapabilities IOConnectivityLoSCapabilities
-	err = json.NewDecoder(resp.Body).Decode(&ioconnectivityloscapabilities)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &ioconnectivityloscapabilities); err != nil {
 		return nil, err
 	}
-
-	ioconnectivityloscapabilities.SetClient(c)
 	return &ioconnectivityloscapabilities, nil
 }
 
 // ListReferencedIOConnectivityLoSCapabilitiess gets the collection of
 // IOConnectivityLoSCapabilities from a provided reference.
-func ListReferencedIOConnectivityLoSCapabilitiess(c common.Client, link string) ([]*IOConnectivityLoSCapabilities, error) { //nolint:dupl
-	var result []*IOConnectivityLoSCapabilities
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedIOConnectivityLoSCapabilitiess(c common.Client, link string) ([]*IOConnectivityLoSCapabilities, error) {
+	return ListReferencedIOConnectivityLoSCapabilitiessContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, ioconnectivityloscapabilitiesLink := range links.ItemLinks {
-		ioconnectivityloscapabilities, err := GetIOConnectivityLoSCapabilities(c, ioconnectivityloscapabilitiesLink)
-		if err != nil {
-			collectionError.Failures[ioconnectivityloscapabilitiesLink] = err
-		} else {
-			result = append(result, ioconnectivityloscapabilities)
-		}
-	}
+// ListReferencedIOConnectivityLoSCapabilitiessContext is the same as
+// ListReferencedIOConnectivityLoSCapabilitiess, but uses ctx for the requests.
+func ListReferencedIOConnectivityLoSCapabilitiessContext(ctx context.Context, c common.Client, link string) ([]*IOConnectivityLoSCapabilities, error) {
+	return ListReferencedIOConnectivityLoSCapabilitiessWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedIOConnectivityLoSCapabilitiessWithOptions is the same as
+// ListReferencedIOConnectivityLoSCapabilitiessContext, but adds the query
+// options opts to the requests.
+func ListReferencedIOConnectivityLoSCapabilitiessWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*IOConnectivityLoSCapabilities, error) { //nolint:dupl
+	var result []*IOConnectivityLoSCapabilities
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
@@ -5,7 +5,7 @@
 package swordfish
 
 import (
-	"encoding/json"
+	"context"
 
 	"github.com/stmcginnis/gofish/common"
 )
@@ -48,48 +48,47 @@ type IOPerformanceLineOfService struct {
 
 // GetIOPerformanceLineOfService will get a IOPerformanceLineOfService instance from the service.
 func GetIOPerformanceLineOfService(c common.Client, uri string) (*IOPerformanceLineOfService, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetIOPerformanceLineOfServiceContext(context.Background(), c, uri)
+}
+
+// GetIOPerformanceLineOfServiceContext is the same as
+// GetIOPerformanceLineOfService, but uses ctx for the request.
+func GetIOPerformanceLineOfServiceContext(ctx context.Context, c common.Client, uri string) (*IOPerformanceLineOfService, error) {
+	return GetIOPerformanceLineOfServiceWithOptions(ctx, c, uri, nil)
+}
 
+// GetIOPerformanceLineOfServiceWithOptions is the same as
+// GetIOPerformanceLineOfServiceContext, but adds the query options opts to the
+// request.
+func GetIOPerformanceLineOfServiceWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*IOPerformanceLineOfService, error) {
 	var ioperformancelineofservice IOPerformanceLineOfService
-	err = json.NewDecoder(resp.Body).Decode(&ioperformancelineofservice)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &ioperformancelineofservice); err != nil {
 		return nil, err
 	}
-
-	ioperformancelineofservice.SetClient(c)
 	return &ioperformancelineofservice, nil
 }
 
 // ListReferencedIOPerformanceLineOfServices gets the collection of IOPerformanceLineOfService from
 // a provided reference.
-func ListReferencedIOPerformanceLineOfServices(c common.Client, link string) ([]*IOPerformanceLineOfService, error) { //nolint:dupl
-	var result []*IOPerformanceLineOfService
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedIOPerformanceLineOfServices(c common.Client, link string) ([]*IOPerformanceLineOfService, error) {
+	return ListReferencedIOPerformanceLineOfServicesContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, ioperformancelineofserviceLink := range links.ItemLinks {
-		ioperformancelineofservice, err := GetIOPerformanceLineOfService(c, ioperformancelineofserviceLink)
-		if err != nil {
-			collectionError.Failures[ioperformancelineofserviceLink] = err
-		} else {
-			result = append(result, ioperformancelineofservice)
-		}
-	}
+// ListReferencedIOPerformanceLineOfServicesContext is the same as
+// ListReferencedIOPerformanceLineOfServices, but uses ctx for the requests.
+func ListReferencedIOPerformanceLineOfServicesContext(ctx context.Context, c common.Client, link string) ([]*IOPerformanceLineOfService, error) {
+	return ListReferencedIOPerformanceLineOfServicesWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedIOPerformanceLineOfServicesWithOptions is the same as
+// ListReferencedIOPerformanceLineOfServicesContext, but adds the query options
+// opts to the requests.
+func ListReferencedIOPerformanceLineOfServicesWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*IOPerformanceLineOfService, error) { //nolint:dupl
+	var result []*IOPerformanceLineOfService
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
@@ -5,6 +5,7 @@
 package swordfish
 
 import (
+	"context"
 	"encoding/json"
 	"reflect"
 
@@ -118,50 +119,49 @@ func (ioperformanceloscapabilities *IOPerformanceLoSCapabilities) Update() error
 
 // GetIOPerformanceLoSCapabilities will get a IOPerformanceLoSCapabilities instance from the service.
 func GetIOPerformanceLoSCapabilities(c common.Client, uri string) (*IOPerformanceLoSCapabilities, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetIOPerformanceLoSCapabilitiesContext(context.Background(), c, uri)
+}
+
+// GetIOPerformanceLoSCapabilitiesContext is the same as
+// GetIOPerformanceLoSCapabilities, but uses ctx for the request.
+func GetIOPerformanceLoSCapabilitiesContext(ctx context.Context, c common.Client, uri string) (*IOPerformanceLoSCapabilities, error) {
+	return GetIOPerformanceLoSCapabilitiesWithOptions(ctx, c, uri, nil)
+}
 
+// GetIOPerformanceLoSCapabilitiesWithOptions is the same as
+// GetIOPerformanceLoSCapabilitiesContext, but adds the query options opts to
+// the request.
+func GetIOPerformanceLoSCapabilitiesWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*IOPerformanceLoSCapabilities, error) {
 	var ioperformanceloscapabilities IOPerformanceLoSCapabilities
-	err = json.NewDecoder(resp.Body).Decode(&ioperformanceloscapabilities)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &ioperformanceloscapabilities); err != nil {
 		return nil, err
 	}
-
-	ioperformanceloscapabilities.SetClient(c)
 	return &ioperformanceloscapabilities, nil
 }
 
 // ListReferencedIOPerformanceLoSCapabilitiess gets the collection of IOPerformanceLoSCapabilities from
 // a provided reference.
-func ListReferencedIOPerformanceLoSCapabilitiess(c common.Client, link string) ([]*IOPerformanceLoSCapabilities, error) { //nolint:dupl
-	var result []*IOPerformanceLoSCapabilities
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedIOPerformanceLoSCapabilitiess(c common.Client, link string) ([]*IOPerformanceLoSCapabilities, error) {
+	return ListReferencedIOPerformanceLoSCapabilitiessContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, ioperformanceloscapabilitiesLink := range links.ItemLinks {
-		ioperformanceloscapabilities, err := GetIOPerformanceLoSCapabilities(c, ioperformanceloscapabilitiesLink)
-		if err != nil {
-			collectionError.Failures[ioperformanceloscapabilitiesLink] = err
-		} else {
-			result = append(result, ioperformanceloscapabilities)
-		}
-	}
+// ListReferencedIOPerformanceLoSCapabilitiessContext is the same as
+// ListReferencedIOPerformanceLoSCapabilitiess, but uses ctx for the requests.
+func ListReferencedIOPerformanceLoSCapabilitiessContext(ctx context.Context, c common.Client, link string) ([]*IOPerformanceLoSCapabilities, error) {
+	return ListReferencedIOPerformanceLoSCapabilitiessWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedIOPerformanceLoSCapabilitiessWithOptions is the same as
+// ListReferencedIOPerformanceLoSCapabilitiessContext, but adds the query
+// options opts to the requests.
+func ListReferencedIOPerformanceLoSCapabilitiessWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*IOPerformanceLoSCapabilities, error) { //nolint:dupl
+	var result []*IOPerformanceLoSCapabilities
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
 
 // IOWorkload is used to describe an IO Workload.
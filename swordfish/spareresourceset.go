@@ -5,6 +5,7 @@
 package swordfish
 
 import (
+	"context"
 	"encoding/json"
 	"reflect"
 
@@ -108,50 +109,48 @@ func (spareresourceset *SpareResourceSet) Update() error {
 
 // GetSpareResourceSet will get a SpareResourceSet instance from the service.
 func GetSpareResourceSet(c common.Client, uri string) (*SpareResourceSet, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetSpareResourceSetContext(context.Background(), c, uri)
+}
+
+// GetSpareResourceSetContext is the same as GetSpareResourceSet, but uses ctx
+// for the request.
+func GetSpareResourceSetContext(ctx context.Context, c common.Client, uri string) (*SpareResourceSet, error) {
+	return GetSpareResourceSetWithOptions(ctx, c, uri, nil)
+}
 
+// GetSpareResourceSetWithOptions is the same as GetSpareResourceSetContext, but
+// adds the query options opts to the request.
+func GetSpareResourceSetWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*SpareResourceSet, error) {
 	var spareresourceset SpareResourceSet
-	err = json.NewDecoder(resp.Body).Decode(&spareresourceset)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &spareresourceset); err != nil {
 		return nil, err
 	}
-
-	spareresourceset.SetClient(c)
 	return &spareresourceset, nil
 }
 
 // ListReferencedSpareResourceSets gets the collection of SpareResourceSet from
 // a provided reference.
-func ListReferencedSpareResourceSets(c common.Client, link string) ([]*SpareResourceSet, error) { //nolint:dupl
-	var result []*SpareResourceSet
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedSpareResourceSets(c common.Client, link string) ([]*SpareResourceSet, error) {
+	return ListReferencedSpareResourceSetsContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, spareresourcesetLink := range links.ItemLinks {
-		spareresourceset, err := GetSpareResourceSet(c, spareresourcesetLink)
-		if err != nil {
-			collectionError.Failures[spareresourcesetLink] = err
-		} else {
-			result = append(result, spareresourceset)
-		}
-	}
+// ListReferencedSpareResourceSetsContext is the same as
+// ListReferencedSpareResourceSets, but uses ctx for the requests.
+func ListReferencedSpareResourceSetsContext(ctx context.Context, c common.Client, link string) ([]*SpareResourceSet, error) {
+	return ListReferencedSpareResourceSetsWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedSpareResourceSetsWithOptions is the same as
+// ListReferencedSpareResourceSetsContext, but adds the query options opts to
+// the requests.
+func ListReferencedSpareResourceSetsWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*SpareResourceSet, error) { //nolint:dupl
+	var result []*SpareResourceSet
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
 
 // ReplacementSpareSets gets other spare sets that can be utilized to replenish
@@ -5,6 +5,7 @@
 package swordfish
 
 import (
+	"context"
 	"encoding/json"
 	"reflect"
 
@@ -208,92 +209,62 @@ func (storagegroup *StorageGroup) Update() error {
 
 // GetStorageGroup will get a StorageGroup instance from the service.
 func GetStorageGroup(c common.Client, uri string) (*StorageGroup, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetStorageGroupContext(context.Background(), c, uri)
+}
 
+// GetStorageGroupContext is the same as GetStorageGroup, but uses ctx for the
+// request.
+func GetStorageGroupContext(ctx context.Context, c common.Client, uri string) (*StorageGroup, error) {
+	return GetStorageGroupWithOptions(ctx, c, uri, nil)
+}
+
+// GetStorageGroupWithOptions is the same as GetStorageGroupContext, but adds
+// the query options opts to the request.
+func GetStorageGroupWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*StorageGroup, error) {
 	var storagegroup StorageGroup
-	err = json.NewDecoder(resp.Body).Decode(&storagegroup)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &storagegroup); err != nil {
 		return nil, err
 	}
-
-	storagegroup.SetClient(c)
 	return &storagegroup, nil
 }
 
 // ListReferencedStorageGroups gets the collection of StorageGroup from
 // a provided reference.
-func ListReferencedStorageGroups(c common.Client, link string) ([]*StorageGroup, error) { //nolint:dupl
-	var result []*StorageGroup
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedStorageGroups(c common.Client, link string) ([]*StorageGroup, error) {
+	return ListReferencedStorageGroupsContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, storagegroupLink := range links.ItemLinks {
-		storagegroup, err := GetStorageGroup(c, storagegroupLink)
-		if err != nil {
-			collectionError.Failures[storagegroupLink] = err
-		} else {
-			result = append(result, storagegroup)
-		}
-	}
+// ListReferencedStorageGroupsContext is the same as
+// ListReferencedStorageGroups, but uses ctx for the requests.
+func ListReferencedStorageGroupsContext(ctx context.Context, c common.Client, link string) ([]*StorageGroup, error) {
+	return ListReferencedStorageGroupsWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedStorageGroupsWithOptions is the same as
+// ListReferencedStorageGroupsContext, but adds the query options opts to the
+// requests.
+func ListReferencedStorageGroupsWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*StorageGroup, error) { //nolint:dupl
+	var result []*StorageGroup
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
 
 // ChildStorageGroups gets child groups of this group.
 func (storagegroup *StorageGroup) ChildStorageGroups() ([]*StorageGroup, error) {
 	var result []*StorageGroup
-
-	collectionError := common.NewCollectionError()
-	for _, sgLink := range storagegroup.childStorageGroups {
-		sg, err := GetStorageGroup(storagegroup.Client, sgLink)
-		if err != nil {
-			collectionError.Failures[sgLink] = err
-		} else {
-			result = append(result, sg)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	err := common.GetObjects(context.Background(), storagegroup.Client, storagegroup.childStorageGroups, &result)
+	return result, err
 }
 
 // ParentStorageGroups gets parent groups of this group.
 func (storagegroup *StorageGroup) ParentStorageGroups() ([]*StorageGroup, error) {
 	var result []*StorageGroup
-
-	collectionError := common.NewCollectionError()
-	for _, sgLink := range storagegroup.parentStorageGroups {
-		sg, err := GetStorageGroup(storagegroup.Client, sgLink)
-		if err != nil {
-			collectionError.Failures[sgLink] = err
-		} else {
-			result = append(result, sg)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	err := common.GetObjects(context.Background(), storagegroup.Client, storagegroup.parentStorageGroups, &result)
+	return result, err
 }
 
 // ClassOfService gets the ClassOfService that all storage in this StorageGroup
@@ -318,7 +289,7 @@ type MappedVolume struct {
 // ClientEndpointGroups.  The property VolumesAreExposed shall be set to true
 // when this action is completed.
 func (storagegroup *StorageGroup) ExposeVolumes() error {
-	_, err := storagegroup.Client.Post(storagegroup.exposeVolumesTarget, nil)
+	err := common.PostAction(context.Background(), &storagegroup.Entity, storagegroup.exposeVolumesTarget, nil)
 	if err == nil {
 		// Only set to exposed if no error. Calling expose when already exposed
 		// could fail so we don't want to indicate they are not exposed.
@@ -331,7 +302,7 @@ func (storagegroup *StorageGroup) ExposeVolumes() error {
 // named in the ClientEndpointGroups. The property VolumesAreExposed shall be
 // set to false when this action is completed.
 func (storagegroup *StorageGroup) HideVolumes() error {
-	_, err := storagegroup.Client.Post(storagegroup.hideVolumesTarget, nil)
+	err := common.PostAction(context.Background(), &storagegroup.Entity, storagegroup.hideVolumesTarget, nil)
 	if err == nil {
 		storagegroup.VolumesAreExposed = false
 	}
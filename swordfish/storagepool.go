@@ -5,6 +5,7 @@
 package swordfish
 
 import (
+	"context"
 	"encoding/json"
 	"reflect"
 
@@ -172,94 +173,64 @@ func (storagepool *StoragePool) Update() error {
 
 // GetStoragePool will get a StoragePool instance from the service.
 func GetStoragePool(c common.Client, uri string) (*StoragePool, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetStoragePoolContext(context.Background(), c, uri)
+}
+
+// GetStoragePoolContext is the same as GetStoragePool, but uses ctx for the
+// request.
+func GetStoragePoolContext(ctx context.Context, c common.Client, uri string) (*StoragePool, error) {
+	return GetStoragePoolWithOptions(ctx, c, uri, nil)
+}
 
+// GetStoragePoolWithOptions is the same as GetStoragePoolContext, but adds the
+// query options opts to the request.
+func GetStoragePoolWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*StoragePool, error) {
 	var storagepool StoragePool
-	err = json.NewDecoder(resp.Body).Decode(&storagepool)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &storagepool); err != nil {
 		return nil, err
 	}
-
-	storagepool.SetClient(c)
 	return &storagepool, nil
 }
 
 // ListReferencedStoragePools gets the collection of StoragePool from
 // a provided reference.
-func ListReferencedStoragePools(c common.Client, link string) ([]*StoragePool, error) { //nolint:dupl
-	var result []*StoragePool
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedStoragePools(c common.Client, link string) ([]*StoragePool, error) {
+	return ListReferencedStoragePoolsContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, storagepoolLink := range links.ItemLinks {
-		storagepool, err := GetStoragePool(c, storagepoolLink)
-		if err != nil {
-			collectionError.Failures[storagepoolLink] = err
-		} else {
-			result = append(result, storagepool)
-		}
-	}
+// ListReferencedStoragePoolsContext is the same as ListReferencedStoragePools,
+// but uses ctx for the requests.
+func ListReferencedStoragePoolsContext(ctx context.Context, c common.Client, link string) ([]*StoragePool, error) {
+	return ListReferencedStoragePoolsWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedStoragePoolsWithOptions is the same as
+// ListReferencedStoragePoolsContext, but adds the query options opts to the
+// requests.
+func ListReferencedStoragePoolsWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*StoragePool, error) { //nolint:dupl
+	var result []*StoragePool
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
 
 // DedicatedSpareDrives gets the Drive entities which are currently assigned as
 // a dedicated spare and are able to support this StoragePool.
 func (storagepool *StoragePool) DedicatedSpareDrives() ([]*redfish.Drive, error) {
 	var result []*redfish.Drive
-
-	collectionError := common.NewCollectionError()
-	for _, driveLink := range storagepool.dedicatedSpareDrives {
-		drive, err := redfish.GetDrive(storagepool.Client, driveLink)
-		if err != nil {
-			collectionError.Failures[driveLink] = err
-		} else {
-			result = append(result, drive)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	err := common.GetObjects(context.Background(), storagepool.Client, storagepool.dedicatedSpareDrives, &result)
+	return result, err
 }
 
 // SpareResourceSets gets resources that may be utilized to replace the capacity
 // provided by a failed resource having a compatible type.
 func (storagepool *StoragePool) SpareResourceSets() ([]*SpareResourceSet, error) {
 	var result []*SpareResourceSet
-
-	collectionError := common.NewCollectionError()
-	for _, srsLink := range storagepool.spareResourceSets {
-		srs, err := GetSpareResourceSet(storagepool.Client, srsLink)
-		if err != nil {
-			collectionError.Failures[srsLink] = err
-		} else {
-			result = append(result, srs)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	err := common.GetObjects(context.Background(), storagepool.Client, storagepool.spareResourceSets, &result)
+	return result, err
 }
 
 // AllocatedPools gets the storage pools allocated from this storage pool.
@@ -275,22 +246,8 @@ func (storagepool *StoragePool) AllocatedVolumes() ([]*Volume, error) {
 // CapacitySources gets space allocations to this pool.
 func (storagepool *StoragePool) CapacitySources() ([]*CapacitySource, error) {
 	var result []*CapacitySource
-
-	collectionError := common.NewCollectionError()
-	for _, capLink := range storagepool.capacitySources {
-		capacity, err := GetCapacitySource(storagepool.Client, capLink)
-		if err != nil {
-			collectionError.Failures[capLink] = err
-		} else {
-			result = append(result, capacity)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	err := common.GetObjects(context.Background(), storagepool.Client, storagepool.capacitySources, &result)
+	return result, err
 }
 
 // ClassesOfService gets references to all classes of service supported by this
@@ -5,6 +5,7 @@
 package swordfish
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/stmcginnis/gofish/common"
@@ -445,48 +446,46 @@ type StorageReplicaInfo struct {
 
 // GetStorageReplicaInfo will get a StorageReplicaInfo instance from the service.
 func GetStorageReplicaInfo(c common.Client, uri string) (*StorageReplicaInfo, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetStorageReplicaInfoContext(context.Background(), c, uri)
+}
+
+// GetStorageReplicaInfoContext is the same as GetStorageReplicaInfo, but uses
+// ctx for the request.
+func GetStorageReplicaInfoContext(ctx context.Context, c common.Client, uri string) (*StorageReplicaInfo, error) {
+	return GetStorageReplicaInfoWithOptions(ctx, c, uri, nil)
+}
 
+// GetStorageReplicaInfoWithOptions is the same as GetStorageReplicaInfoContext,
+// but adds the query options opts to the request.
+func GetStorageReplicaInfoWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*StorageReplicaInfo, error) {
 	var storagereplicainfo StorageReplicaInfo
-	err = json.NewDecoder(resp.Body).Decode(&storagereplicainfo)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &storagereplicainfo); err != nil {
 		return nil, err
 	}
-
-	storagereplicainfo.SetClient(c)
 	return &storagereplicainfo, nil
 }
 
 // ListReferencedStorageReplicaInfos gets the collection of StorageReplicaInfo from
 // a provided reference.
-func ListReferencedStorageReplicaInfos(c common.Client, link string) ([]*StorageReplicaInfo, error) { //nolint:dupl
-	var result []*StorageReplicaInfo
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedStorageReplicaInfos(c common.Client, link string) ([]*StorageReplicaInfo, error) {
+	return ListReferencedStorageReplicaInfosContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, storagereplicainfoLink := range links.ItemLinks {
-		storagereplicainfo, err := GetStorageReplicaInfo(c, storagereplicainfoLink)
-		if err != nil {
-			collectionError.Failures[storagereplicainfoLink] = err
-		} else {
-			result = append(result, storagereplicainfo)
-		}
-	}
+// ListReferencedStorageReplicaInfosContext is the same as
+// ListReferencedStorageReplicaInfos, but uses ctx for the requests.
+func ListReferencedStorageReplicaInfosContext(ctx context.Context, c common.Client, link string) ([]*StorageReplicaInfo, error) {
+	return ListReferencedStorageReplicaInfosWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedStorageReplicaInfosWithOptions is the same as
+// ListReferencedStorageReplicaInfosContext, but adds the query options opts to
+// the requests.
+func ListReferencedStorageReplicaInfosWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*StorageReplicaInfo, error) { //nolint:dupl
+	var result []*StorageReplicaInfo
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
@@ -5,6 +5,7 @@
 package swordfish
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/stmcginnis/gofish/common"
@@ -162,46 +163,44 @@ func (storageservice *StorageService) UnmarshalJSON(b []byte) error {
 
 // GetStorageService will get a StorageService instance from the service.
 func GetStorageService(c common.Client, uri string) (*StorageService, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetStorageServiceContext(context.Background(), c, uri)
+}
 
+// GetStorageServiceContext is the same as GetStorageService, but uses ctx for
+// the request.
+func GetStorageServiceContext(ctx context.Context, c common.Client, uri string) (*StorageService, error) {
+	return GetStorageServiceWithOptions(ctx, c, uri, nil)
+}
+
+// GetStorageServiceWithOptions is the same as GetStorageServiceContext, but
+// adds the query options opts to the request.
+func GetStorageServiceWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*StorageService, error) {
 	var storageservice StorageService
-	err = json.NewDecoder(resp.Body).Decode(&storageservice)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &storageservice); err != nil {
 		return nil, err
 	}
-
-	storageservice.SetClient(c)
 	return &storageservice, nil
 }
 
 // ListReferencedStorageServices gets the collection of StorageService from
 // a provided reference.
 func ListReferencedStorageServices(c common.Client, link string) ([]*StorageService, error) {
-	var result []*StorageService
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, storageserviceLink := range links.ItemLinks {
-		storageservice, err := GetStorageService(c, storageserviceLink)
-		if err != nil {
-			collectionError.Failures[storageserviceLink] = err
-		} else {
-			result = append(result, storageservice)
-		}
-	}
+	return ListReferencedStorageServicesContext(context.Background(), c, link)
+}
 
-	if collectionError.Empty() {
-		return result, nil
-	}
+// ListReferencedStorageServicesContext is the same as
+// ListReferencedStorageServices, but uses ctx for the requests.
+func ListReferencedStorageServicesContext(ctx context.Context, c common.Client, link string) ([]*StorageService, error) {
+	return ListReferencedStorageServicesWithOptions(ctx, c, link, nil)
+}
 
-	return result, collectionError
+// ListReferencedStorageServicesWithOptions is the same as
+// ListReferencedStorageServicesContext, but adds the query options opts to the
+// requests.
+func ListReferencedStorageServicesWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*StorageService, error) {
+	var result []*StorageService
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
 
 // ClassesOfService gets the storage service's classes of service.
@@ -283,65 +282,23 @@ func (storageservice *StorageService) IOPerformanceLoSCapabilities() (*IOPerform
 // Redundancy gets the redundancy information for the storage subsystem.
 func (storageservice *StorageService) Redundancy() ([]*redfish.Redundancy, error) {
 	var result []*redfish.Redundancy
-
-	collectionError := common.NewCollectionError()
-	for _, redundancyLink := range storageservice.redundancy {
-		redundancy, err := redfish.GetRedundancy(storageservice.Client, redundancyLink)
-		if err != nil {
-			collectionError.Failures[redundancyLink] = err
-		} else {
-			result = append(result, redundancy)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	err := common.GetObjects(context.Background(), storageservice.Client, storageservice.redundancy, &result)
+	return result, err
 }
 
 // SpareResourceSets gets resources that may be utilized to replace the capacity
 // provided by a failed resource having a compatible type.
 func (storageservice *StorageService) SpareResourceSets() ([]*SpareResourceSet, error) {
 	var result []*SpareResourceSet
-
-	collectionError := common.NewCollectionError()
-	for _, srsLink := range storageservice.spareResourceSets {
-		srs, err := GetSpareResourceSet(storageservice.Client, srsLink)
-		if err != nil {
-			collectionError.Failures[srsLink] = err
-		} else {
-			result = append(result, srs)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	err := common.GetObjects(context.Background(), storageservice.Client, storageservice.spareResourceSets, &result)
+	return result, err
 }
 
 // StorageGroups gets the storage groups that are a part of this storage service.
 func (storageservice *StorageService) StorageGroups() ([]*StorageGroup, error) {
 	var result []*StorageGroup
-
-	collectionError := common.NewCollectionError()
-	for _, sgLink := range storageservice.spareResourceSets {
-		sg, err := GetStorageGroup(storageservice.Client, sgLink)
-		if err != nil {
-			collectionError.Failures[sgLink] = err
-		} else {
-			result = append(result, sg)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	err := common.GetObjects(context.Background(), storageservice.Client, storageservice.spareResourceSets, &result)
+	return result, err
 }
 
 // Volumes gets the volumes that are a part of this storage service.
@@ -356,6 +313,5 @@ func (storageservice *StorageService) SetEncryptionKey(key string) error {
 	}
 	t := temp{EncryptionKey: key}
 
-	_, err := storageservice.Client.Post(storageservice.setEncryptionKeyTarget, t)
-	return err
+	return common.PostAction(context.Background(), &storageservice.Entity, storageservice.setEncryptionKeyTarget, t)
 }
@@ -5,7 +5,7 @@
 package swordfish
 
 import (
-	"encoding/json"
+	"context"
 
 	"github.com/stmcginnis/gofish/common"
 	"github.com/stmcginnis/gofish/redfish"
@@ -18,43 +18,41 @@ type StorageSystem struct {
 
 // GetStorageSystem will get a StorageSystem instance from the Swordfish service.
 func GetStorageSystem(c common.Client, uri string) (*StorageSystem, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetStorageSystemContext(context.Background(), c, uri)
+}
 
+// GetStorageSystemContext is the same as GetStorageSystem, but uses ctx for the
+// request.
+func GetStorageSystemContext(ctx context.Context, c common.Client, uri string) (*StorageSystem, error) {
+	return GetStorageSystemWithOptions(ctx, c, uri, nil)
+}
+
+// GetStorageSystemWithOptions is the same as GetStorageSystemContext, but adds
+// the query options opts to the request.
+func GetStorageSystemWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*StorageSystem, error) {
 	var storageSystem StorageSystem
-	err = json.NewDecoder(resp.Body).Decode(&storageSystem)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &storageSystem); err != nil {
 		return nil, err
 	}
-
-	storageSystem.SetClient(c)
 	return &storageSystem, nil
 }
 
 // ListReferencedStorageSystems gets the collection of StorageSystems.
 func ListReferencedStorageSystems(c common.Client, link string) ([]*StorageSystem, error) {
-	var result []*StorageSystem
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, storageSystemLink := range links.ItemLinks {
-		storageSystem, err := GetStorageSystem(c, storageSystemLink)
-		if err != nil {
-			collectionError.Failures[storageSystemLink] = err
-		} else {
-			result = append(result, storageSystem)
-		}
-	}
+	return ListReferencedStorageSystemsContext(context.Background(), c, link)
+}
 
-	if collectionError.Empty() {
-		return result, nil
-	}
+// ListReferencedStorageSystemsContext is the same as
+// ListReferencedStorageSystems, but uses ctx for the requests.
+func ListReferencedStorageSystemsContext(ctx context.Context, c common.Client, link string) ([]*StorageSystem, error) {
+	return ListReferencedStorageSystemsWithOptions(ctx, c, link, nil)
+}
 
-	return result, collectionError
+// ListReferencedStorageSystemsWithOptions is the same as
+// ListReferencedStorageSystemsContext, but adds the query options opts to the
+// requests.
+func ListReferencedStorageSystemsWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*StorageSystem, error) {
+	var result []*StorageSystem
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
@@ -5,6 +5,7 @@
 package swordfish
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"reflect"
@@ -548,49 +549,45 @@ func (volume *Volume) Update() error {
 
 // GetVolume will get a Volume instance from the service.
 func GetVolume(c common.Client, uri string) (*Volume, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return GetVolumeContext(context.Background(), c, uri)
+}
+
+// GetVolumeContext is the same as GetVolume, but uses ctx for the request.
+func GetVolumeContext(ctx context.Context, c common.Client, uri string) (*Volume, error) {
+	return GetVolumeWithOptions(ctx, c, uri, nil)
+}
 
+// GetVolumeWithOptions is the same as GetVolumeContext, but adds the query
+// options opts to the request.
+func GetVolumeWithOptions(ctx context.Context, c common.Client, uri string, opts *common.QueryOptions) (*Volume, error) {
 	var volume Volume
-	err = json.NewDecoder(resp.Body).Decode(&volume)
-	if err != nil {
+	if err := common.GetObjectWithOptions(ctx, c, uri, opts, &volume); err != nil {
 		return nil, err
 	}
-
-	volume.SetClient(c)
 	return &volume, nil
 }
 
 // ListReferencedVolumes gets the collection of Volume from a provided reference.
-func ListReferencedVolumes(c common.Client, link string) ([]*Volume, error) { //nolint:dupl
-	var result []*Volume
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+func ListReferencedVolumes(c common.Client, link string) ([]*Volume, error) {
+	return ListReferencedVolumesContext(context.Background(), c, link)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, volumeLink := range links.ItemLinks {
-		volume, err := GetVolume(c, volumeLink)
-		if err != nil {
-			collectionError.Failures[volumeLink] = err
-		} else {
-			result = append(result, volume)
-		}
-	}
+// ListReferencedVolumesContext is the same as ListReferencedVolumes, but uses
+// ctx for the requests.
+func ListReferencedVolumesContext(ctx context.Context, c common.Client, link string) ([]*Volume, error) {
+	return ListReferencedVolumesWithOptions(ctx, c, link, nil)
+}
 
-	if collectionError.Empty() {
+// ListReferencedVolumesWithOptions is the same as ListReferencedVolumesContext,
+// but adds the query options opts to the requests.
+func ListReferencedVolumesWithOptions(ctx context.Context, c common.Client, link string, opts *common.QueryOptions) ([]*Volume, error) { //nolint:dupl
+	var result []*Volume
+	if link == "" {
 		return result, nil
 	}
 
-	return result, collectionError
+	err := common.GetCollectionObjectsWithOptions(ctx, c, link, opts, &result)
+	return result, err
 }
 
 // ClassOfService gets the class of service that this storage volume conforms to.
@@ -605,22 +602,8 @@ func (volume *Volume) ClassOfService() (*ClassOfService, error) {
 // getDrives gets a set of referenced drives.
 func (volume *Volume) getDrives(links []string) ([]*redfish.Drive, error) {
 	var result []*redfish.Drive
-
-	collectionError := common.NewCollectionError()
-	for _, driveLink := range links {
-		drive, err := redfish.GetDrive(volume.Client, driveLink)
-		if err != nil {
-			collectionError.Failures[driveLink] = err
-		} else {
-			result = append(result, drive)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	err := common.GetObjects(context.Background(), volume.Client, links, &result)
+	return result, err
 }
 
 // DedicatedSpareDrives references the Drives that are dedicated spares for this
@@ -637,64 +620,22 @@ func (volume *Volume) Drives() ([]*redfish.Drive, error) {
 // SpareResourceSets gets the spare resources that can be used for this volume.
 func (volume *Volume) SpareResourceSets() ([]*SpareResourceSet, error) {
 	var result []*SpareResourceSet
-
-	collectionError := common.NewCollectionError()
-	for _, srsLink := range volume.spareResourceSets {
-		srs, err := GetSpareResourceSet(volume.Client, srsLink)
-		if err != nil {
-			collectionError.Failures[srsLink] = err
-		} else {
-			result = append(result, srs)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	err := common.GetObjects(context.Background(), volume.Client, volume.spareResourceSets, &result)
+	return result, err
 }
 
 // StorageGroups gets the storage groups that associated with this volume.
 func (volume *Volume) StorageGroups() ([]*StorageGroup, error) {
 	var result []*StorageGroup
-
-	collectionError := common.NewCollectionError()
-	for _, sgLink := range volume.storageGroups {
-		sg, err := GetStorageGroup(volume.Client, sgLink)
-		if err != nil {
-			collectionError.Failures[sgLink] = err
-		} else {
-			result = append(result, sg)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	err := common.GetObjects(context.Background(), volume.Client, volume.storageGroups, &result)
+	return result, err
 }
 
 // StoragePools gets the storage pools that associated with this volume.
 func (volume *Volume) StoragePools() ([]*StoragePool, error) {
 	var result []*StoragePool
-
-	collectionError := common.NewCollectionError()
-	for _, sgLink := range volume.allocatedPools {
-		sg, err := GetStoragePool(volume.Client, sgLink)
-		if err != nil {
-			collectionError.Failures[sgLink] = err
-		} else {
-			result = append(result, sg)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	err := common.GetObjects(context.Background(), volume.Client, volume.allocatedPools, &result)
+	return result, err
 }
 
 // AssignReplicaTarget is used to establish a replication relationship by
@@ -720,8 +661,7 @@ func (volume *Volume) AssignReplicaTarget(replicaType ReplicaType, updateMode Re
 		TargetVolume:      targetVolumeODataID,
 	}
 
-	_, err := volume.Client.Post(volume.assignReplicaTargetTarget, t)
-	return err
+	return common.PostAction(context.Background(), &volume.Entity, volume.assignReplicaTargetTarget, t)
 }
 
 // CheckConsistency is used to force a check of the Volume's parity or redundant
@@ -731,8 +671,7 @@ func (volume *Volume) CheckConsistency() error {
 		return fmt.Errorf("CheckConsistency action is not supported by this system")
 	}
 
-	_, err := volume.Client.Post(volume.checkConsistencyTarget, nil)
-	return err
+	return common.PostAction(context.Background(), &volume.Entity, volume.checkConsistencyTarget, nil)
 }
 
 // Initialize is used to prepare the contents of the volume for use by the system.
@@ -749,8 +688,7 @@ func (volume *Volume) Initialize(initType InitializeType) error {
 	// Set the values for the action arguments
 	t := temp{InitializeType: initType}
 
-	_, err := volume.Client.Post(volume.initializeTarget, t)
-	return err
+	return common.PostAction(context.Background(), &volume.Entity, volume.initializeTarget, t)
 }
 
 // RemoveReplicaRelationship is used to disable data synchronization between a
@@ -774,8 +712,7 @@ func (volume *Volume) RemoveReplicaRelationship(deleteTarget bool, targetVolumeO
 		TargetVolume:       targetVolumeODataID,
 	}
 
-	_, err := volume.Client.Post(volume.removeReplicaRelationshipTarget, t)
-	return err
+	return common.PostAction(context.Background(), &volume.Entity, volume.removeReplicaRelationshipTarget, t)
 }
 
 // ResumeReplication is used to resume the active data synchronization between a
@@ -795,8 +732,7 @@ func (volume *Volume) ResumeReplication(targetVolumeODataID string) error {
 	// Set the values for the action arguments
 	t := temp{TargetVolume: targetVolumeODataID}
 
-	_, err := volume.Client.Post(volume.resumeReplicationTarget, t)
-	return err
+	return common.PostAction(context.Background(), &volume.Entity, volume.resumeReplicationTarget, t)
 }
 
 // ReverseReplicationRelationship is used to reverse the replication
@@ -815,8 +751,7 @@ func (volume *Volume) ReverseReplicationRelationship(targetVolumeODataID string)
 	// Set the values for the action arguments
 	t := temp{TargetVolume: targetVolumeODataID}
 
-	_, err := volume.Client.Post(volume.reverseReplicationRelationshipTarget, t)
-	return err
+	return common.PostAction(context.Background(), &volume.Entity, volume.reverseReplicationRelationshipTarget, t)
 }
 
 // SplitReplication is used to split the replication relationship and suspend
@@ -835,8 +770,7 @@ func (volume *Volume) SplitReplication(targetVolumeODataID string) error {
 	// Set the values for the action arguments
 	t := temp{TargetVolume: targetVolumeODataID}
 
-	_, err := volume.Client.Post(volume.splitReplicationTarget, t)
-	return err
+	return common.PostAction(context.Background(), &volume.Entity, volume.splitReplicationTarget, t)
 }
 
 // SuspendReplication is used to suspend active data synchronization between a
@@ -856,6 +790,5 @@ func (volume *Volume) SuspendReplication(targetVolumeODataID string) error {
 	// Set the values for the action arguments
 	t := temp{TargetVolume: targetVolumeODataID}
 
-	_, err := volume.Client.Post(volume.suspendReplicationTarget, t)
-	return err
+	return common.PostAction(context.Background(), &volume.Entity, volume.suspendReplicationTarget, t)
 }
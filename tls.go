@@ -0,0 +1,83 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// PublicKeyPin returns the pin of the public key of cert, as used in
+// ClientConfig.PinnedPublicKeys: the base64 encoded SHA-256 digest of its
+// DER encoded SubjectPublicKeyInfo.
+func PublicKeyPin(cert *x509.Certificate) string {
+	digest := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return base64.StdEncoding.EncodeToString(digest[:])
+}
+
+// newTLSConfig builds the TLS settings of the transport from config.
+func newTLSConfig(config *ClientConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: config.Insecure, //nolint:gosec
+		MinVersion:         config.MinTLSVersion,
+		ServerName:         config.ServerName,
+	}
+
+	rootCAs, err := readPEM(config.RootCAs, config.RootCAsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the CA bundle: %w", err)
+	}
+	if rootCAs != nil {
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(rootCAs) {
+			return nil, errors.New("no certificates found in the CA bundle")
+		}
+	}
+
+	certificate, err := readPEM(config.ClientCertificate, config.ClientCertificateFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the client certificate: %w", err)
+	}
+	key, err := readPEM(config.ClientKey, config.ClientKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the client key: %w", err)
+	}
+	if certificate != nil || key != nil {
+		pair, err := tls.X509KeyPair(certificate, key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{pair}
+	}
+
+	if len(config.PinnedPublicKeys) > 0 {
+		pins := make(map[string]bool, len(config.PinnedPublicKeys))
+		for _, pin := range config.PinnedPublicKeys {
+			pins[pin] = true
+		}
+		tlsConfig.VerifyConnection = func(state tls.ConnectionState) error {
+			if len(state.PeerCertificates) == 0 || !pins[PublicKeyPin(state.PeerCertificates[0])] {
+				return errors.New("the service certificate does not match any pinned public key")
+			}
+			return nil
+		}
+	}
+
+	return tlsConfig, nil
+}
+
+// readPEM returns data, or the content of the file name if data is empty.
+// It returns nil if neither is set.
+func readPEM(data []byte, name string) ([]byte, error) {
+	if len(data) > 0 || name == "" {
+		return data, nil
+	}
+	return os.ReadFile(name)
+}
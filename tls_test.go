@@ -0,0 +1,176 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log"
+	"math/big"
+	"net"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stmcginnis/gofish/mockserver"
+)
+
+// testCertificate is a certificate and its key, PEM encoded.
+type testCertificate struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+// newTestCertificate creates a certificate signed by parent, or self-signed
+// if parent is nil.
+func newTestCertificate(t *testing.T, template *x509.Certificate, parent *testCertificate) *testCertificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Error generating key: %s", err)
+	}
+
+	template.SerialNumber = big.NewInt(time.Now().UnixNano())
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+
+	signer, signerKey := template, key
+	if parent != nil {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatalf("Error creating certificate: %s", err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+
+	return &testCertificate{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+// TestTLSConfig tests trusting a CA, presenting a client certificate and
+// pinning the service certificate.
+func TestTLSConfig(t *testing.T) {
+	ca := newTestCertificate(t, &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "Site CA"},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil)
+	serverCert := newTestCertificate(t, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "bmc.example.com"},
+		DNSNames:    []string{"bmc.example.com"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca)
+	clientCert := newTestCertificate(t, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "admin"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca)
+
+	server, err := mockserver.New("mockserver/testdata/simple")
+	if err != nil {
+		t.Fatalf("Error loading mockup: %s", err)
+	}
+	pair, _ := tls.X509KeyPair(serverCert.certPEM, serverCert.keyPEM)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.cert)
+	ts := httptest.NewUnstartedServer(server)
+	ts.TLS = &tls.Config{
+		Certificates: []tls.Certificate{pair},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+		MaxVersion:   tls.VersionTLS12,
+	}
+	ts.Config.ErrorLog = log.New(io.Discard, "", 0)
+	ts.StartTLS()
+	defer ts.Close()
+
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+	certFile := filepath.Join(dir, "client.pem")
+	keyFile := filepath.Join(dir, "client.key")
+	for name, data := range map[string][]byte{caFile: ca.certPEM, certFile: clientCert.certPEM, keyFile: clientCert.keyPEM} {
+		if err := os.WriteFile(name, data, 0o600); err != nil {
+			t.Fatalf("Error writing %s: %s", name, err)
+		}
+	}
+
+	mutualTLS := ClientConfig{
+		Endpoint:          ts.URL,
+		RootCAs:           ca.certPEM,
+		ClientCertificate: clientCert.certPEM,
+		ClientKey:         clientCert.keyPEM,
+		ServerName:        "bmc.example.com",
+	}
+	tests := []struct {
+		name    string
+		config  func(config *ClientConfig)
+		success bool
+	}{
+		{"mutual TLS", func(config *ClientConfig) {}, true},
+		{"files", func(config *ClientConfig) {
+			config.RootCAs, config.ClientCertificate, config.ClientKey = nil, nil, nil
+			config.RootCAsFile, config.ClientCertificateFile, config.ClientKeyFile = caFile, certFile, keyFile
+		}, true},
+		{"pinned key", func(config *ClientConfig) {
+			config.PinnedPublicKeys = []string{"other", PublicKeyPin(serverCert.cert)}
+		}, true},
+		{"pinned CA key", func(config *ClientConfig) { config.PinnedPublicKeys = []string{PublicKeyPin(ca.cert)} }, false},
+		{"insecure with wrong pin", func(config *ClientConfig) {
+			config.Insecure = true
+			config.PinnedPublicKeys = []string{PublicKeyPin(clientCert.cert)}
+		}, false},
+		{"no client certificate", func(config *ClientConfig) { config.ClientCertificate, config.ClientKey = nil, nil }, false},
+		{"system CAs", func(config *ClientConfig) { config.RootCAs = nil }, false},
+		{"IP address", func(config *ClientConfig) { config.ServerName = "" }, false},
+		{"TLS 1.3", func(config *ClientConfig) { config.MinTLSVersion = tls.VersionTLS13 }, false},
+	}
+	for _, test := range tests {
+		config := mutualTLS
+		test.config(&config)
+		c, err := Connect(config)
+		if test.success && err != nil {
+			t.Errorf("%s: expected to connect, got: %s", test.name, err)
+		}
+		if !test.success && err == nil {
+			t.Errorf("%s: expected the connection to fail", test.name)
+		}
+		if c != nil {
+			c.HTTPClient.CloseIdleConnections()
+		}
+	}
+}
+
+// TestTLSConfigErrors tests invalid TLS settings.
+func TestTLSConfigErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		config ClientConfig
+	}{
+		{"invalid CA bundle", ClientConfig{RootCAs: []byte("not a certificate")}},
+		{"missing CA file", ClientConfig{RootCAsFile: filepath.Join(t.TempDir(), "missing.pem")}},
+		{"certificate without key", ClientConfig{ClientCertificate: []byte("not a certificate")}},
+	}
+	for _, test := range tests {
+		test.config.Endpoint = "https://" + net.JoinHostPort("127.0.0.1", "1")
+		if _, err := Connect(test.config); err == nil {
+			t.Errorf("%s: expected an error", test.name)
+		}
+	}
+}
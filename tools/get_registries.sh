@@ -0,0 +1,54 @@
+#!/bin/bash
+# SPDX-License-Identifier: BSD-3-Clause
+
+# Updates the DMTF standard message registries embedded in the redfish package
+# from the published registry bundle.
+
+# Find the registry document name by going here:
+#
+#     https://www.dmtf.org/standards/redfish
+#
+# Inspect the url for the message registries you want - for example, the
+# 2022.3 update document is "DSP8011_2022.3.zip" on this page. The base name
+# then is:
+registrydoc="DSP8011_2022.3"
+
+# Check if filename provided on the command line
+if [[ "$#" -eq 1 ]]; then
+    registrydoc="${1}"
+fi
+
+# The registries to embed
+registries="Base TaskEvent ResourceEvent Update License"
+
+destination="$(dirname "$0")/../redfish/registries"
+
+# See if we already have this locally or if we need to fetch it
+if [[ ! -d $registrydoc ]]; then
+    if [[ ! -f "${registrydoc}.zip" ]]; then
+        # Use curl instead of wget because it is more likely to be present
+        echo "Fetching registry document $registrydoc"
+        curl -G -L "https://www.dmtf.org/sites/default/files/standards/documents/${registrydoc}.zip" > "${registrydoc}.zip"
+    fi
+
+    echo "Extracting registry files..."
+    unzip -q "${registrydoc}.zip" -d "${registrydoc}"
+fi
+
+for registry in $registries; do
+    files=$(find "$registrydoc" -name "${registry}.[0-9]*.json")
+    if [[ -z "$files" ]]; then
+        echo "Registry $registry not found in $registrydoc"
+        exit 1
+    fi
+
+    # Message IDs only carry the major and minor version of their registry,
+    # so the latest errata of every published minor version is embedded. The
+    # files are copied unmodified.
+    rm -f "${destination}/${registry}".[0-9]*.json
+    for minor in $(basename -a $files | sed -E 's/^(.*\.[0-9]+\.[0-9]+)\.[0-9]+\.json$/\1/' | sort -u); do
+        latest=$(find "$registrydoc" -name "${minor}.[0-9]*.json" | sort -V | tail -n 1)
+        echo "Embedding $(basename "$latest")"
+        cp "$latest" "$destination"
+    done
+done